@@ -1,10 +1,19 @@
 package main
 
 import (
+	"os"
+
 	"github.com/sirupsen/logrus"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			logrus.Fatalf("failed to migrate: %v", err)
+		}
+		return
+	}
+
 	server, err := newServer()
 	if err != nil {
 		logrus.Fatalf("falied to create server: %v", err)
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/db"
+	"hello-cafe/internal/migrate"
+	"hello-cafe/repository/migrations"
+)
+
+const (
+	migrateUsage = `usage: api migrate <command>
+  up           적용되지 않은 migration 을 모두 적용
+  down N       최근에 적용된 migration N 개를 되돌림
+  status       migration 적용 현황
+  baseline [V] 스키마가 이미 있는 DB 에 V(기본 1) 버전까지 적용된 것으로 기록 (SQL 은 실행하지 않음)
+  create NAME  repository/migrations 의 DB 종류별 디렉토리에 새 migration 파일 생성`
+
+	// defaultMigrationDir migrate create 로 파일을 만들 위치 (MIGRATION_DIR 로 변경 가능)
+	defaultMigrationDir = "repository/migrations"
+)
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		return createMigration(args[1])
+	}
+
+	migrator, err := newMigrator()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("applied  %04d_%s\n", m.Version, m.Name)
+		}
+		return errors.WithStack(err)
+	case "down":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return errors.Wrapf(err, "count(%s) is invalid", args[1])
+		}
+
+		reverted, err := migrator.Down(n)
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		return errors.WithStack(err)
+	case "status":
+		return printMigrationStatus(migrator)
+	case "baseline":
+		version := int64(1)
+		if len(args) == 2 {
+			if version, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return errors.Wrapf(err, "version(%s) is invalid", args[1])
+			}
+		} else if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+
+		recorded, err := migrator.Baseline(version)
+		for _, m := range recorded {
+			fmt.Printf("baseline %04d_%s\n", m.Version, m.Name)
+		}
+		return errors.WithStack(err)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+func newMigrator() (*migrate.Migrator, error) {
+	cfg, err := api.Config()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api configuration")
+	}
+
+	conn, err := db.Connect(cfg.DB)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect database")
+	}
+
+	fsys, err := migrations.FS(cfg.DB.Dialect)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return migrate.New(conn, fsys, cfg.Migrate)
+}
+
+func createMigration(name string) error {
+	dir := os.Getenv("MIGRATION_DIR")
+	if dir == "" {
+		dir = defaultMigrationDir
+	}
+
+	dirs := make([]string, 0, len(migrations.Dialects))
+	for _, dialect := range migrations.Dialects {
+		dirs = append(dirs, filepath.Join(dir, dialect))
+	}
+
+	files, err := migrate.Create(name, dirs...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, file := range files {
+		fmt.Printf("created  %s\n", file)
+	}
+
+	return nil
+}
+
+func printMigrationStatus(migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedDT != nil {
+			applied = status.AppliedDT.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+	}
+
+	return w.Flush()
+}
@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"hello-cafe/middleware"
+	"hello-cafe/model"
 
 	"hello-cafe/handler"
 	"hello-cafe/repository"
@@ -18,20 +20,58 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"hello-cafe/internal/api"
 	"hello-cafe/internal/db"
+	"hello-cafe/internal/migrate"
+	"hello-cafe/internal/notify"
+	"hello-cafe/repository/migrations"
+)
+
+const (
+	// logoutTokenSweepInterval 만료된 로그아웃 토큰 정리 주기
+	logoutTokenSweepInterval = 10 * time.Minute
+
+	// itemPurgeInterval 보관 기간이 지난 휴지통 상품 정리 주기
+	itemPurgeInterval = time.Hour
+
+	// itemIndexRebuildInterval 다른 서버에서 바뀐 상품을 반영하기 위해 검색 색인을 다시 만드는 주기
+	itemIndexRebuildInterval = 10 * time.Minute
+
+	// workerShutdownTimeout 서버 종료 후 실행 중인 스케줄러 작업과 정리 작업을 기다리는 최대 시간
+	workerShutdownTimeout = 10 * time.Second
 )
 
 type server struct {
 	ginEngine *gin.Engine
 
-	adminHandler handler.AdminHandler
-	itemHandler  handler.ItemHandler
+	adminHandler     handler.AdminHandler
+	storeHandler     handler.StoreHandler
+	memberHandler    handler.MemberHandler
+	itemHandler      handler.ItemHandler
+	categoryHandler  handler.CategoryHandler
+	optionHandler    handler.ItemOptionHandler
+	stockHandler     handler.StockHandler
+	auditHandler     handler.AuditHandler
+	schedulerHandler handler.SchedulerHandler
 
-	adminService service.AdminService
-	itemService  service.ItemService
+	tokenService     service.TokenService
+	adminService     service.AdminService
+	storeService     service.StoreService
+	memberService    service.MemberService
+	itemService      service.ItemService
+	categoryService  service.CategoryService
+	optionService    service.ItemOptionService
+	stockService     service.StockService
+	auditService     service.AuditService
+	expiryService    service.ExpiryService
+	schedulerService service.SchedulerService
 
+	conn *gorm.DB
 	repo repository.Repository
+
+	queryTimeout  time.Duration
+	operatorToken string
 }
 
 func newServer() (*server, error) {
@@ -41,16 +81,24 @@ func newServer() (*server, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get api configuration")
 	}
+	s.queryTimeout = cfg.QueryTimeout
+	s.operatorToken = cfg.OperatorToken
 
-	if err = db.Connect(cfg.DB); err != nil {
+	if s.conn, err = db.Connect(cfg.DB); err != nil {
 		return nil, errors.Wrap(err, "failed to connect database")
 	}
 
+	if cfg.Migrate.AutoMigrate {
+		if err := s.migrate(cfg.DB.Dialect, cfg.Migrate); err != nil {
+			return nil, errors.Wrap(err, "failed to migrate database")
+		}
+	}
+
 	if err := s.initRepository(); err != nil {
 		return nil, errors.Wrap(err, "failed to init repository")
 	}
 
-	if err := s.initService(); err != nil {
+	if err := s.initService(cfg); err != nil {
 		return nil, errors.Wrap(err, "failed to init service")
 	}
 
@@ -63,19 +111,79 @@ func newServer() (*server, error) {
 	return s, nil
 }
 
+// migrate 여러 서버가 동시에 뜨더라도 lock 을 잡은 한 곳에서만 migration 이 실행된다
+func (s *server) migrate(dialect string, cfg migrate.Config) error {
+	fsys, err := migrations.FS(dialect)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	migrator, err := migrate.New(s.conn, fsys, cfg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
 func (s *server) initRepository() (err error) {
-	if s.repo, err = repository.NewRepository(); err != nil {
+	if s.repo, err = repository.NewRepository(s.conn); err != nil {
 		return errors.WithStack(err)
 	}
 	return nil
 }
 
-func (s *server) initService() (err error) {
-	if s.adminService, err = service.NewAdminService(s.repo); err != nil {
+func (s *server) initService(cfg *api.Configure) (err error) {
+	if s.tokenService, err = service.NewTokenService(s.repo, cfg.JWT); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.adminService, err = service.NewAdminService(s.repo, s.tokenService); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.storeService, err = service.NewStoreService(s.repo); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.memberService, err = service.NewMemberService(s.repo, s.storeService); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.itemService, err = service.NewItemService(s.repo, cfg.Item); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.categoryService, err = service.NewCategoryService(s.repo); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.optionService, err = service.NewItemOptionService(s.repo); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.stockService, err = service.NewStockService(s.repo, cfg.Item); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.auditService, err = service.NewAuditService(s.repo); err != nil {
+		return errors.WithStack(err)
+	}
+
+	notifier, err := notify.New(cfg.Expiry.Notify)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.expiryService, err = service.NewExpiryService(s.itemService, notifier, cfg.Expiry); err != nil {
 		return errors.WithStack(err)
 	}
 
-	if s.itemService, err = service.NewItemService(s.repo); err != nil {
+	if s.schedulerService, err = service.NewSchedulerService(s.expiryService, cfg.Expiry); err != nil {
 		return errors.WithStack(err)
 	}
 
@@ -87,31 +195,126 @@ func (s *server) initHandler() (err error) {
 		return errors.Wrap(err, "failed to create admin handler")
 	}
 
+	if s.storeHandler, err = handler.NewStoreHandler(s.storeService); err != nil {
+		return errors.Wrap(err, "failed to create store handler")
+	}
+
+	if s.memberHandler, err = handler.NewMemberHandler(s.memberService); err != nil {
+		return errors.Wrap(err, "failed to create member handler")
+	}
+
 	if s.itemHandler, err = handler.NewItemHandler(s.itemService); err != nil {
 		return errors.Wrap(err, "failed to create item handler")
 	}
 
+	if s.categoryHandler, err = handler.NewCategoryHandler(s.categoryService); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.optionHandler, err = handler.NewItemOptionHandler(s.optionService); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.stockHandler, err = handler.NewStockHandler(s.stockService); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if s.auditHandler, err = handler.NewAuditHandler(s.auditService); err != nil {
+		return errors.Wrap(err, "failed to create audit handler")
+	}
+
+	if s.schedulerHandler, err = handler.NewSchedulerHandler(s.schedulerService); err != nil {
+		return errors.Wrap(err, "failed to create scheduler handler")
+	}
+
 	return nil
 }
 
 func (s *server) initRoutes() {
-	v1 := s.ginEngine.Group("v1")
+	s.ginEngine.Use(middleware.NewRequestIDMiddleware())
+
+	v1 := s.ginEngine.Group("v1", middleware.NewTimeoutMiddleware(s.queryTimeout))
+	auth := middleware.NewTokenAuthMiddleware(s.tokenService, s.storeService)
+	audit := middleware.NewAuditor(s.auditService)
+
+	// 활성 매장에서의 권한별 허용 범위
+	owner := middleware.RequireRole(model.RoleOwner)
+	manager := middleware.RequireRole(model.RoleOwner, model.RoleManager)
+	staff := middleware.RequireRole(model.RoleOwner, model.RoleManager, model.RoleStaff)
+
+	// 모든 매장의 정보를 다루는 서버 운영 API, 가입하면 누구나 owner 가 되므로 매장 권한으로는 보호할 수 없다
+	operator := middleware.RequireOperator(s.operatorToken)
 
 	{
 		user := v1.Group("/admin")
-		user.POST("/sign-in", s.adminHandler.SignIn)   // 로그인
-		user.POST("/sign-up", s.adminHandler.SignUp)   // 회원가입
-		user.POST("/sign-out", s.adminHandler.SignOut) // 로그아웃
+		user.POST("/sign-in", audit(model.AuditActionSignIn), s.adminHandler.SignIn)    // 로그인
+		user.POST("/sign-up", audit(model.AuditActionSignUp), s.adminHandler.SignUp)    // 회원가입
+		user.POST("/sign-out", audit(model.AuditActionSignOut), s.adminHandler.SignOut) // 로그아웃
+		user.GET("/scheduler", operator, s.schedulerHandler.Status)                     // 스케줄러 작업 상태 조회
+		user.POST("/token/refresh", s.adminHandler.Refresh)                             // 토큰 재발급
+
+		member := user.Group("/members", auth, owner)
+		member.POST("", audit(model.AuditActionMemberInvite), s.memberHandler.Invite)                        // 직원 초대
+		member.GET("", s.memberHandler.Find)                                                                 // 직원 리스트 조회
+		member.PUT("/:admin_seq/role", audit(model.AuditActionMemberUpdateRole), s.memberHandler.UpdateRole) // 직원 권한 변경
+		member.DELETE("/:admin_seq", audit(model.AuditActionMemberDelete), s.memberHandler.Delete)           // 직원 삭제
 	}
 
 	{
-		item := v1.Group("/items", middleware.TokenAuthMiddleware)
-		item.POST("", s.itemHandler.Create)             // 상품 등록
-		item.PUT("/:item_seq", s.itemHandler.Update)    // 상품 수정
-		item.DELETE("/:item_seq", s.itemHandler.Delete) // 상품 삭제
-		item.GET("", s.itemHandler.Find)                // 상품 리스트 조회
-		item.GET("/:item_seq", s.itemHandler.Get)       // 상품 상세 조회
-		item.GET("/search", s.itemHandler.Search)       // 상품 이름 검색
+		store := v1.Group("/stores", auth)
+		store.POST("", s.storeHandler.Create) // 매장 등록
+		store.GET("", s.storeHandler.Find)    // 소속 매장 리스트 조회
+	}
+
+	{
+		item := v1.Group("/items", auth)
+		item.POST("", audit(model.AuditActionItemCreate), manager, s.itemHandler.Create)           // 상품 등록
+		item.PUT("/:item_seq", audit(model.AuditActionItemUpdate), manager, s.itemHandler.Update)  // 상품 수정
+		item.DELETE("/:item_seq", audit(model.AuditActionItemDelete), owner, s.itemHandler.Delete) // 상품 삭제
+		item.GET("", staff, s.itemHandler.Find)                                                    // 상품 리스트 조회
+		item.GET("/:item_seq", staff, s.itemHandler.Get)                                           // 상품 상세 조회
+		item.GET("/search", staff, s.itemHandler.Search)                                           // 상품 이름 검색
+		item.GET("/search/index", owner, s.itemHandler.SearchIndex)                                // 상품 검색 색인 상태
+		item.POST("/import", audit(model.AuditActionItemImport), manager, s.itemHandler.Import)    // 상품 CSV 일괄 등록
+		item.GET("/export", manager, s.itemHandler.Export)                                         // 상품 내보내기
+
+		item.GET("/trash", manager, s.itemHandler.Trash)                                                   // 휴지통 상품 리스트 조회
+		item.POST("/:item_seq/restore", audit(model.AuditActionItemRestore), owner, s.itemHandler.Restore) // 휴지통 상품 복구
+
+		item.GET("/:item_seq/history", owner, s.itemHandler.History) // 상품 변경 이력 조회
+		item.GET("/:item_seq/prices", owner, s.itemHandler.Prices)   // 상품 가격 변경 내역 조회
+
+		item.GET("/low-stock", staff, s.stockHandler.FindLow)                                                               // 재고 부족 상품 리스트 조회
+		item.GET("/:item_seq/stock", staff, s.stockHandler.Get)                                                             // 상품 재고 조회
+		item.PUT("/:item_seq/stock", audit(model.AuditActionStockUpdateThreshold), manager, s.stockHandler.UpdateThreshold) // 재고 부족 기준 변경
+		item.POST("/:item_seq/stock/movements", audit(model.AuditActionStockMove), staff, s.stockHandler.Move)              // 재고 변동 등록
+		item.GET("/:item_seq/stock/movements", manager, s.stockHandler.Movements)                                           // 재고 변동 원장 조회
+
+		item.GET("/expiring", staff, s.stockHandler.Expiring)                                                   // 유통기한 임박 lot 리스트 조회
+		item.GET("/:item_seq/lots", staff, s.stockHandler.Lots)                                                 // 상품 lot 리스트 조회
+		item.POST("/:item_seq/lots", audit(model.AuditActionLotCreate), staff, s.stockHandler.CreateLot)        // lot 입고
+		item.POST("/:item_seq/lots/consume", audit(model.AuditActionLotConsume), staff, s.stockHandler.Consume) // lot 판매, 폐기
+
+		option := item.Group("/:item_seq/options")
+		option.GET("", staff, s.optionHandler.Find)                                                                                       // 상품 옵션 조회
+		option.POST("", audit(model.AuditActionItemOptionCreate), manager, s.optionHandler.CreateGroup)                                   // 옵션 그룹 등록
+		option.PUT("/:option_group_seq", audit(model.AuditActionItemOptionUpdate), manager, s.optionHandler.UpdateGroup)                  // 옵션 그룹 수정
+		option.DELETE("/:option_group_seq", audit(model.AuditActionItemOptionDelete), manager, s.optionHandler.DeleteGroup)               // 옵션 그룹 삭제
+		option.POST("/:option_group_seq/values", audit(model.AuditActionItemOptionCreate), manager, s.optionHandler.Create)               // 옵션 등록
+		option.PUT("/:option_group_seq/values/:option_seq", audit(model.AuditActionItemOptionUpdate), manager, s.optionHandler.Update)    // 옵션 수정
+		option.DELETE("/:option_group_seq/values/:option_seq", audit(model.AuditActionItemOptionDelete), manager, s.optionHandler.Delete) // 옵션 삭제
+	}
+
+	{
+		category := v1.Group("/categories", auth)
+		category.POST("", audit(model.AuditActionCategoryCreate), manager, s.categoryHandler.Create)               // 카테고리 등록
+		category.GET("", staff, s.categoryHandler.Find)                                                            // 카테고리 트리 조회
+		category.PUT("/:category_seq", audit(model.AuditActionCategoryUpdate), manager, s.categoryHandler.Update)  // 카테고리 수정, 이동
+		category.DELETE("/:category_seq", audit(model.AuditActionCategoryDelete), owner, s.categoryHandler.Delete) // 카테고리 삭제
+	}
+
+	{
+		v1.GET("/audit", auth, owner, s.auditHandler.Find) // 감사 기록 조회
 	}
 }
 
@@ -126,6 +329,24 @@ func (s *server) start() {
 		Handler: s.ginEngine,
 	}
 
+	// 만료된 로그아웃 토큰과 휴지통 상품 정리, 상품 검색 색인
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	var sweepers sync.WaitGroup
+	for _, run := range []func(ctx context.Context){
+		func(ctx context.Context) { s.tokenService.RunSweeper(ctx, logoutTokenSweepInterval) },
+		func(ctx context.Context) { s.itemService.RunPurger(ctx, itemPurgeInterval) },
+		func(ctx context.Context) { s.itemService.RunIndexer(ctx, itemIndexRebuildInterval) },
+	} {
+		sweepers.Add(1)
+		go func(run func(ctx context.Context)) {
+			defer sweepers.Done()
+			run(sweeperCtx)
+		}(run)
+	}
+
+	// 유통기한이 지난 상품 판매 중지
+	s.schedulerService.Start(context.Background())
+
 	go func() {
 		// 서비스 접속
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -134,23 +355,57 @@ func (s *server) start() {
 	}()
 
 	// 5초의 타임아웃으로 인해 인터럽트 신호가 서버를 정상종료 할 때까지 기다립니다.
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	// kill (파라미터 없음) 기본값으로 syscanll.SIGTERM를 보냅니다
 	// kill -2 는 syscall.SIGINT를 보냅니다
 	// kill -9 는 syscall.SIGKILL를 보내지만 캐치할수 없으므로, 추가할 필요가 없습니다.
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutdown Server ...")
+	stopSweeper()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server Shutdown:", err)
 	}
-	// 5초의 타임아웃으로 ctx.Done()을 캐치합니다.
+
+	// 실행 중인 스케줄러 작업과 정리 작업, 색인이 DB 를 닫기 전에 끝나도록
+	// 서버 종료에 쓴 시간과 별도로 기다린다
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), workerShutdownTimeout)
+	defer cancelWait()
+
+	stopped := true
+	if err := s.schedulerService.Stop(waitCtx); err != nil {
+		log.Println("Scheduler Stop:", err)
+		stopped = false
+	}
+	if err := waitGroup(waitCtx, &sweepers); err != nil {
+		log.Println("Sweeper Stop:", err)
+		stopped = false
+	}
+
+	// 끝나지 않은 작업이 있으면 DB 를 닫지 않고 프로세스 종료에 맡긴다
+	if stopped {
+		if sqlDB, err := s.conn.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}
+	log.Println("Server exiting")
+}
+
+// waitGroup wg 가 끝나거나 ctx 가 종료될 때까지 기다린다
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
 	select {
+	case <-done:
+		return nil
 	case <-ctx.Done():
-		log.Println("timeout of 5 seconds.")
+		return errors.Wrap(ctx.Err(), "failed to wait running sweepers")
 	}
-	log.Println("Server exiting")
 }
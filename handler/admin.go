@@ -4,6 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/internal/valid"
+	"hello-cafe/middleware"
 	"hello-cafe/model/request"
 	"hello-cafe/model/response"
 	"hello-cafe/service"
@@ -13,6 +17,7 @@ type AdminHandler interface {
 	SignIn(ctx *gin.Context)  // 로그인
 	SignUp(ctx *gin.Context)  // 회원가입
 	SignOut(ctx *gin.Context) // 로그아웃
+	Refresh(ctx *gin.Context) // 토큰 재발급
 }
 
 type adminHandler struct {
@@ -32,18 +37,30 @@ func (h *adminHandler) SignIn(ctx *gin.Context) {
 		return
 	}
 
+	if !valid.IsNil(req.Phone) {
+		middleware.SetAuditTarget(ctx, "phone="+*req.Phone)
+	}
+
 	if err := req.Validate(); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	token, err := h.adminService.SignIn(*req.Phone, *req.Password)
+	token, err := h.adminService.SignIn(ctx.Request.Context(), *req.Phone, *req.Password)
 	if err != nil {
+		// 비밀번호가 틀려 실패한 로그인도 전화번호로 찾은 관리자를 기록한다
+		var signInErr *service.SignInError
+		if errors.As(err, &signInErr) {
+			middleware.SetAuditActor(ctx, signInErr.AdminSeq)
+		}
+
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	ctx.JSON(response.Success(gin.H{"token": token}))
+	middleware.SetAuditActor(ctx, token.AdminSeq)
+
+	ctx.JSON(response.Success(token))
 }
 
 func (h *adminHandler) SignUp(ctx *gin.Context) {
@@ -53,16 +70,23 @@ func (h *adminHandler) SignUp(ctx *gin.Context) {
 		return
 	}
 
+	if !valid.IsNil(req.Phone) {
+		middleware.SetAuditTarget(ctx, "phone="+*req.Phone)
+	}
+
 	if err := req.Validate(); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	if err := h.adminService.SignUp(*req.Phone, *req.Password, req.Name); err != nil {
+	adminSeq, err := h.adminService.SignUp(ctx.Request.Context(), *req.Phone, *req.Password, req.Name)
+	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
+	middleware.SetAuditActor(ctx, adminSeq)
+
 	ctx.JSON(response.SimpleSuccess(http.StatusOK))
 }
 
@@ -78,10 +102,37 @@ func (h *adminHandler) SignOut(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.adminService.SignOut(*req.Phone, *req.Token); err != nil {
+	// 로그아웃은 인증 middleware 를 거치지 않으므로 토큰에서 관리자를 확인한다
+	middleware.SetAuditTarget(ctx, "phone="+*req.Phone)
+	if claims, err := internaljwt.ParseJWT(*req.Token); err == nil {
+		middleware.SetAuditActor(ctx, claims.AdminSeq)
+	}
+
+	if err := h.adminService.SignOut(ctx.Request.Context(), *req.Phone, *req.Token, req.RefreshToken); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
 	ctx.JSON(response.SimpleSuccess(http.StatusOK))
 }
+
+func (h *adminHandler) Refresh(ctx *gin.Context) {
+	req := request.RefreshToken{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	token, err := h.adminService.RefreshToken(ctx.Request.Context(), *req.RefreshToken)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(token))
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/middleware"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository/memory"
+	"hello-cafe/service"
+)
+
+type recordingAuditService struct {
+	logs model.AuditLogs
+}
+
+func (s *recordingAuditService) Record(ctx context.Context, log model.AuditLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *recordingAuditService) Find(ctx context.Context, storeSeq int64, req request.FindAudit) (*model.AuditLogPage, error) {
+	return &model.AuditLogPage{Logs: s.logs}, nil
+}
+
+func Test_adminHandler_auditActor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+		wantSeq  int64 // 기존 관리자는 1, 새로 가입한 관리자는 2
+	}{
+		{name: "로그인 성공", path: "/sign-in", body: `{"phone": "010-1234-1111", "password": "12341234"}`, wantCode: http.StatusOK, wantSeq: 1},
+		{name: "로그인 실패(비밀번호)", path: "/sign-in", body: `{"phone": "010-1234-1111", "password": "43214321"}`, wantCode: http.StatusUnauthorized, wantSeq: 1},
+		{name: "로그인 실패(없는 관리자)", path: "/sign-in", body: `{"phone": "010-9999-9999", "password": "12341234"}`, wantCode: http.StatusUnauthorized, wantSeq: 0},
+		{name: "회원가입", path: "/sign-up", body: `{"phone": "010-1234-2222", "password": "12341234", "name": "임꺽정"}`, wantCode: http.StatusOK, wantSeq: 2},
+		{name: "회원가입 실패(중복)", path: "/sign-up", body: `{"phone": "010-1234-1111", "password": "12341234", "name": "홍길동"}`, wantCode: http.StatusBadRequest, wantSeq: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+
+			tokenService, err := service.NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			adminService, err := service.NewAdminService(repo, tokenService)
+			require.NoError(t, err)
+			_, err = adminService.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+			require.NoError(t, err)
+
+			h, err := NewAdminHandler(adminService)
+			require.NoError(t, err)
+
+			auditService := &recordingAuditService{}
+			audit := middleware.NewAuditor(auditService)
+
+			engine := gin.New()
+			engine.POST("/sign-in", audit(model.AuditActionSignIn), h.SignIn)
+			engine.POST("/sign-up", audit(model.AuditActionSignUp), h.SignUp)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+
+			require.Len(t, auditService.logs, 1)
+			if got := auditService.logs[0].AdminSeq; got != tt.wantSeq {
+				t.Errorf("audit admin_seq = %v, want %v", got, tt.wantSeq)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type AuditHandler interface {
+	Find(ctx *gin.Context) // 감사 기록 조회
+}
+
+type auditHandler struct {
+	auditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) (AuditHandler, error) {
+	return &auditHandler{
+		auditService: auditService,
+	}, nil
+}
+
+func (h *auditHandler) Find(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindAudit{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidPeriod.SetInternal(err)))
+		return
+	}
+
+	page, err := h.auditService.Find(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(page))
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/valid"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type CategoryHandler interface {
+	Create(ctx *gin.Context) // 카테고리 등록
+	Find(ctx *gin.Context)   // 카테고리 트리 조회
+	Update(ctx *gin.Context) // 카테고리 수정, 이동
+	Delete(ctx *gin.Context) // 카테고리 삭제
+}
+
+type categoryHandler struct {
+	categoryService service.CategoryService
+}
+
+func NewCategoryHandler(categoryService service.CategoryService) (CategoryHandler, error) {
+	return &categoryHandler{
+		categoryService: categoryService,
+	}, nil
+}
+
+func (h *categoryHandler) Create(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateCategory{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if !valid.IsNil(req.Name) {
+		middleware.SetAuditTarget(ctx, "name="+*req.Name)
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	category, err := h.categoryService.Create(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(category))
+}
+
+func (h *categoryHandler) Find(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	categories, err := h.categoryService.Find(ctx.Request.Context(), principal.StoreSeq)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(categories))
+}
+
+func (h *categoryHandler) Update(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.UpdateCategory{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	category, err := h.categoryService.Update(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(category))
+}
+
+func (h *categoryHandler) Delete(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.DeleteCategory{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := h.categoryService.Delete(ctx.Request.Context(), principal.StoreSeq, req.CategorySeq); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/service"
+)
+
+// itemETag 상품 버전을 ETag 로 표현한다
+func itemETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// parseIfMatch If-Match 헤더의 ETag 에서 버전을 읽는다.
+// If-Match 는 strong 비교만 허용하므로 weak ETag(W/) 는 거부하고, * 는 현재 버전과 상관없이 변경하는 것으로 본다
+func parseIfMatch(header string) (version int64, matchAny bool, err error) {
+	tag := strings.TrimSpace(header)
+	switch {
+	case tag == "*":
+		return 0, true, nil
+	case strings.HasPrefix(tag, "W/"):
+		return 0, false, apierror.ErrWeakItemETag
+	case len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`):
+		return 0, false, apierror.ErrInvalidItemVersion
+	}
+
+	version, err = strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false, apierror.ErrInvalidItemVersion
+	}
+
+	return version, false, nil
+}
+
+// bindIfMatch If-Match 헤더가 있으면 version 으로 사용한다. 요청의 version 과 다르면 잘못된 요청이다.
+// If-Match 가 * 이면 matchAny 를 true 로 반환하며, 요청에 version 이 있으면 그 버전을 사용한다
+func bindIfMatch(ctx *gin.Context, version **int64) (matchAny bool, err error) {
+	header := ctx.GetHeader("If-Match")
+	if header == "" {
+		return false, nil
+	}
+
+	ifMatch, matchAny, err := parseIfMatch(header)
+	if err != nil || matchAny {
+		return matchAny, err
+	}
+
+	if !valid.IsNil(*version) && **version != ifMatch {
+		return false, apierror.ErrInvalidItemVersion
+	}
+
+	*version = &ifMatch
+
+	return false, nil
+}
+
+// anyVersion If-Match: * 이고 요청에 version 이 없으면 현재 버전과 상관없이 변경한다
+func anyVersion(matchAny bool, version **int64) {
+	if matchAny && valid.IsNil(*version) {
+		v := service.AnyItemVersion
+		*version = &v
+	}
+}
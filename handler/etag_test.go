@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+	"hello-cafe/repository/memory"
+	"hello-cafe/service"
+)
+
+func Test_parseIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int64
+		wantAny bool
+		wantErr bool
+	}{
+		{
+			name:   "strong ETag",
+			header: `"3"`,
+			want:   3,
+		},
+		{
+			name:    "weak ETag 는 strong 비교에 사용할 수 없다",
+			header:  `W/"3"`,
+			wantErr: true,
+		},
+		{
+			name:    "따옴표 누락",
+			header:  `3`,
+			wantErr: true,
+		},
+		{
+			name:    "숫자가 아닌 버전",
+			header:  `"abc"`,
+			wantErr: true,
+		},
+		{
+			name:    "0 버전",
+			header:  `"0"`,
+			wantErr: true,
+		},
+		{
+			name:    "모든 버전(*)",
+			header:  `*`,
+			wantAny: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotAny, err := parseIfMatch(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseIfMatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseIfMatch() got = %v, want %v", got, tt.want)
+			}
+			if gotAny != tt.wantAny {
+				t.Errorf("parseIfMatch() gotAny = %v, want %v", gotAny, tt.wantAny)
+			}
+		})
+	}
+}
+
+func Test_itemHandler_ifMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		method   string
+		ifMatch  string
+		wantCode int
+		wantETag string
+	}{
+		{name: "수정(현재 버전)", method: http.MethodPut, ifMatch: `"1"`, wantCode: http.StatusOK},
+		{name: "수정(모든 버전)", method: http.MethodPut, ifMatch: `*`, wantCode: http.StatusOK},
+		{name: "수정(이전 버전)", method: http.MethodPut, ifMatch: `"2"`, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+		{name: "수정(weak ETag)", method: http.MethodPut, ifMatch: `W/"1"`, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+		{name: "삭제(현재 버전)", method: http.MethodDelete, ifMatch: `"1"`, wantCode: http.StatusOK},
+		{name: "삭제(모든 버전)", method: http.MethodDelete, ifMatch: `*`, wantCode: http.StatusOK},
+		{name: "삭제(이전 버전)", method: http.MethodDelete, ifMatch: `"2"`, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+		{name: "삭제(weak ETag)", method: http.MethodDelete, ifMatch: `W/"1"`, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, auth, accessToken := newTestAuth(t)
+
+			itemService, err := service.NewItemService(repo, api.ItemConfig{})
+			require.NoError(t, err)
+
+			category, err := dao.NewCategory(1, 0, "테스트", 1)
+			require.NoError(t, err)
+
+			created, err := repo.Category().Create(ctx, *category)
+			require.NoError(t, err)
+
+			require.NoError(t, itemService.Create(ctx, 1, 1, newTestItem(created.CategorySeq)))
+
+			h, err := NewItemHandler(itemService)
+			require.NoError(t, err)
+
+			engine := gin.New()
+			engine.PUT("/items/:item_seq", auth, h.Update)
+			engine.DELETE("/items/:item_seq", auth, h.Delete)
+
+			var body *strings.Reader
+			if tt.method == http.MethodPut {
+				body = strings.NewReader(`{"price": 5000}`)
+			} else {
+				body = strings.NewReader("")
+			}
+
+			req := httptest.NewRequest(tt.method, "/items/1", body)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("access-token", accessToken)
+			req.Header.Set("If-Match", tt.ifMatch)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %v, want %v", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+// newTestAuth 사장 한 명이 가입한 저장소와 인증 middleware, access token 을 만든다
+func newTestAuth(t *testing.T) (repository.Repository, gin.HandlerFunc, string) {
+	t.Helper()
+
+	ctx := context.Background()
+	repo := memory.NewRepository()
+
+	tokenService, err := service.NewTokenService(repo, internaljwt.Config{})
+	require.NoError(t, err)
+
+	storeService, err := service.NewStoreService(repo)
+	require.NoError(t, err)
+
+	adminService, err := service.NewAdminService(repo, tokenService)
+	require.NoError(t, err)
+	_, err = adminService.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+	require.NoError(t, err)
+
+	token, err := adminService.SignIn(ctx, "010-1234-1111", "12341234")
+	require.NoError(t, err)
+
+	return repo, middleware.NewTokenAuthMiddleware(tokenService, storeService), token.AccessToken
+}
+
+func newTestItem(category int64) request.CreateItem {
+	barcode, name, description := "1", "아메리카노", "설명"
+	price, cost := int64(4500), int64(1500)
+	size := request.ItemSizeSmall
+	expireDT := time.Now().Add(24 * time.Hour)
+
+	return request.CreateItem{
+		Category:    &category,
+		Barcode:     &barcode,
+		Price:       &price,
+		Cost:        &cost,
+		Name:        &name,
+		Description: &description,
+		ExpireDT:    &expireDT,
+		Size:        &size,
+	}
+}
@@ -1,25 +1,41 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/middleware"
+	"hello-cafe/model"
 	"hello-cafe/model/request"
 	"hello-cafe/model/response"
 	"hello-cafe/service"
 )
 
 type ItemHandler interface {
-	Create(ctx *gin.Context) // 상품 등록
-	Update(ctx *gin.Context) // 상품 수정
-	Delete(ctx *gin.Context) // 상품 삭제
-	Find(ctx *gin.Context)   // 상품 리스트 조회
-	Get(ctx *gin.Context)    // 상품 상세
-	Search(ctx *gin.Context) // 상품 이름
+	Create(ctx *gin.Context)      // 상품 등록
+	Update(ctx *gin.Context)      // 상품 수정
+	Delete(ctx *gin.Context)      // 상품 삭제
+	Find(ctx *gin.Context)        // 상품 리스트 조회
+	Get(ctx *gin.Context)         // 상품 상세
+	Search(ctx *gin.Context)      // 상품 이름
+	SearchIndex(ctx *gin.Context) // 상품 검색 색인 상태
+	Trash(ctx *gin.Context)       // 휴지통 상품 리스트
+	Restore(ctx *gin.Context)     // 휴지통 상품 복구
+	History(ctx *gin.Context)     // 상품 변경 이력
+	Prices(ctx *gin.Context)      // 상품 가격 변경 내역
+	Import(ctx *gin.Context)      // 상품 CSV 일괄 등록
+	Export(ctx *gin.Context)      // 상품 내보내기
 }
 
+// maxItemImportSize CSV 파일 최대 크기
+const maxItemImportSize = 5 << 20
+
 type itemHandler struct {
 	itemService service.ItemService
 }
@@ -31,18 +47,28 @@ func NewItemHandler(itemService service.ItemService) (ItemHandler, error) {
 }
 
 func (h *itemHandler) Create(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
 	req := request.CreateItem{}
 	if err := ctx.ShouldBind(&req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
+	if !valid.IsNil(req.Barcode) {
+		middleware.SetAuditTarget(ctx, "barcode="+*req.Barcode)
+	}
+
 	if err := req.Validate(); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	if err := h.itemService.Create(req); err != nil {
+	if err := h.itemService.Create(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
@@ -51,6 +77,12 @@ func (h *itemHandler) Create(ctx *gin.Context) {
 }
 
 func (h *itemHandler) Update(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
 	req := request.UpdateItem{}
 	if err := ctx.ShouldBind(&req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
@@ -62,13 +94,21 @@ func (h *itemHandler) Update(ctx *gin.Context) {
 		return
 	}
 
+	anyMatch, err := bindIfMatch(ctx, &req.Version)
+	if err != nil {
+		h.abortWithCurrent(ctx, principal.StoreSeq, req.ItemSeq, err)
+		return
+	}
+
 	if err := req.Validate(); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	if err := h.itemService.Update(req); err != nil {
-		ctx.AbortWithStatusJSON(response.Failure(err))
+	anyVersion(anyMatch, &req.Version)
+
+	if err := h.itemService.Update(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req); err != nil {
+		h.abortWithCurrent(ctx, principal.StoreSeq, req.ItemSeq, err)
 		return
 	}
 
@@ -76,33 +116,166 @@ func (h *itemHandler) Update(ctx *gin.Context) {
 }
 
 func (h *itemHandler) Delete(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
 	req := request.DeleteItem{}
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	if err := h.itemService.Delete(req.ItemSeq); err != nil {
+	anyMatch, err := bindIfMatch(ctx, &req.Version)
+	if err != nil {
+		h.abortWithCurrent(ctx, principal.StoreSeq, req.ItemSeq, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
+	anyVersion(anyMatch, &req.Version)
+
+	if valid.IsNil(req.Version) {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrNilItemVersion))
+		return
+	}
+
+	if err := h.itemService.Delete(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req.ItemSeq, *req.Version); err != nil {
+		h.abortWithCurrent(ctx, principal.StoreSeq, req.ItemSeq, err)
+		return
+	}
+
 	ctx.JSON(response.SimpleSuccess(http.StatusOK))
 }
 
 func (h *itemHandler) Find(ctx *gin.Context) {
-	queryAdminSeq := ctx.Query("admin_seq")
-	if queryAdminSeq == "" {
-		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAdmin))
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindItems{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidItemQuery.SetInternal(err)))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	page, err := h.itemService.Find(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SuccessWithPagination(page.Items, page.Pagination))
+}
+
+func (h *itemHandler) Get(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.GetItem{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	item, err := h.itemService.Get(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.Header("ETag", itemETag(item.Version))
+	ctx.JSON(response.Success(item))
+}
+
+// abortWithCurrent 버전 조건이 맞지 않아 실패한 경우(412) 현재 상품 정보와 ETag 를 함께 내려주어 다시 시도할 수 있게 한다
+func (h *itemHandler) abortWithCurrent(ctx *gin.Context, storeSeq, itemSeq int64, err error) {
+	if !errors.Is(err, apierror.ErrStaleItem) && !errors.Is(err, apierror.ErrWeakItemETag) {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	item, getErr := h.itemService.Get(ctx.Request.Context(), storeSeq, request.GetItem{ItemSeq: itemSeq})
+	if getErr != nil {
+		ctx.AbortWithStatusJSON(response.Failure(getErr))
+		return
+	}
+
+	ctx.Header("ETag", itemETag(item.Version))
+	ctx.AbortWithStatusJSON(response.FailureWithData(err, item))
+}
+
+func (h *itemHandler) Search(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.SearchItems{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidItemQuery.SetInternal(err)))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	page, err := h.itemService.Search(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SuccessWithPagination(page.Items, page.Pagination))
+}
+
+// SearchIndex 매장의 검색 색인 크기와 서버가 마지막으로 색인을 다시 만든 시간
+func (h *itemHandler) SearchIndex(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	adminSeq, err := strconv.ParseInt(queryAdminSeq, 10, 64)
+	ctx.JSON(response.Success(h.itemService.IndexStats(principal.StoreSeq)))
+}
+
+func (h *itemHandler) Trash(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
 	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
@@ -124,7 +297,7 @@ func (h *itemHandler) Find(ctx *gin.Context) {
 		return
 	}
 
-	items, err := h.itemService.Find(adminSeq, lastItemSeq, limit)
+	items, err := h.itemService.FindTrash(ctx.Request.Context(), principal.StoreSeq, lastItemSeq, limit)
 	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
@@ -133,8 +306,14 @@ func (h *itemHandler) Find(ctx *gin.Context) {
 	ctx.JSON(response.Success(items))
 }
 
-func (h *itemHandler) Get(ctx *gin.Context) {
-	req := request.GetItem{}
+func (h *itemHandler) Restore(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.RestoreItem{}
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
@@ -145,39 +324,176 @@ func (h *itemHandler) Get(ctx *gin.Context) {
 		return
 	}
 
-	item, err := h.itemService.Get(req.ItemSeq)
+	if err := h.itemService.Restore(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req.ItemSeq); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
+
+func (h *itemHandler) History(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
 	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	ctx.JSON(response.Success(item))
+	req := request.FindItemHistory{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	revisions, err := h.itemService.History(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(revisions))
 }
 
-func (h *itemHandler) Search(ctx *gin.Context) {
-	queryAdminSeq := ctx.Query("admin_seq")
-	if queryAdminSeq == "" {
-		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAdmin))
+func (h *itemHandler) Prices(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindItemPrices{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidPeriod.SetInternal(err)))
+		return
+	}
+
+	prices, err := h.itemService.Prices(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(prices))
+}
+
+func (h *itemHandler) Import(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.ImportItems{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	adminSeq, err := strconv.ParseInt(queryAdminSeq, 10, 64)
+	// multipart 의 file 또는 text/csv body 를 받는다
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxItemImportSize)
+	var file io.Reader = ctx.Request.Body
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidImportFile.SetInternal(err)))
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			ctx.AbortWithStatusJSON(response.Failure(errors.WithStack(err)))
+			return
+		}
+		defer f.Close()
+		file = f
+	}
+
+	rows, err := request.ParseItemCSV(file)
 	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
+	req.Rows = rows
+
+	middleware.SetAuditTarget(ctx, fmt.Sprintf("rows=%d,mode=%s,dry_run=%t", len(rows), req.Mode, req.DryRun))
 
-	queryText := ctx.Query("text")
-	if queryText == "" {
-		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrNilSearchText))
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	items, err := h.itemService.Search(adminSeq, queryText)
+	result, err := h.itemService.Import(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req)
 	if err != nil {
 		ctx.AbortWithStatusJSON(response.Failure(err))
 		return
 	}
 
-	ctx.JSON(response.Success(items))
+	ctx.JSON(response.Success(result))
+}
+
+func (h *itemHandler) Export(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.ExportItems{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	// 매장 확인이 끝나고 첫 상품을 받은 뒤에 응답 header 를 쓴다
+	var exporter itemExporter
+	start := func() error {
+		ctx.Header("Content-Type", itemExportContentTypes[req.Format])
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="items.%s"`, req.Format))
+		ctx.Status(http.StatusOK)
+
+		exporter, err = newItemExporter(ctx.Writer, req)
+		return err
+	}
+
+	err = h.itemService.Export(ctx.Request.Context(), principal.StoreSeq, func(item model.Item) error {
+		if exporter == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		return exporter.Write(item)
+	})
+	if err == nil && exporter == nil {
+		err = start()
+	}
+	if err == nil {
+		err = exporter.Close()
+	}
+
+	if err != nil {
+		if ctx.Writer.Written() {
+			// 이미 출력을 시작했으므로 오류 응답을 보낼 수 없다
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.AbortWithStatusJSON(response.Failure(err))
+	}
 }
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/export"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+// itemExportColumns csv, xlsx 의 컬럼, jsonl 은 model.Item 을 그대로 출력한다
+var itemExportColumns = []struct {
+	name  string
+	ko    string
+	value func(item model.Item) any
+}{
+	{name: "item_seq", ko: "상품번호", value: func(item model.Item) any { return item.ItemSeq }},
+	{name: "category", ko: "카테고리", value: func(item model.Item) any { return item.Category }},
+	{name: "barcode", ko: "바코드", value: func(item model.Item) any { return item.Barcode }},
+	{name: "name", ko: "상품명", value: func(item model.Item) any { return item.Name }},
+	{name: "consonant", ko: "초성", value: func(item model.Item) any { return item.Consonant }},
+	{name: "description", ko: "설명", value: func(item model.Item) any { return item.Description }},
+	{name: "size", ko: "사이즈", value: func(item model.Item) any { return item.Size }},
+	{name: "price", ko: "가격", value: func(item model.Item) any { return item.Price }},
+	{name: "cost", ko: "원가", value: func(item model.Item) any { return item.Cost }},
+	{name: "margin", ko: "마진", value: func(item model.Item) any { return item.Margin }},
+	{name: "expire_dt", ko: "유통기한", value: func(item model.Item) any { return item.ExpireDT }},
+	{name: "version", ko: "버전", value: func(item model.Item) any { return item.Version }},
+	{name: "reg_dt", ko: "등록일시", value: func(item model.Item) any { return item.RegDT }},
+	{name: "mod_dt", ko: "수정일시", value: func(item model.Item) any { return item.ModDT }},
+}
+
+var itemExportContentTypes = map[request.ItemExportFormat]string{
+	request.ItemExportCSV:   "text/csv; charset=utf-8",
+	request.ItemExportJSONL: "application/x-ndjson; charset=utf-8",
+	request.ItemExportXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// itemExporter 상품을 한 건씩 형식에 맞게 출력한다
+type itemExporter interface {
+	Write(item model.Item) error
+	Close() error
+}
+
+func newItemExporter(w io.Writer, req request.ExportItems) (itemExporter, error) {
+	if req.Format == request.ItemExportJSONL {
+		return &jsonlItemExporter{encoder: json.NewEncoder(w)}, nil
+	}
+
+	header := make([]string, len(itemExportColumns))
+	for i, column := range itemExportColumns {
+		header[i] = column.name
+		if req.Header == "ko" {
+			header[i] = column.ko
+		}
+	}
+
+	var (
+		table export.TableWriter
+		err   error
+	)
+	if req.Format == request.ItemExportXLSX {
+		table, err = export.NewXLSXWriter(w, "items", header)
+	} else {
+		table, err = export.NewCSVWriter(w, header, *req.BOM)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &tableItemExporter{table: table}, nil
+}
+
+type tableItemExporter struct {
+	table export.TableWriter
+}
+
+func (e *tableItemExporter) Write(item model.Item) error {
+	cells := make([]any, len(itemExportColumns))
+	for i, column := range itemExportColumns {
+		cells[i] = column.value(item)
+	}
+
+	return e.table.WriteRow(cells)
+}
+
+func (e *tableItemExporter) Close() error {
+	return e.table.Close()
+}
+
+type jsonlItemExporter struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlItemExporter) Write(item model.Item) error {
+	return errors.WithStack(e.encoder.Encode(item))
+}
+
+func (e *jsonlItemExporter) Close() error {
+	return nil
+}
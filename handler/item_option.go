@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type ItemOptionHandler interface {
+	Find(ctx *gin.Context)        // 상품 옵션 조회
+	CreateGroup(ctx *gin.Context) // 옵션 그룹 등록
+	UpdateGroup(ctx *gin.Context) // 옵션 그룹 수정
+	DeleteGroup(ctx *gin.Context) // 옵션 그룹 삭제
+	Create(ctx *gin.Context)      // 옵션 등록
+	Update(ctx *gin.Context)      // 옵션 수정
+	Delete(ctx *gin.Context)      // 옵션 삭제
+}
+
+type itemOptionHandler struct {
+	itemOptionService service.ItemOptionService
+}
+
+func NewItemOptionHandler(itemOptionService service.ItemOptionService) (ItemOptionHandler, error) {
+	return &itemOptionHandler{
+		itemOptionService: itemOptionService,
+	}, nil
+}
+
+func (h *itemOptionHandler) Find(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.GetItem{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	groups, err := h.itemOptionService.Find(ctx.Request.Context(), principal.StoreSeq, req.ItemSeq)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(groups))
+}
+
+func (h *itemOptionHandler) CreateGroup(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateItemOptionGroup{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	group, err := h.itemOptionService.CreateGroup(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(group))
+}
+
+func (h *itemOptionHandler) UpdateGroup(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.UpdateItemOptionGroup{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	group, err := h.itemOptionService.UpdateGroup(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(group))
+}
+
+func (h *itemOptionHandler) DeleteGroup(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.DeleteItemOptionGroup{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := h.itemOptionService.DeleteGroup(ctx.Request.Context(), principal.StoreSeq, req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
+
+func (h *itemOptionHandler) Create(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateItemOption{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	option, err := h.itemOptionService.Create(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(option))
+}
+
+func (h *itemOptionHandler) Update(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.UpdateItemOption{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	option, err := h.itemOptionService.Update(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(option))
+}
+
+func (h *itemOptionHandler) Delete(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.DeleteItemOption{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := h.itemOptionService.Delete(ctx.Request.Context(), principal.StoreSeq, req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type StockHandler interface {
+	Get(ctx *gin.Context)             // 상품 재고 조회
+	UpdateThreshold(ctx *gin.Context) // 재고 부족 기준 변경
+	FindLow(ctx *gin.Context)         // 재고 부족 상품 리스트
+	Move(ctx *gin.Context)            // 재고 변동 등록
+	Movements(ctx *gin.Context)       // 재고 변동 원장 조회
+	CreateLot(ctx *gin.Context)       // lot 입고
+	Lots(ctx *gin.Context)            // 상품 lot 리스트
+	Consume(ctx *gin.Context)         // lot 차감
+	Expiring(ctx *gin.Context)        // 유통기한 임박 lot 리스트
+}
+
+type stockHandler struct {
+	stockService service.StockService
+}
+
+func NewStockHandler(stockService service.StockService) (StockHandler, error) {
+	return &stockHandler{
+		stockService: stockService,
+	}, nil
+}
+
+func (h *stockHandler) Get(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.GetItemStock{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	stock, err := h.stockService.Get(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(stock))
+}
+
+func (h *stockHandler) UpdateThreshold(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.UpdateItemStock{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	stock, err := h.stockService.UpdateThreshold(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(stock))
+}
+
+func (h *stockHandler) FindLow(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindLowStock{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	items, err := h.stockService.FindLow(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(items))
+}
+
+// Move 판매, 입고, 폐기는 staff 도 등록할 수 있고, 조정과 이동은 manager 이상만 할 수 있다
+func (h *stockHandler) Move(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateStockMovement{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	movements, err := h.stockService.Move(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(movements))
+}
+
+func (h *stockHandler) Movements(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindStockMovements{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	movements, err := h.stockService.Movements(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(movements))
+}
+
+func (h *stockHandler) CreateLot(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateItemLot{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	lot, err := h.stockService.CreateLot(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(lot))
+}
+
+func (h *stockHandler) Lots(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindItemLots{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	lots, err := h.stockService.Lots(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(lots))
+}
+
+func (h *stockHandler) Consume(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.ConsumeItemLots{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	movements, err := h.stockService.Consume(ctx.Request.Context(), principal.StoreSeq, principal.AdminSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(movements))
+}
+
+func (h *stockHandler) Expiring(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.FindExpiringLots{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidPeriod.SetInternal(err)))
+		return
+	}
+
+	lots, err := h.stockService.Expiring(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(lots))
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/api"
+	"hello-cafe/service"
+)
+
+func Test_stockHandler_Expiring(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+	}{
+		{name: "기본 기간", query: "", wantCode: http.StatusOK},
+		{name: "96시간", query: "?within=96h", wantCode: http.StatusOK},
+		{name: "기간 형식 오류", query: "?within=abc", wantCode: http.StatusBadRequest},
+		{name: "단위 누락", query: "?within=96", wantCode: http.StatusBadRequest},
+		{name: "음수 기간", query: "?within=-1h", wantCode: http.StatusBadRequest},
+		{name: "최대 기간 초과", query: "?within=745h", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, auth, accessToken := newTestAuth(t)
+
+			stockService, err := service.NewStockService(repo, api.ItemConfig{})
+			require.NoError(t, err)
+
+			h, err := NewStockHandler(stockService)
+			require.NoError(t, err)
+
+			engine := gin.New()
+			engine.GET("/items/expiring", auth, h.Expiring)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/expiring"+tt.query, nil)
+			req.Header.Set("access-token", accessToken)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/valid"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type MemberHandler interface {
+	Invite(ctx *gin.Context)     // 직원 초대
+	Find(ctx *gin.Context)       // 직원 리스트 조회
+	UpdateRole(ctx *gin.Context) // 직원 권한 변경
+	Delete(ctx *gin.Context)     // 직원 삭제
+}
+
+type memberHandler struct {
+	memberService service.MemberService
+}
+
+func NewMemberHandler(memberService service.MemberService) (MemberHandler, error) {
+	return &memberHandler{
+		memberService: memberService,
+	}, nil
+}
+
+func (h *memberHandler) Invite(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.InviteMember{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if !valid.IsNil(req.Phone) {
+		middleware.SetAuditTarget(ctx, "phone="+*req.Phone)
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	invited, err := h.memberService.Invite(ctx.Request.Context(), principal.StoreSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(invited))
+}
+
+func (h *memberHandler) Find(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	members, err := h.memberService.Find(ctx.Request.Context(), principal.StoreSeq)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(members))
+}
+
+func (h *memberHandler) UpdateRole(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.UpdateMemberRole{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	middleware.SetAuditTarget(ctx, fmt.Sprintf("admin_seq=%d,role=%s", req.AdminSeq, *req.Role))
+
+	if err := h.memberService.UpdateRole(ctx.Request.Context(), principal.StoreSeq, req.AdminSeq, *req.Role); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
+
+func (h *memberHandler) Delete(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.DeleteMember{}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := h.memberService.Delete(ctx.Request.Context(), principal.StoreSeq, req.AdminSeq); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.SimpleSuccess(http.StatusOK))
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type SchedulerHandler interface {
+	Status(ctx *gin.Context) // 스케줄러 작업 상태
+}
+
+type schedulerHandler struct {
+	schedulerService service.SchedulerService
+}
+
+func NewSchedulerHandler(schedulerService service.SchedulerService) (SchedulerHandler, error) {
+	return &schedulerHandler{
+		schedulerService: schedulerService,
+	}, nil
+}
+
+// Status 서버에서 실행 중인 작업의 상태와 마지막 실행 결과, 매장과 관계없이 서버 전체의 작업이다
+func (h *schedulerHandler) Status(ctx *gin.Context) {
+	ctx.JSON(response.Success(h.schedulerService.Status()))
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"hello-cafe/middleware"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+	"hello-cafe/service"
+)
+
+type StoreHandler interface {
+	Create(ctx *gin.Context) // 매장 등록
+	Find(ctx *gin.Context)   // 소속 매장 리스트 조회
+}
+
+type storeHandler struct {
+	storeService service.StoreService
+}
+
+func NewStoreHandler(storeService service.StoreService) (StoreHandler, error) {
+	return &storeHandler{
+		storeService: storeService,
+	}, nil
+}
+
+func (h *storeHandler) Create(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	req := request.CreateStore{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	store, err := h.storeService.Create(ctx.Request.Context(), principal.AdminSeq, req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(store))
+}
+
+func (h *storeHandler) Find(ctx *gin.Context) {
+	principal, err := middleware.GetPrincipal(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	stores, err := h.storeService.Find(ctx.Request.Context(), principal.AdminSeq)
+	if err != nil {
+		ctx.AbortWithStatusJSON(response.Failure(err))
+		return
+	}
+
+	ctx.JSON(response.Success(stores))
+}
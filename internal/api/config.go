@@ -2,16 +2,33 @@ package api
 
 import (
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 	"hello-cafe/internal/db"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/internal/migrate"
 )
 
-const defaultConfigPath = "/root/.hello-cafe/config.yml"
+const (
+	defaultConfigPath = "/root/.hello-cafe/config.yml"
+
+	defaultQueryTimeout = 5 * time.Second
+)
 
 type Configure struct {
-	DB db.Config `yaml:"db"`
+	DB      db.Config          `yaml:"db"`
+	Migrate migrate.Config     `yaml:"migrate"`
+	JWT     internaljwt.Config `yaml:"jwt"`
+	Item    ItemConfig         `yaml:"item"`
+	Expiry  ExpiryConfig       `yaml:"expiry"`
+
+	// QueryTimeout 요청 하나가 DB 를 사용할 수 있는 최대 시간, 클라이언트가 연결을 끊으면 그 즉시 취소된다
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+
+	// OperatorToken 서버 운영 API 를 호출할 때 X-Operator-Token 헤더로 보내는 값, 비어 있으면 운영 API 를 사용할 수 없다
+	OperatorToken string `yaml:"operator_token"`
 }
 
 func unmarshalConfig(path string, cfg *Configure) error {
@@ -37,5 +54,12 @@ func Config() (*Configure, error) {
 		return nil, errors.Wrapf(err, "failed to unmarshal config [ path = %s ]", path)
 	}
 
+	cfg.DB = cfg.DB.WithDefaults()
+	cfg.Item = cfg.Item.WithDefaults()
+	cfg.Expiry = cfg.Expiry.WithDefaults()
+	if cfg.QueryTimeout <= 0 {
+		cfg.QueryTimeout = defaultQueryTimeout
+	}
+
 	return &cfg, nil
 }
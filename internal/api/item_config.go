@@ -0,0 +1,62 @@
+package api
+
+import (
+	"time"
+
+	"hello-cafe/internal/notify"
+)
+
+const (
+	DefaultTrashRetention = 30 * 24 * time.Hour
+
+	// DefaultLowStockThreshold 상품에 재고 부족 기준을 설정하지 않았을 때의 기준
+	DefaultLowStockThreshold = 5
+
+	DefaultExpiryInterval  = 10 * time.Minute
+	DefaultExpiryBatchSize = 100
+)
+
+type ItemConfig struct {
+	// TrashRetention 삭제된 상품을 휴지통에 보관하는 기간, 이후 영구 삭제된다
+	TrashRetention time.Duration `json:"trash_retention" yaml:"trash_retention"`
+
+	// LowStockThreshold 재고가 이 값 이하이면 부족으로 본다. 상품마다 따로 정할 수 있다
+	LowStockThreshold int64 `json:"low_stock_threshold" yaml:"low_stock_threshold"`
+}
+
+// WithDefaults 설정되지 않은 값을 기본값으로 채운다
+func (c ItemConfig) WithDefaults() ItemConfig {
+	if c.TrashRetention <= 0 {
+		c.TrashRetention = DefaultTrashRetention
+	}
+
+	if c.LowStockThreshold <= 0 {
+		c.LowStockThreshold = DefaultLowStockThreshold
+	}
+
+	return c
+}
+
+type ExpiryConfig struct {
+	// Interval 유통기한이 지난 상품을 확인하는 주기
+	Interval time.Duration `json:"interval" yaml:"interval"`
+
+	// BatchSize 한 번에 조회하여 판매 중지하는 상품 수
+	BatchSize int `json:"batch_size" yaml:"batch_size"`
+
+	// Notify 판매 중지한 상품을 매장별로 알리는 방식
+	Notify notify.Config `json:"notify" yaml:"notify"`
+}
+
+// WithDefaults 설정되지 않은 값을 기본값으로 채운다
+func (c ExpiryConfig) WithDefaults() ExpiryConfig {
+	if c.Interval <= 0 {
+		c.Interval = DefaultExpiryInterval
+	}
+
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultExpiryBatchSize
+	}
+
+	return c
+}
@@ -7,34 +7,120 @@ import (
 )
 
 var (
-	ErrNilPhone           = NewAPIError(http.StatusBadRequest, "핸드폰 번호를 입력해 주세요.")
-	ErrNilPassword        = NewAPIError(http.StatusBadRequest, "비밀번호를 입력해 주세요")
-	ErrInvalidPhone       = NewAPIError(http.StatusBadRequest, "핸드폰 번호 형식이 잘못 되었습니다.")
-	ErrInvalidPassword    = NewAPIError(http.StatusBadRequest, "비밀번호 형식이 잘못 되었습니다.")
-	ErrNilCategory        = NewAPIError(http.StatusBadRequest, "카테고리를 입력해 주세요.")
-	ErrInvalidCategory    = NewAPIError(http.StatusBadRequest, "카테고리 형식이 잘못 되었습니다.")
-	ErrNilBarcode         = NewAPIError(http.StatusBadRequest, "바코드를 입력해 주세요.")
-	ErrNilPrice           = NewAPIError(http.StatusBadRequest, "가격을 입력해 주세요.")
-	ErrNilCost            = NewAPIError(http.StatusBadRequest, "원가를 입력해 주세요.")
-	ErrNilName            = NewAPIError(http.StatusBadRequest, "이름을 입력해 주세요.")
-	ErrNilDescription     = NewAPIError(http.StatusBadRequest, "설명을 입력해 주세요.")
-	ErrNilExpireDT        = NewAPIError(http.StatusBadRequest, "유통기한을 입력해 주세요.")
-	ErrNilSize            = NewAPIError(http.StatusBadRequest, "사이즈를 입력해 주세요.")
-	ErrNilSearchText      = NewAPIError(http.StatusBadRequest, "검색어를 입력해 주세요.")
-	ErrInvalidSize        = NewAPIError(http.StatusBadRequest, "사이즈 형식이 잘못 되었습니다.")
-	ErrDuplicatedAdmin    = NewAPIError(http.StatusBadRequest, "중복된 계정입니다.")
-	ErrNilAccessToken     = NewAPIError(http.StatusBadRequest, "access token 정보를 입력해 주세요.")
-	ErrInvalidAdmin       = NewAPIError(http.StatusBadRequest, "관리자 정보가 잘못 되었습니다.")
-	ErrInvalidItem        = NewAPIError(http.StatusBadRequest, "상품 정보가 잘못 되었습니다.")
-	ErrDuplicatedItem     = NewAPIError(http.StatusBadRequest, "중복된 상품입니다.")
-	ErrNotExistItem       = NewAPIError(http.StatusBadRequest, "존재하지 않는 상품입니다.")
-	ErrInvalidAccessToken = NewAPIError(http.StatusBadRequest, "엑세스 토큰 인증 실패.")
+	ErrNilPhone            = NewAPIError(http.StatusBadRequest, "핸드폰 번호를 입력해 주세요.")
+	ErrNilPassword         = NewAPIError(http.StatusBadRequest, "비밀번호를 입력해 주세요")
+	ErrInvalidPhone        = NewAPIError(http.StatusBadRequest, "핸드폰 번호 형식이 잘못 되었습니다.")
+	ErrInvalidPassword     = NewAPIError(http.StatusBadRequest, "비밀번호 형식이 잘못 되었습니다.")
+	ErrNilCategory         = NewAPIError(http.StatusBadRequest, "카테고리를 입력해 주세요.")
+	ErrInvalidCategory     = NewAPIError(http.StatusBadRequest, "카테고리 형식이 잘못 되었습니다.")
+	ErrNilBarcode          = NewAPIError(http.StatusBadRequest, "바코드를 입력해 주세요.")
+	ErrNilPrice            = NewAPIError(http.StatusBadRequest, "가격을 입력해 주세요.")
+	ErrNilCost             = NewAPIError(http.StatusBadRequest, "원가를 입력해 주세요.")
+	ErrNilName             = NewAPIError(http.StatusBadRequest, "이름을 입력해 주세요.")
+	ErrNilDescription      = NewAPIError(http.StatusBadRequest, "설명을 입력해 주세요.")
+	ErrNilExpireDT         = NewAPIError(http.StatusBadRequest, "유통기한을 입력해 주세요.")
+	ErrNilSize             = NewAPIError(http.StatusBadRequest, "사이즈를 입력해 주세요.")
+	ErrNilSearchText       = NewAPIError(http.StatusBadRequest, "검색어를 입력해 주세요.")
+	ErrInvalidSize         = NewAPIError(http.StatusBadRequest, "사이즈 형식이 잘못 되었습니다.")
+	ErrDuplicatedAdmin     = NewAPIError(http.StatusBadRequest, "중복된 계정입니다.")
+	ErrNilAccessToken      = NewAPIError(http.StatusBadRequest, "access token 정보를 입력해 주세요.")
+	ErrInvalidAdmin        = NewAPIError(http.StatusBadRequest, "관리자 정보가 잘못 되었습니다.")
+	ErrInvalidItem         = NewAPIError(http.StatusBadRequest, "상품 정보가 잘못 되었습니다.")
+	ErrDuplicatedItem      = NewAPIError(http.StatusBadRequest, "중복된 상품입니다.")
+	ErrInvalidAccessToken  = NewAPIError(http.StatusBadRequest, "엑세스 토큰 인증 실패.")
+	ErrNilRefreshToken     = NewAPIError(http.StatusBadRequest, "refresh token 정보를 입력해 주세요.")
+	ErrNilRole             = NewAPIError(http.StatusBadRequest, "권한을 입력해 주세요.")
+	ErrInvalidRole         = NewAPIError(http.StatusBadRequest, "권한 형식이 잘못 되었습니다.")
+	ErrInvalidMember       = NewAPIError(http.StatusBadRequest, "직원 정보가 잘못 되었습니다.")
+	ErrNilStoreName        = NewAPIError(http.StatusBadRequest, "매장 이름을 입력해 주세요.")
+	ErrInvalidStore        = NewAPIError(http.StatusBadRequest, "매장 정보가 잘못 되었습니다.")
+	ErrDuplicatedMember    = NewAPIError(http.StatusBadRequest, "이미 매장에 소속된 직원입니다.")
+	ErrExistingAccount     = NewAPIError(http.StatusBadRequest, "이미 가입된 계정입니다. 비밀번호 없이 초대해 주세요.")
+	ErrDeletedItemBarcode  = NewAPIError(http.StatusBadRequest, "휴지통에 있는 상품의 바코드입니다. 상품을 복구하거나 영구 삭제된 후 등록해 주세요.")
+	ErrInvalidItemVersion  = NewAPIError(http.StatusBadRequest, "상품 버전 형식이 잘못 되었습니다.")
+	ErrInvalidPeriod       = NewAPIError(http.StatusBadRequest, "조회 기간이 잘못 되었습니다.")
+	ErrInvalidCursor       = NewAPIError(http.StatusBadRequest, "페이지 정보가 잘못 되었습니다.")
+	ErrInvalidLimit        = NewAPIError(http.StatusBadRequest, "조회 개수가 잘못 되었습니다.")
+	ErrInvalidPrice        = NewAPIError(http.StatusBadRequest, "가격 형식이 잘못 되었습니다.")
+	ErrInvalidCost         = NewAPIError(http.StatusBadRequest, "원가 형식이 잘못 되었습니다.")
+	ErrInvalidExpireDT     = NewAPIError(http.StatusBadRequest, "유통기한 형식이 잘못 되었습니다.")
+	ErrInvalidImportMode   = NewAPIError(http.StatusBadRequest, "등록 방식이 잘못 되었습니다.")
+	ErrInvalidImportFile   = NewAPIError(http.StatusBadRequest, "CSV 파일 형식이 잘못 되었습니다.")
+	ErrEmptyImportFile     = NewAPIError(http.StatusBadRequest, "등록할 상품이 없습니다.")
+	ErrTooManyImportRows   = NewAPIError(http.StatusBadRequest, "한 번에 등록할 수 있는 상품은 1000개 입니다.")
+	ErrInvalidExportFormat = NewAPIError(http.StatusBadRequest, "파일 형식이 잘못 되었습니다.")
+	ErrInvalidExportHeader = NewAPIError(http.StatusBadRequest, "컬럼 이름 언어가 잘못 되었습니다.")
+	ErrInvalidItemSort     = NewAPIError(http.StatusBadRequest, "정렬 기준이 잘못 되었습니다.")
+	ErrInvalidSortOrder    = NewAPIError(http.StatusBadRequest, "정렬 방향이 잘못 되었습니다.")
+	ErrInvalidItemQuery    = NewAPIError(http.StatusBadRequest, "상품 조회 조건이 잘못 되었습니다.")
+	ErrNilCategoryName     = NewAPIError(http.StatusBadRequest, "카테고리 이름을 입력해 주세요.")
+	ErrInvalidCategoryName = NewAPIError(http.StatusBadRequest, "카테고리 이름은 50자까지 입력할 수 있습니다.")
+	ErrDuplicatedCategory  = NewAPIError(http.StatusBadRequest, "같은 상위 카테고리에 같은 이름의 카테고리가 있습니다.")
+	ErrInvalidParent       = NewAPIError(http.StatusBadRequest, "상위 카테고리가 잘못 되었습니다.")
+	ErrCategoryHasChildren = NewAPIError(http.StatusBadRequest, "하위 카테고리가 있는 카테고리는 삭제할 수 없습니다.")
+	ErrUnknownCategory     = NewAPIError(http.StatusBadRequest, "매장에 없는 카테고리입니다.")
+	ErrCategoryInUse       = NewAPIError(http.StatusBadRequest, "상품이 있는 카테고리는 삭제할 수 없습니다. (휴지통 상품 포함)")
+	ErrNilOptionName       = NewAPIError(http.StatusBadRequest, "옵션 이름을 입력해 주세요.")
+	ErrInvalidOptionName   = NewAPIError(http.StatusBadRequest, "옵션 이름은 50자까지 입력할 수 있습니다.")
+	ErrInvalidOptionGroup  = NewAPIError(http.StatusBadRequest, "옵션 그룹 정보가 잘못 되었습니다.")
+	ErrInvalidOption       = NewAPIError(http.StatusBadRequest, "옵션 정보가 잘못 되었습니다.")
+	ErrInvalidOptionSelect = NewAPIError(http.StatusBadRequest, "옵션 선택 개수가 잘못 되었습니다.")
+	ErrInvalidOptionSKU    = NewAPIError(http.StatusBadRequest, "SKU 는 50자까지 입력할 수 있습니다.")
+	ErrDuplicatedOptions   = NewAPIError(http.StatusBadRequest, "같은 상품에 같은 이름의 옵션 그룹이 있습니다.")
+	ErrDuplicatedOption    = NewAPIError(http.StatusBadRequest, "같은 옵션 그룹에 같은 이름의 옵션이 있습니다.")
+	ErrDuplicatedOptionKey = NewAPIError(http.StatusBadRequest, "매장에 같은 바코드나 SKU 가 있습니다.")
+	ErrInvalidSelection    = NewAPIError(http.StatusBadRequest, "선택한 옵션이 옵션 그룹의 선택 조건에 맞지 않습니다.")
+	ErrInvalidStockType    = NewAPIError(http.StatusBadRequest, "재고 변동 종류가 잘못 되었습니다.")
+	ErrInvalidQuantity     = NewAPIError(http.StatusBadRequest, "재고 변동 수량이 잘못 되었습니다.")
+	ErrNilStockReason      = NewAPIError(http.StatusBadRequest, "재고 변동 사유를 입력해 주세요.")
+	ErrInvalidStockReason  = NewAPIError(http.StatusBadRequest, "재고 변동 사유는 200자까지 입력할 수 있습니다.")
+	ErrInvalidThreshold    = NewAPIError(http.StatusBadRequest, "재고 부족 기준은 0 이상이어야 합니다.")
+	ErrInvalidTransfer     = NewAPIError(http.StatusBadRequest, "재고를 이동할 매장이 잘못 되었습니다.")
+	ErrNoTransferItem      = NewAPIError(http.StatusBadRequest, "이동할 매장에 같은 바코드의 상품이 없습니다.")
+	ErrInsufficientStock   = NewAPIError(http.StatusBadRequest, "재고가 부족합니다.")
+	ErrNilLotNumber        = NewAPIError(http.StatusBadRequest, "lot 번호를 입력해 주세요.")
+	ErrInvalidLotNumber    = NewAPIError(http.StatusBadRequest, "lot 번호는 50자까지 입력할 수 있습니다.")
+	ErrInvalidLotExpireDT  = NewAPIError(http.StatusBadRequest, "lot 의 유통기한은 입고일 이후여야 합니다.")
+	ErrInvalidLot          = NewAPIError(http.StatusBadRequest, "lot 정보가 잘못 되었습니다.")
+	ErrDuplicatedLot       = NewAPIError(http.StatusBadRequest, "같은 상품에 같은 lot 번호가 있습니다.")
+	ErrInsufficientLot     = NewAPIError(http.StatusBadRequest, "lot 의 남은 수량이 부족합니다.")
 )
 
 var (
 	ErrIDNotExist        = NewAPIError(http.StatusUnauthorized, "존재하지 않는 계정입니다.")
 	ErrIncorrectPassword = NewAPIError(http.StatusUnauthorized, "비밀번호가 잘못 되었습니다.")
 	ErrAlreadyLogout     = NewAPIError(http.StatusUnauthorized, "이미 로그아웃 되었습니다.")
+
+	ErrInvalidRefreshToken = NewAPIError(http.StatusUnauthorized, "리프레시 토큰 인증 실패.")
+	ErrReusedRefreshToken  = NewAPIError(http.StatusUnauthorized, "이미 사용된 리프레시 토큰입니다. 다시 로그인해 주세요.")
+)
+
+var (
+	ErrForbidden         = NewAPIError(http.StatusForbidden, "권한이 없습니다.")
+	ErrForbiddenItem     = NewAPIError(http.StatusForbidden, "상품에 대한 권한이 없습니다.")
+	ErrForbiddenStore    = NewAPIError(http.StatusForbidden, "매장에 대한 권한이 없습니다.")
+	ErrNoStore           = NewAPIError(http.StatusForbidden, "소속된 매장이 없습니다.")
+	ErrForbiddenOperator = NewAPIError(http.StatusForbidden, "운영자만 사용할 수 있습니다.")
+)
+
+var (
+	ErrNotExistItem     = NewAPIError(http.StatusNotFound, "존재하지 않는 상품입니다.")
+	ErrNotExistMember   = NewAPIError(http.StatusNotFound, "존재하지 않는 직원입니다.")
+	ErrNotExistCategory = NewAPIError(http.StatusNotFound, "존재하지 않는 카테고리입니다.")
+	ErrNotExistOption   = NewAPIError(http.StatusNotFound, "존재하지 않는 옵션입니다.")
+	ErrNotExistLot      = NewAPIError(http.StatusNotFound, "존재하지 않는 lot 입니다.")
+
+	ErrNotExistDeletedItem = NewAPIError(http.StatusNotFound, "휴지통에 없는 상품입니다.")
+)
+
+var (
+	ErrStaleItem      = NewAPIError(http.StatusPreconditionFailed, "다른 사용자가 먼저 상품을 변경했습니다. 최신 상품 정보를 확인 후 다시 시도해 주세요.")
+	ErrWeakItemETag   = NewAPIError(http.StatusPreconditionFailed, "If-Match 에는 weak ETag(W/) 를 사용할 수 없습니다. 상품 조회 응답의 ETag 를 그대로 입력해 주세요.")
+	ErrNilItemVersion = NewAPIError(http.StatusPreconditionRequired, "상품 버전을 입력해 주세요. (If-Match 헤더 또는 version)")
+)
+
+var (
+	ErrRequestTimeout = NewAPIError(http.StatusServiceUnavailable, "요청 처리 시간이 초과 되었습니다. 잠시 후 다시 시도해 주세요.")
 )
 
 type APIError struct {
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value    interface{}
+	expireDT time.Time
+}
+
+// TTLCache 만료 시간을 가지는 in-process 캐시
+type TTLCache struct {
+	mu    sync.RWMutex
+	items map[string]entry
+}
+
+func NewTTLCache() *TTLCache {
+	return &TTLCache{items: make(map[string]entry)}
+}
+
+func (c *TTLCache) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.items[key]
+	if !ok || time.Now().After(e.expireDT) {
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *TTLCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = entry{value: value, expireDT: time.Now().Add(ttl)}
+}
+
+func (c *TTLCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+}
+
+// DeleteExpired 만료된 항목을 모두 제거한다
+func (c *TTLCache) DeleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.items {
+		if now.After(e.expireDT) {
+			delete(c.items, k)
+		}
+	}
+}
+
+func (c *TTLCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.items)
+}
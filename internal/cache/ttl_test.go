@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache_Get(t *testing.T) {
+	c := NewTTLCache()
+	c.Set("alive", true, time.Minute)
+	c.Set("expired", true, -time.Second)
+
+	type args struct {
+		key string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		wantOK bool
+	}{
+		{
+			name: "유효한 항목",
+			args: args{
+				key: "alive",
+			},
+			wantOK: true,
+		},
+		{
+			name: "만료된 항목",
+			args: args{
+				key: "expired",
+			},
+			wantOK: false,
+		},
+		{
+			name: "없는 항목",
+			args: args{
+				key: "unknown",
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := c.Get(tt.args.key); ok != tt.wantOK {
+				t.Errorf("Get() ok = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+
+	c.DeleteExpired()
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after DeleteExpired() = %v, want %v", got, 1)
+	}
+}
@@ -0,0 +1,67 @@
+// Package cursor 페이지 정보를 client 가 바꿀 수 없도록 서명하여 주고받는다
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/internaljwt"
+)
+
+// GetSecretKey 설정하지 않으면 JWT 서명 키를 사용한다
+func GetSecretKey() string {
+	k := os.Getenv("CURSOR_SECRET_KEY")
+	if k == "" {
+		k = internaljwt.GetSecretKey()
+	}
+	return k
+}
+
+// Encode v 를 JSON 으로 바꾸어 {payload}.{signature} 형태의 base64 문자열로 만든다
+func Encode(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal cursor")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload)), nil
+}
+
+// Decode 서명이 맞지 않거나 형식이 잘못된 cursor 는 apierror.ErrInvalidCursor 를 반환한다
+func Decode(token string, v any) error {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return apierror.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return apierror.ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, sign(payload)) {
+		return apierror.ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return apierror.ErrInvalidCursor
+	}
+
+	return nil
+}
+
+func sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(GetSecretKey()))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,65 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+)
+
+type testCursor struct {
+	Value   string `json:"v"`
+	ItemSeq int64  `json:"i"`
+}
+
+func TestDecode(t *testing.T) {
+	token, err := Encode(testCursor{Value: "4500", ItemSeq: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"v":"4500","i":1}`)) + "." + signature
+
+	tests := []struct {
+		name    string
+		token   string
+		want    testCursor
+		wantErr error
+	}{
+		{
+			name:  "성공",
+			token: token,
+			want:  testCursor{Value: "4500", ItemSeq: 3},
+		},
+		{
+			name:    "값을 바꾼 cursor",
+			token:   tampered,
+			wantErr: apierror.ErrInvalidCursor,
+		},
+		{
+			name:    "서명 누락",
+			token:   payload,
+			wantErr: apierror.ErrInvalidCursor,
+		},
+		{
+			name:    "base64 가 아닌 값",
+			token:   "!!!.???",
+			wantErr: apierror.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testCursor{}
+			err := Decode(tt.token, &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tt.want {
+				t.Errorf("Decode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"fmt"
 	"log"
 	"net"
 	"strconv"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -15,9 +17,14 @@ import (
 	gorm_mysql "gorm.io/driver/mysql"
 )
 
-var db *gorm.DB
+const (
+	DialectMySQL  = "mysql"
+	DialectSQLite = "sqlite"
+)
 
 type Config struct {
+	// Dialect mysql(기본값) 또는 sqlite, sqlite 는 Database 를 파일 경로로 사용한다 (":memory:" 가능)
+	Dialect         string `json:"dialect" yaml:"dialect"`
 	Host            string `json:"host" yaml:"host"`
 	Port            int    `json:"port" yaml:"port"`
 	Database        string `json:"database" yaml:"database"`
@@ -31,7 +38,70 @@ type Config struct {
 	SSLMode         string `json:"ssl_mode" yaml:"ssl_mode"`
 }
 
-func Connect(c Config) (err error) {
+// WithDefaults 설정되지 않은 값을 기본값으로 채운다
+func (c Config) WithDefaults() Config {
+	if c.Dialect == "" {
+		c.Dialect = DialectMySQL
+	}
+
+	return c
+}
+
+// Connect 설정에 맞는 DB 에 연결한다. 반환된 connection 은 repository 에 주입해 사용한다
+func Connect(c Config) (*gorm.DB, error) {
+	c = c.WithDefaults()
+
+	var dialect gorm.Dialector
+	switch c.Dialect {
+	case DialectMySQL:
+		dialect = openMySQL(c)
+	case DialectSQLite:
+		dialect = openSQLite(c)
+	default:
+		return nil, fmt.Errorf("dialect(%s) is not supported", c.Dialect)
+	}
+
+	db, err := gorm.Open(dialect, &gorm.Config{DisableNestedTransaction: true, TranslateError: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect database")
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database pool")
+	}
+
+	if c.Dialect == DialectSQLite {
+		// SQLite 는 쓰기가 한 connection 씩만 가능하고, in-memory DB 는 connection 마다 따로 생기므로 하나만 사용한다
+		sqlDB.SetMaxOpenConns(1)
+	} else if c.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	}
+
+	if c.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	}
+
+	if c.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(c.ConnMaxLifetime) * time.Second)
+	}
+
+	if c.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(time.Duration(c.ConnMaxIdleTime) * time.Second)
+	}
+
+	if c.Verbose {
+		db.Logger = logger.New(log.New(logrus.StandardLogger().Out, "\r\n", log.LstdFlags), logger.Config{
+			SlowThreshold: 200 * time.Millisecond,
+			LogLevel:      logger.Info,
+			Colorful:      true,
+		})
+	}
+
+	return db, nil
+}
+
+func openMySQL(c Config) gorm.Dialector {
 	dsnConfig := mysql.NewConfig()
 	dsnConfig.User = c.Username
 	dsnConfig.Passwd = c.Password
@@ -44,25 +114,20 @@ func Connect(c Config) (err error) {
 	dsnConfig.Params = map[string]string{
 		"charset": "utf8mb4",
 	}
-	dialect := gorm_mysql.Open(dsnConfig.FormatDSN())
 
 	logrus.Debugf("DSN string : %s", dsnConfig.FormatDSN())
 
-	if db, err = gorm.Open(dialect, &gorm.Config{DisableNestedTransaction: true}); err != nil {
-		return errors.Wrap(err, "failed to connect database")
-	}
+	return gorm_mysql.Open(dsnConfig.FormatDSN())
+}
 
-	if c.Verbose {
-		db.Logger = logger.New(log.New(logrus.StandardLogger().Out, "\r\n", log.LstdFlags), logger.Config{
-			SlowThreshold: 200 * time.Millisecond,
-			LogLevel:      logger.Info,
-			Colorful:      true,
-		})
+func openSQLite(c Config) gorm.Dialector {
+	dsn := c.Database
+	if dsn == "" {
+		dsn = ":memory:"
 	}
+	dsn += "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
 
-	return nil
-}
+	logrus.Debugf("DSN string : %s", dsn)
 
-func Conn() *gorm.DB {
-	return db
+	return sqlite.Open(dsn)
 }
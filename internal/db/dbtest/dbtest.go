@@ -0,0 +1,43 @@
+// Package dbtest 테스트마다 독립된 in-memory SQLite DB 를 준비한다
+package dbtest
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"hello-cafe/internal/db"
+	"hello-cafe/internal/migrate"
+	"hello-cafe/repository/migrations"
+)
+
+// Connect 새 in-memory SQLite DB 에 연결하고 모든 migration 을 적용한다
+func Connect(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	conn, err := db.Connect(db.Config{Dialect: db.DialectSQLite, Database: ":memory:"})
+	if err != nil {
+		t.Fatalf("failed to connect database: %+v", err)
+	}
+
+	fsys, err := migrations.FS(db.DialectSQLite)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %+v", err)
+	}
+
+	migrator, err := migrate.New(conn, fsys, migrate.Config{})
+	if err != nil {
+		t.Fatalf("failed to create migrator: %+v", err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to migrate database: %+v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	return conn
+}
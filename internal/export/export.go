@@ -0,0 +1,81 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// utf8BOM 엑셀은 BOM 이 없으면 UTF-8 CSV 의 한글이 깨진다
+const utf8BOM = "\ufeff"
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// TableWriter 한 행씩 바로 출력하여 전체 데이터를 메모리에 올리지 않는다
+type TableWriter interface {
+	WriteRow(cells []any) error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter header 를 먼저 출력한다. bom 이면 맨 앞에 UTF-8 BOM 을 붙인다
+func NewCSVWriter(w io.Writer, header []string, bom bool) (TableWriter, error) {
+	if bom {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	writer := &csvWriter{w: csv.NewWriter(w)}
+	if err := writer.w.Write(header); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return writer, nil
+}
+
+func (c *csvWriter) WriteRow(cells []any) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = formatCell(cell)
+	}
+
+	if err := c.w.Write(record); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return errors.WithStack(c.w.Error())
+}
+
+func formatCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.Format(timeLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(timeLayout)
+	default:
+		return ""
+	}
+}
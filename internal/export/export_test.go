@@ -0,0 +1,120 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCSVWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		bom  bool
+		want string
+	}{
+		{
+			name: "BOM 포함",
+			bom:  true,
+			want: "\ufeff바코드,가격,유통기한\n8801,4500,2030-01-02 09:00:00\n",
+		},
+		{
+			name: "BOM 제외",
+			bom:  false,
+			want: "바코드,가격,유통기한\n8801,4500,2030-01-02 09:00:00\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			w, err := NewCSVWriter(&buf, []string{"바코드", "가격", "유통기한"}, tt.bom)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.WriteRow([]any{"8801", int64(4500), time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("NewCSVWriter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewXLSXWriter(t *testing.T) {
+	buf := bytes.Buffer{}
+	w, err := NewXLSXWriter(&buf, "상품", []string{"상품명", "가격"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.WriteRow([]any{"아메리카노 <L>", int64(4500)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("NewXLSXWriter() missing %s", name)
+		}
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">상품명</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">아메리카노 &lt;L&gt;</t></is></c>`,
+		`<c r="B2"><v>4500</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml = %s, want contains %s", sheet, want)
+		}
+	}
+}
+
+func Test_columnName(t *testing.T) {
+	tests := []struct {
+		name string
+		i    int
+		want string
+	}{
+		{name: "첫 번째 열", i: 0, want: "A"},
+		{name: "26 번째 열", i: 25, want: "Z"},
+		{name: "27 번째 열", i: 26, want: "AA"},
+		{name: "703 번째 열", i: 702, want: "AAA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnName(tt.i); got != tt.want {
+				t.Errorf("columnName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// xlsx 를 구성하는 최소한의 파일, 시트는 하나만 만든다
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd   = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+// NewXLSXWriter sheetName 시트 하나에 header 를 첫 행으로 출력한다.
+// 문자열은 공유 문자열 테이블 없이 inline 으로 넣어 행을 받는 즉시 출력한다
+func NewXLSXWriter(w io.Writer, sheetName string, header []string) (TableWriter, error) {
+	zw := zip.NewWriter(w)
+
+	workbook := xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + escape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: xlsxContentTypes},
+		{name: "_rels/.rels", content: xlsxRootRels},
+		{name: "xl/workbook.xml", content: workbook},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRels},
+	}
+	for _, file := range files {
+		f, err := zw.Create(file.name)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if _, err := io.WriteString(f, file.content); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	// 시트는 마지막 파일이므로 Close 전까지 이어서 쓸 수 있다
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	writer := &xlsxWriter{zw: zw, sheet: bufio.NewWriter(sheet)}
+	if _, err := writer.sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	cells := make([]any, len(header))
+	for i, name := range header {
+		cells[i] = name
+	}
+	if err := writer.WriteRow(cells); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return writer, nil
+}
+
+func (x *xlsxWriter) WriteRow(cells []any) error {
+	x.row++
+	row := strconv.Itoa(x.row)
+
+	x.sheet.WriteString(`<row r="` + row + `">`)
+	for i, cell := range cells {
+		ref := columnName(i) + row
+		switch v := cell.(type) {
+		case int:
+			x.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.Itoa(v) + `</v></c>`)
+		case int64:
+			x.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatInt(v, 10) + `</v></c>`)
+		default:
+			if s := formatCell(cell); s != "" {
+				x.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + escape(s) + `</t></is></c>`)
+			}
+		}
+	}
+
+	// bufio.Writer 는 처음 발생한 오류를 계속 반환한다
+	if _, err := x.sheet.WriteString(`</row>`); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := x.sheet.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(x.zw.Close())
+}
+
+// columnName 0 부터 시작하는 열 번호를 A, B, ..., Z, AA 로 바꾼다
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
+
+func escape(s string) string {
+	b := strings.Builder{}
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -1,13 +1,63 @@
 package internaljwt
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
 )
 
-const defaultSecretKey = "default key"
+const (
+	defaultSecretKey = "default key"
+
+	// Issuer 토큰 발행자
+	Issuer = "hello-cafe"
+
+	DefaultAccessTokenTTL  = time.Minute * 20
+	DefaultRefreshTokenTTL = time.Hour * 24 * 14
+)
+
+type Config struct {
+	AccessTokenTTL  time.Duration `json:"access_token_ttl" yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl" yaml:"refresh_token_ttl"`
+}
+
+// WithDefaults 설정되지 않은 값을 기본값으로 채운다
+func (c Config) WithDefaults() Config {
+	if c.AccessTokenTTL <= 0 {
+		c.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	if c.RefreshTokenTTL <= 0 {
+		c.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	return c
+}
+
+// Identity access token 에 담을 관리자 정보
+type Identity struct {
+	AdminSeq int64
+	StoreSeq int64
+	Role     string
+	Phone    string
+	// FamilyID 토큰을 발급한 refresh token 계열
+	FamilyID string
+}
+
+// Claims access token 에 담기는 관리자 정보
+type Claims struct {
+	AdminSeq int64  `json:"admin_seq"`
+	StoreSeq int64  `json:"store_seq"`
+	Role     string `json:"role"`
+	FamilyID string `json:"fid,omitempty"`
+	jwt.StandardClaims
+}
 
 func GetSecretKey() string {
 	k := os.Getenv("JWT_SECRET_KEY")
@@ -17,18 +67,84 @@ func GetSecretKey() string {
 	return k
 }
 
-func CreateJWT(Id string) (string, error) {
-	secretKey := GetSecretKey()
-	mySigningKey := []byte(secretKey)
+func CreateJWT(identity Identity, ttl time.Duration) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create token id")
+	}
 
-	aToken := jwt.New(jwt.SigningMethodHS256)
-	claims := aToken.Claims.(jwt.MapClaims)
-	claims["Id"] = Id
-	claims["exp"] = time.Now().Add(time.Minute * 20).Unix()
+	now := time.Now()
+	claims := Claims{
+		AdminSeq: identity.AdminSeq,
+		StoreSeq: identity.StoreSeq,
+		Role:     identity.Role,
+		FamilyID: identity.FamilyID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			Issuer:    Issuer,
+			Subject:   identity.Phone,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	}
 
-	tk, err := aToken.SignedString(mySigningKey)
+	tk, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(GetSecretKey()))
 	if err != nil {
 		return "", err
 	}
 	return tk, nil
 }
+
+func ParseJWT(strToken string) (*Claims, error) {
+	claims := new(Claims)
+	token, err := jwt.ParseWithClaims(strToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method(%v)", token.Header["alg"])
+		}
+		return []byte(GetSecretKey()), nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse token")
+	}
+
+	switch {
+	case !token.Valid:
+		return nil, errors.New("token is invalid")
+	case !claims.VerifyIssuer(Issuer, true):
+		return nil, fmt.Errorf("issuer(%s) is invalid", claims.Issuer)
+	case claims.AdminSeq <= 0:
+		return nil, fmt.Errorf("admin_seq(%d) is invalid", claims.AdminSeq)
+	case claims.StoreSeq <= 0:
+		return nil, fmt.Errorf("store_seq(%d) is invalid", claims.StoreSeq)
+	case claims.Role == "":
+		return nil, errors.New("role is empty")
+	case claims.Id == "":
+		return nil, errors.New("token id is empty")
+	}
+
+	return claims, nil
+}
+
+// NewTokenID 추측할 수 없는 임의의 토큰 식별자를 만든다
+func NewTokenID() (string, error) {
+	return randomHex(16)
+}
+
+// NewRefreshToken 서버에 저장되는 불투명한 refresh token 을 만든다
+func NewRefreshToken() (string, error) {
+	return randomHex(32)
+}
+
+// HashRefreshToken refresh token 은 원문 대신 해시로 저장한다
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,98 @@
+package internaljwt
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWT(t *testing.T) {
+	validToken, err := CreateJWT(Identity{
+		AdminSeq: 1,
+		StoreSeq: 1,
+		Role:     "owner",
+		Phone:    "010-1234-1234",
+	}, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+
+	otherIssuerToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		AdminSeq:       1,
+		StoreSeq:       1,
+		Role:           "owner",
+		StandardClaims: jwt.StandardClaims{Id: "id", Issuer: "other"},
+	}).SignedString([]byte(GetSecretKey()))
+	require.NoError(t, err)
+
+	noAdminToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Role:           "owner",
+		StandardClaims: jwt.StandardClaims{Id: "id", Issuer: Issuer},
+	}).SignedString([]byte(GetSecretKey()))
+	require.NoError(t, err)
+
+	noRoleToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		AdminSeq:       1,
+		StoreSeq:       1,
+		StandardClaims: jwt.StandardClaims{Id: "id", Issuer: Issuer},
+	}).SignedString([]byte(GetSecretKey()))
+	require.NoError(t, err)
+
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantAdminSeq int64
+		wantErr      bool
+	}{
+		{
+			name: "성공",
+			args: args{
+				token: validToken,
+			},
+			wantAdminSeq: 1,
+			wantErr:      false,
+		},
+		{
+			name: "발행자 불일치",
+			args: args{
+				token: otherIssuerToken,
+			},
+			wantErr: true,
+		},
+		{
+			name: "관리자 정보 누락",
+			args: args{
+				token: noAdminToken,
+			},
+			wantErr: true,
+		},
+		{
+			name: "권한 누락",
+			args: args{
+				token: noRoleToken,
+			},
+			wantErr: true,
+		},
+		{
+			name: "잘못된 토큰",
+			args: args{
+				token: "invalid token",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJWT(tt.args.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseJWT() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.AdminSeq != tt.wantAdminSeq {
+				t.Errorf("ParseJWT() admin_seq = %v, want %v", got.AdminSeq, tt.wantAdminSeq)
+			}
+		})
+	}
+}
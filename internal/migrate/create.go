@@ -0,0 +1,45 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create dirs 각각에 같은 버전의 빈 up/down migration 파일을 만든다.
+// DB 종류별 디렉토리를 함께 넘겨 버전이 어긋나지 않도록 한다.
+func Create(name string, dirs ...string) ([]string, error) {
+	if !nameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("migration name(%s) must be snake_case", name)
+	}
+
+	var version int64 = 1
+	for _, dir := range dirs {
+		migrations, err := Load(os.DirFS(dir))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if len(migrations) > 0 && migrations[len(migrations)-1].Version >= version {
+			version = migrations[len(migrations)-1].Version + 1
+		}
+	}
+
+	files := make([]string, 0, 2*len(dirs))
+	for _, dir := range dirs {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+			if err := os.WriteFile(file, []byte(fmt.Sprintf("-- %04d_%s %s\n", version, name, direction)), 0644); err != nil {
+				return nil, errors.Wrapf(err, "failed to create migration file(%s)", file)
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,370 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLockTimeout = 30 * time.Second
+
+	// lockName 여러 서버가 동시에 migration 하지 않도록 잡는 MySQL named lock
+	lockName = "hello_cafe_schema_migrations"
+
+	dialectMySQL = "mysql"
+)
+
+// fileRegexp {version}_{name}.(up|down).sql
+var fileRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Config struct {
+	AutoMigrate bool          `json:"auto_migrate" yaml:"auto_migrate"`
+	LockTimeout time.Duration `json:"lock_timeout" yaml:"lock_timeout"`
+}
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedDT *time.Time
+}
+
+type appliedMigration struct {
+	Version   int64     `gorm:"Column:version"`
+	Name      string    `gorm:"Column:name"`
+	AppliedDT time.Time `gorm:"Column:applied_dt"`
+}
+
+type Migrator struct {
+	conn        *gorm.DB
+	dialect     string
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+func New(conn *gorm.DB, fsys fs.FS, cfg Config) (*Migrator, error) {
+	if conn == nil {
+		return nil, errors.New("db connection is nil")
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load migrations")
+	}
+
+	lockTimeout := cfg.LockTimeout
+	if lockTimeout <= 0 {
+		lockTimeout = defaultLockTimeout
+	}
+
+	return &Migrator{
+		conn:        conn,
+		dialect:     conn.Dialector.Name(),
+		migrations:  migrations,
+		lockTimeout: lockTimeout,
+	}, nil
+}
+
+// Load fsys 최상위의 migration 파일을 버전 순서로 읽는다
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migration directory")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		matches := fileRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("migration file name(%s) is invalid", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "migration version(%s) is invalid", matches[1])
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration file(%s)", entry.Name())
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration version(%d) has different names(%s, %s)", version, m.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration version(%d) has no up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up 적용되지 않은 migration 을 모두 적용한다
+func (m *Migrator) Up() ([]Migration, error) {
+	applied := make([]Migration, 0)
+
+	err := m.withLock(func(tx *gorm.DB) error {
+		done, err := m.applied(tx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			logrus.Infof("applying migration %04d_%s", migration.Version, migration.Name)
+
+			if err := m.execStatements(tx, migration.Up); err != nil {
+				return errors.Wrapf(err, "failed to apply migration(%d)", migration.Version)
+			}
+
+			if err := tx.Exec("INSERT INTO schema_migrations (version, name, applied_dt) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now()).Error; err != nil {
+				return errors.Wrapf(err, "failed to record migration(%d)", migration.Version)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Baseline version 까지의 migration 을 실행하지 않고 적용된 것으로 기록한다.
+// migration 도입 이전에 tables.sql 로 만든 DB 처럼 스키마가 이미 있는 경우에 사용한다.
+func (m *Migrator) Baseline(version int64) ([]Migration, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("version(%d) must be positive", version)
+	}
+
+	found := false
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("migration version(%d) does not exist", version)
+	}
+
+	recorded := make([]Migration, 0)
+
+	err := m.withLock(func(tx *gorm.DB) error {
+		done, err := m.applied(tx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			logrus.Infof("baselining migration %04d_%s", migration.Version, migration.Name)
+
+			if err := tx.Exec("INSERT INTO schema_migrations (version, name, applied_dt) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now()).Error; err != nil {
+				return errors.Wrapf(err, "failed to record migration(%d)", migration.Version)
+			}
+
+			recorded = append(recorded, migration)
+		}
+
+		return nil
+	})
+
+	return recorded, err
+}
+
+// Down 가장 최근에 적용된 migration 부터 n 개를 되돌린다
+func (m *Migrator) Down(n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("count(%d) must be positive", n)
+	}
+
+	reverted := make([]Migration, 0, n)
+
+	err := m.withLock(func(tx *gorm.DB) error {
+		done, err := m.applied(tx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migration(%d) has no down file", migration.Version)
+			}
+
+			logrus.Infof("reverting migration %04d_%s", migration.Version, migration.Name)
+
+			if err := m.execStatements(tx, migration.Down); err != nil {
+				return errors.Wrapf(err, "failed to revert migration(%d)", migration.Version)
+			}
+
+			if err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error; err != nil {
+				return errors.Wrapf(err, "failed to delete migration record(%d)", migration.Version)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (m *Migrator) Status() ([]Status, error) {
+	if err := m.ensureTable(m.conn); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	done, err := m.applied(m.conn)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if a, ok := done[migration.Version]; ok {
+			appliedDT := a.AppliedDT
+			status.AppliedDT = &appliedDT
+		}
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+func (m *Migrator) ensureTable(tx *gorm.DB) error {
+	ddl := "CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+		"`version` bigint(20) NOT NULL," +
+		"`name` varchar(255) NOT NULL," +
+		"`applied_dt` datetime NOT NULL," +
+		"PRIMARY KEY (`version`)" +
+		")"
+	if m.dialect == dialectMySQL {
+		ddl += " ENGINE=InnoDB DEFAULT CHARSET=utf8"
+	}
+
+	if err := tx.Exec(ddl).Error; err != nil {
+		return errors.Wrap(err, "failed to create schema_migrations table")
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied(tx *gorm.DB) (map[int64]appliedMigration, error) {
+	rows := make([]appliedMigration, 0)
+	if err := tx.Raw("SELECT version, name, applied_dt FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to read schema_migrations")
+	}
+
+	result := make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+
+	return result, nil
+}
+
+// withLock named lock 은 세션 단위이므로 하나의 connection 에서 lock 부터 unlock 까지 실행한다.
+// SQLite 는 하나의 프로세스만 파일을 쓰므로 lock 없이 실행한다.
+func (m *Migrator) withLock(fn func(tx *gorm.DB) error) error {
+	return m.conn.Connection(func(tx *gorm.DB) (err error) {
+		if m.dialect != dialectMySQL {
+			if err := m.ensureTable(tx); err != nil {
+				return errors.WithStack(err)
+			}
+
+			return fn(tx)
+		}
+
+		var locked *int
+		if err := tx.Raw("SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds())).Scan(&locked).Error; err != nil {
+			return errors.Wrap(err, "failed to get migration lock")
+		}
+
+		if locked == nil || *locked != 1 {
+			return fmt.Errorf("timeout waiting for migration lock(%s)", lockName)
+		}
+
+		defer func() {
+			if releaseErr := tx.Exec("SELECT RELEASE_LOCK(?)", lockName).Error; releaseErr != nil && err == nil {
+				err = errors.Wrap(releaseErr, "failed to release migration lock")
+			}
+		}()
+
+		if err := m.ensureTable(tx); err != nil {
+			return errors.WithStack(err)
+		}
+
+		return fn(tx)
+	})
+}
+
+func (m *Migrator) execStatements(tx *gorm.DB, sql string) error {
+	for _, stmt := range SplitStatements(sql, m.dialect == dialectMySQL) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return errors.Wrapf(err, "failed to execute statement: %s", stmt)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"hello-cafe/internal/db"
+	"hello-cafe/repository/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	type args struct {
+		fsys fstest.MapFS
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantVersions []int64
+		wantErr      bool
+	}{
+		{
+			name: "버전 순서로 정렬",
+			args: args{
+				fsys: fstest.MapFS{
+					"0002_add_store.up.sql":   {Data: []byte("CREATE TABLE store (id int);")},
+					"0002_add_store.down.sql": {Data: []byte("DROP TABLE store;")},
+					"0001_init.up.sql":        {Data: []byte("CREATE TABLE admin (id int);")},
+					"0001_init.down.sql":      {Data: []byte("DROP TABLE admin;")},
+					"migrations.go":           {Data: []byte("package migrations")},
+				},
+			},
+			wantVersions: []int64{1, 2},
+			wantErr:      false,
+		},
+		{
+			name: "up 파일 누락",
+			args: args{
+				fsys: fstest.MapFS{
+					"0001_init.down.sql": {Data: []byte("DROP TABLE admin;")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "잘못된 파일 이름",
+			args: args{
+				fsys: fstest.MapFS{
+					"init.sql": {Data: []byte("CREATE TABLE admin (id int);")},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.args.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			versions := make([]int64, 0, len(got))
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			if err == nil && !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("Load() versions = %v, want %v", versions, tt.wantVersions)
+			}
+		})
+	}
+}
+
+func TestLoad_embedded(t *testing.T) {
+	versions := make(map[string][]int64)
+	for _, dialect := range migrations.Dialects {
+		fsys, err := migrations.FS(dialect)
+		if err != nil {
+			t.Fatalf("FS(%s) error = %v", dialect, err)
+		}
+
+		got, err := Load(fsys)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", dialect, err)
+		}
+
+		for _, m := range got {
+			if m.Down == "" {
+				t.Errorf("migration(%s, %d) has no down file", dialect, m.Version)
+			}
+			versions[dialect] = append(versions[dialect], m.Version)
+		}
+	}
+
+	// 모든 DB 종류가 같은 버전의 migration 을 가져야 한다
+	for _, dialect := range migrations.Dialects {
+		if !reflect.DeepEqual(versions[dialect], versions[migrations.Dialects[0]]) {
+			t.Errorf("migration versions(%s) = %v, want %v", dialect, versions[dialect], versions[migrations.Dialects[0]])
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	type args struct {
+		sql             string
+		backslashEscape bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "여러 문장",
+			args: args{
+				sql: "CREATE TABLE a (id int);\nCREATE TABLE b (id int);\n",
+			},
+			want: []string{"CREATE TABLE a (id int)", "CREATE TABLE b (id int)"},
+		},
+		{
+			name: "따옴표 안의 세미콜론",
+			args: args{
+				sql: "CREATE TABLE a (`id` int COMMENT 'a;b');",
+			},
+			want: []string{"CREATE TABLE a (`id` int COMMENT 'a;b')"},
+		},
+		{
+			name: "주석 무시",
+			args: args{
+				sql: "-- comment; with semicolon\nDROP TABLE a;",
+			},
+			want: []string{"DROP TABLE a"},
+		},
+		{
+			name: "블록 주석 무시",
+			args: args{
+				sql: "/* comment; with 'quote */\nDROP TABLE a /* inline; */;\nDROP TABLE b;",
+			},
+			want: []string{"DROP TABLE a", "DROP TABLE b"},
+		},
+		{
+			name: "여러 줄 블록 주석",
+			args: args{
+				sql: "/*\n * a;\n * b;\n */\nDROP TABLE a;",
+			},
+			want: []string{"DROP TABLE a"},
+		},
+		{
+			name: "문자열 안의 주석 기호",
+			args: args{
+				sql: "INSERT INTO a VALUES ('--', '/*');",
+			},
+			want: []string{"INSERT INTO a VALUES ('--', '/*')"},
+		},
+		{
+			name: "backslash 로 escape 된 따옴표 (MySQL)",
+			args: args{
+				sql:             "INSERT INTO a VALUES ('it\\'s; ok');INSERT INTO a VALUES ('\\\\');",
+				backslashEscape: true,
+			},
+			want: []string{"INSERT INTO a VALUES ('it\\'s; ok')", "INSERT INTO a VALUES ('\\\\')"},
+		},
+		{
+			name: "backslash 는 일반 문자 (SQLite)",
+			args: args{
+				sql:             "INSERT INTO a VALUES ('a\\');INSERT INTO a VALUES ('it''s; ok');",
+				backslashEscape: false,
+			},
+			want: []string{"INSERT INTO a VALUES ('a\\')", "INSERT INTO a VALUES ('it''s; ok')"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitStatements(tt.args.sql, tt.args.backslashEscape); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrator_Baseline(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE admin (id int);")},
+		"0001_init.down.sql":      {Data: []byte("DROP TABLE admin;")},
+		"0002_add_store.up.sql":   {Data: []byte("CREATE TABLE store (id int);")},
+		"0002_add_store.down.sql": {Data: []byte("DROP TABLE store;")},
+	}
+
+	tests := []struct {
+		name         string
+		version      int64
+		wantBaseline []int64
+		wantApplied  []int64
+		wantErr      bool
+	}{
+		{
+			name:         "기존 스키마를 기록한 뒤 나머지만 적용",
+			version:      1,
+			wantBaseline: []int64{1},
+			wantApplied:  []int64{2},
+		},
+		{
+			name:         "모든 버전 기록",
+			version:      2,
+			wantBaseline: []int64{1, 2},
+			wantApplied:  []int64{},
+		},
+		{
+			name:    "없는 버전",
+			version: 3,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := db.Connect(db.Config{Dialect: db.DialectSQLite, Database: ":memory:"})
+			if err != nil {
+				t.Fatalf("failed to connect database: %+v", err)
+			}
+
+			// tables.sql 로 이미 만든 테이블
+			if err := conn.Exec("CREATE TABLE admin (id int)").Error; err != nil {
+				t.Fatal(err)
+			}
+
+			migrator, err := New(conn, fsys, Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorded, err := migrator.Baseline(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Baseline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := versionsOf(recorded); !reflect.DeepEqual(got, tt.wantBaseline) {
+				t.Errorf("Baseline() versions = %v, want %v", got, tt.wantBaseline)
+			}
+
+			applied, err := migrator.Up()
+			if err != nil {
+				t.Fatalf("Up() error = %v", err)
+			}
+
+			if got := versionsOf(applied); !reflect.DeepEqual(got, tt.wantApplied) {
+				t.Errorf("Up() versions = %v, want %v", got, tt.wantApplied)
+			}
+		})
+	}
+}
+
+func versionsOf(migrations []Migration) []int64 {
+	versions := make([]int64, 0, len(migrations))
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+
+	return versions
+}
@@ -0,0 +1,72 @@
+package migrate
+
+import "strings"
+
+// SplitStatements 여러 문장으로 이루어진 SQL 을 세미콜론 기준으로 나눈다.
+// 따옴표 안의 세미콜론은 무시하고, -- 주석과 /* */ 주석은 지운다.
+// backslashEscape 가 true 이면 MySQL 처럼 문자열 안의 \ 다음 문자를 escape 된 문자로 본다.
+// SQLite 는 \ 를 일반 문자로 다루므로 false 로 호출해야 한다.
+func SplitStatements(sql string, backslashEscape bool) []string {
+	var (
+		statements   []string
+		current      strings.Builder
+		quote        rune
+		lineComment  bool
+		blockComment bool
+	)
+
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case lineComment:
+			if r == '\n' {
+				lineComment = false
+				current.WriteRune(r)
+			}
+			continue
+		case blockComment:
+			if r == '*' && i+1 < len(runes) && runes[i+1] == '/' {
+				blockComment = false
+				current.WriteRune(' ')
+				i++
+			}
+			continue
+		case quote != 0:
+			current.WriteRune(r)
+			if backslashEscape && quote != '`' && r == '\\' && i+1 < len(runes) {
+				current.WriteRune(runes[i+1])
+				i++
+				continue
+			}
+			if r == quote {
+				quote = 0
+			}
+			continue
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			lineComment = true
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			blockComment = true
+			i++
+			continue
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == ';':
+			if stmt := strings.TrimSpace(current.String()); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if stmt := strings.TrimSpace(current.String()); stmt != "" {
+		statements = append(statements, stmt)
+	}
+
+	return statements
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TypeLog     = "log"
+	TypeWebhook = "webhook"
+	TypeSMTP    = "smtp"
+)
+
+// Notification 알림 한 건, Data 는 webhook 에는 JSON 으로 그대로 보내고 메일과 로그에는 Message 만 사용한다
+type Notification struct {
+	Event     string      `json:"event"`
+	StoreSeq  int64       `json:"store_seq"`
+	Subject   string      `json:"subject"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Notifier 알림을 보내는 방식, 설정의 notifiers 로 여러 개를 함께 사용할 수 있다
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+type Config struct {
+	// Notifiers 사용할 알림 방식(log, webhook, smtp), 비어 있으면 log 만 사용한다
+	Notifiers []string      `json:"notifiers" yaml:"notifiers"`
+	Webhook   WebhookConfig `json:"webhook" yaml:"webhook"`
+	SMTP      SMTPConfig    `json:"smtp" yaml:"smtp"`
+}
+
+// New cfg.Notifiers 의 알림 방식으로 모두 보내는 Notifier 를 만든다
+func New(cfg Config) (Notifier, error) {
+	types := cfg.Notifiers
+	if len(types) == 0 {
+		types = []string{TypeLog}
+	}
+
+	notifiers := make(multiNotifier, 0, len(types))
+	for _, t := range types {
+		var (
+			notifier Notifier
+			err      error
+		)
+		switch strings.ToLower(strings.TrimSpace(t)) {
+		case TypeLog:
+			notifier = NewLogNotifier()
+		case TypeWebhook:
+			notifier, err = NewWebhookNotifier(cfg.Webhook)
+		case TypeSMTP:
+			notifier, err = NewSMTPNotifier(cfg.SMTP)
+		default:
+			err = fmt.Errorf("unknown notifier(%s)", t)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create %s notifier", t)
+		}
+
+		notifiers = append(notifiers, namedNotifier{name: t, Notifier: notifier})
+	}
+
+	return notifiers, nil
+}
+
+type namedNotifier struct {
+	name string
+	Notifier
+}
+
+// multiNotifier 하나가 실패해도 나머지에는 보내고, 실패한 알림 방식을 모아 반환한다
+type multiNotifier []namedNotifier
+
+func (m multiNotifier) Notify(ctx context.Context, n Notification) error {
+	failed := make([]string, 0)
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", notifier.name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("failed to notify [%s]", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+type logNotifier struct{}
+
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Notify(ctx context.Context, n Notification) error {
+	logrus.WithFields(logrus.Fields{
+		"event":     n.Event,
+		"store_seq": n.StoreSeq,
+	}).Warnf("%s\n%s", n.Subject, n.Message)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestNotification() Notification {
+	return Notification{
+		Event:     "item.expired",
+		StoreSeq:  1,
+		Subject:   "유통기한 경과 상품 판매 중지",
+		Message:   "우유(8801)\n.점으로 시작하는 줄",
+		Data:      map[string]int{"hidden": 1},
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "기본값은 log", cfg: Config{}},
+		{name: "여러 알림 방식", cfg: Config{Notifiers: []string{"log", "webhook"}, Webhook: WebhookConfig{URL: "http://localhost"}}},
+		{name: "webhook 주소 없음", cfg: Config{Notifiers: []string{"webhook"}}, wantErr: true},
+		{name: "smtp 수신자 없음", cfg: Config{Notifiers: []string{"smtp"}, SMTP: SMTPConfig{Addr: "localhost:1025", From: "cafe@localhost"}}, wantErr: true},
+		{name: "없는 알림 방식", cfg: Config{Notifiers: []string{"sms"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	received := make(chan Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- n
+	}))
+	defer srv.Close()
+
+	notifier, err := New(Config{Notifiers: []string{"log", "webhook"}, Webhook: WebhookConfig{URL: srv.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := notifier.Notify(context.Background(), newTestNotification()); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-received; got.Event != "item.expired" || got.StoreSeq != 1 {
+		t.Errorf("webhook received %+v", got)
+	}
+
+	// 2xx 가 아닌 응답은 실패이며, 다른 알림 방식에는 그대로 보낸다
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failed.Close()
+
+	notifier, err = New(Config{Notifiers: []string{"webhook", "log"}, Webhook: WebhookConfig{URL: failed.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := notifier.Notify(context.Background(), newTestNotification()); err == nil || !strings.Contains(err.Error(), "webhook") {
+		t.Errorf("Notify() error = %v, want webhook failure", err)
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mail := make(chan []string, 1)
+	go serveTestSMTP(t, l, mail)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Addr: l.Addr().String(), From: "cafe@localhost", To: []string{"owner@localhost"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := notifier.Notify(context.Background(), newTestNotification()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(<-mail, "\n")
+	for _, want := range []string{"MAIL FROM:<cafe@localhost>", "RCPT TO:<owner@localhost>", "Subject: =?utf-8?q?", "우유(8801)", "\n.점으로 시작하는 줄"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("mail = %q, want %q", got, want)
+		}
+	}
+}
+
+// serveTestSMTP 메일 한 통을 받아 명령과 본문을 보내는 최소한의 SMTP 서버
+func serveTestSMTP(t *testing.T, l net.Listener, mail chan<- []string) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+
+	lines := make([]string, 0)
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			t.Errorf("failed to read smtp command: %v", err)
+			return
+		}
+		lines = append(lines, line)
+
+		switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "DATA":
+			_ = tp.PrintfLine("354 end with <CR><LF>.<CR><LF>")
+			body, err := tp.ReadDotLines()
+			if err != nil {
+				t.Errorf("failed to read smtp data: %v", err)
+				return
+			}
+			lines = append(lines, body...)
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			mail <- lines
+			return
+		default:
+			_ = tp.PrintfLine("250 ok")
+		}
+	}
+}
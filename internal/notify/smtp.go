@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultSMTPTimeout = 10 * time.Second
+
+// SMTPConfig 개발 환경에서는 MailHog 같은 로컬 SMTP 서버(localhost:1025)를 사용한다.
+// Username 이 있으면 PLAIN 인증을 사용하며, net/smtp 는 localhost 가 아니면 TLS 없이 인증하지 않는다
+type SMTPConfig struct {
+	Addr     string        `json:"addr" yaml:"addr"`
+	From     string        `json:"from" yaml:"from"`
+	To       []string      `json:"to" yaml:"to"`
+	Username string        `json:"username" yaml:"username"`
+	Password string        `json:"password" yaml:"password"`
+	Timeout  time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+type smtpNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) (Notifier, error) {
+	switch {
+	case cfg.Addr == "":
+		return nil, errors.New("smtp addr is empty")
+	case cfg.From == "":
+		return nil, errors.New("smtp from is empty")
+	case len(cfg.To) == 0:
+		return nil, errors.New("smtp to is empty")
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Addr); err != nil {
+		return nil, errors.Wrapf(err, "invalid smtp addr(%s)", cfg.Addr)
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSMTPTimeout
+	}
+
+	return &smtpNotifier{cfg: cfg}, nil
+}
+
+// Notify smtp.SendMail 은 ctx 를 받지 않으므로 직접 연결하여 ctx 와 Timeout 중 빠른 시각을 deadline 으로 사용한다
+func (s *smtpNotifier) Notify(ctx context.Context, n Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect smtp server")
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	host, _, _ := net.SplitHostPort(s.cfg.Addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return errors.Wrap(err, "failed to create smtp client")
+	}
+	defer client.Close()
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, host)); err != nil {
+			return errors.Wrap(err, "failed to authenticate smtp")
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return errors.Wrap(err, "failed to set smtp sender")
+	}
+
+	for _, to := range s.cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "failed to set smtp recipient(%s)", to)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to start smtp data")
+	}
+
+	if _, err := w.Write(s.message(n)); err != nil {
+		return errors.Wrap(err, "failed to write smtp data")
+	}
+
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "failed to send mail")
+	}
+
+	return errors.WithStack(client.Quit())
+}
+
+// message 제목은 한글이 깨지지 않도록 RFC 2047 로 인코딩한다
+func (s *smtpNotifier) message(n Notification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(s.cfg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", n.CreatedAt.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(n.Message, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+type WebhookConfig struct {
+	URL     string        `json:"url" yaml:"url"`
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier Notification 을 JSON 으로 url 에 POST 한다. 2xx 가 아닌 응답은 실패로 본다
+func NewWebhookNotifier(cfg WebhookConfig) (Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("webhook url is empty")
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &webhookNotifier{url: cfg.URL, client: &http.Client{Timeout: timeout}}, nil
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send webhook")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded %d", resp.StatusCode)
+	}
+
+	return nil
+}
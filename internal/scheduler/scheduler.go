@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Job Interval 마다 실행할 작업, Run 이 반환한 결과는 Status 의 LastResult 로 조회한다
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (interface{}, error)
+}
+
+// Status 작업의 실행 상태와 마지막 실행 결과
+type Status struct {
+	Name           string
+	Interval       time.Duration
+	Running        bool
+	Runs           int64
+	Failures       int64
+	LastStartedAt  time.Time
+	LastFinishedAt time.Time
+	LastDuration   time.Duration
+	LastResult     interface{}
+	LastError      string
+	NextRunAt      time.Time
+}
+
+type job struct {
+	Job
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// Scheduler 작업마다 goroutine 을 두어 시작하자마자 한 번, 이후 Interval 마다 실행한다.
+// 같은 작업은 겹쳐서 실행하지 않으며, 실행이 Interval 보다 오래 걸리면 끝나자마자 다시 실행한다
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add 시작하기 전에만 작업을 등록할 수 있다
+func (s *Scheduler) Add(j Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.started:
+		return errors.Errorf("scheduler is already started, job(%s)", j.Name)
+	case j.Name == "":
+		return errors.New("job name is empty")
+	case j.Interval <= 0:
+		return errors.Errorf("job(%s) interval must be positive", j.Name)
+	case j.Run == nil:
+		return errors.Errorf("job(%s) run is nil", j.Name)
+	}
+
+	for _, registered := range s.jobs {
+		if registered.Name == j.Name {
+			return errors.Errorf("job(%s) is already added", j.Name)
+		}
+	}
+
+	s.jobs = append(s.jobs, &job{Job: j, status: Status{Name: j.Name, Interval: j.Interval}})
+	return nil
+}
+
+// Start ctx 가 종료되거나 Stop 을 호출할 때까지 작업을 실행한다. 두 번째 호출부터는 무시한다
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j *job) {
+			defer s.wg.Done()
+			j.loop(ctx)
+		}(j)
+	}
+}
+
+// Stop 실행 중인 작업을 취소하고 끝날 때까지 기다린다. ctx 가 먼저 끝나면 기다리지 않고 ctx 의 에러를 반환한다
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "failed to wait running jobs")
+	}
+}
+
+// Status 등록한 순서대로 작업의 상태를 반환한다
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.mu.Unlock()
+
+	result := make([]Status, 0, len(jobs))
+	for _, j := range jobs {
+		j.mu.RLock()
+		result = append(result, j.status)
+		j.mu.RUnlock()
+	}
+
+	return result
+}
+
+func (j *job) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		timer := time.NewTimer(time.Until(j.run(ctx)))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// run 작업이 panic 하더라도 실패로 기록하고 다음 주기에 다시 실행한다. 다음 실행 시각을 반환한다
+func (j *job) run(ctx context.Context) time.Time {
+	started := time.Now()
+	j.mu.Lock()
+	j.status.Running = true
+	j.status.LastStartedAt = started
+	j.mu.Unlock()
+
+	var (
+		result interface{}
+		err    error
+	)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		result, err = j.Run(ctx)
+	}()
+
+	finished := time.Now()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Running = false
+	j.status.Runs++
+	j.status.LastFinishedAt = finished
+	j.status.LastDuration = finished.Sub(started)
+	j.status.LastResult = result
+	j.status.LastError = ""
+	j.status.NextRunAt = started.Add(j.Interval)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// 종료 중에 취소된 실행은 실패로 세지 않는다
+		j.status.LastError = err.Error()
+		logrus.Infof("job(%s) is canceled: %v", j.Name, err)
+	case err != nil:
+		j.status.Failures++
+		j.status.LastError = err.Error()
+		logrus.Errorf("failed to run job(%s): %+v", j.Name, err)
+	}
+
+	return j.status.NextRunAt
+}
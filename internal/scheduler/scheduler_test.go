@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Add(t *testing.T) {
+	run := func(ctx context.Context) (interface{}, error) { return nil, nil }
+
+	s := New()
+	if err := s.Add(Job{Name: "expiry", Interval: time.Minute, Run: run}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		job  Job
+	}{
+		{name: "이름 없음", job: Job{Interval: time.Minute, Run: run}},
+		{name: "주기 없음", job: Job{Name: "purge", Run: run}},
+		{name: "실행 함수 없음", job: Job{Name: "purge", Interval: time.Minute}},
+		{name: "같은 이름", job: Job{Name: "expiry", Interval: time.Minute, Run: run}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Add(tt.job); err == nil {
+				t.Errorf("Add() error = nil, want error")
+			}
+		})
+	}
+
+	// 시작한 뒤에는 등록할 수 없다
+	s.Start(context.Background())
+	defer s.Stop(context.Background())
+	if err := s.Add(Job{Name: "purge", Interval: time.Minute, Run: run}); err == nil {
+		t.Errorf("Add() after start error = nil, want error")
+	}
+}
+
+func TestScheduler_Status(t *testing.T) {
+	var runs atomic.Int64
+	s := New()
+	if err := s.Add(Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (interface{}, error) {
+		return runs.Add(1), nil
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(Job{Name: "fail", Interval: time.Hour, Run: func(ctx context.Context) (interface{}, error) {
+		panic("boom")
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	status := s.Status()
+	if len(status) != 2 || status[0].Name != "count" || status[0].Runs < 3 || status[0].LastResult != status[0].Runs || status[0].Running {
+		t.Errorf("Status() count = %+v", status[0])
+	}
+
+	// panic 은 실패로 기록하고 주기는 그대로 유지한다
+	if fail := status[1]; fail.Runs != 1 || fail.Failures != 1 || fail.LastError != "panic: boom" || !fail.NextRunAt.Equal(fail.LastStartedAt.Add(time.Hour)) {
+		t.Errorf("Status() fail = %+v", fail)
+	}
+
+	// 멈춘 뒤에는 실행하지 않는다
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Errorf("runs after Stop() = %d, want %d", runs.Load(), stopped)
+	}
+}
+
+func TestScheduler_Stop(t *testing.T) {
+	started, finished := make(chan struct{}), make(chan struct{})
+	s := New()
+	if err := s.Add(Job{Name: "slow", Interval: time.Hour, Run: func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		close(finished)
+		return nil, ctx.Err()
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	<-started
+
+	// 실행 중인 작업이 취소를 받고 끝날 때까지 기다린다
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Errorf("Stop() returned before running job finished")
+	}
+
+	// 기다리는 시간이 지나면 ctx 의 에러를 반환한다
+	blocked := New()
+	running, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	if err := blocked.Add(Job{Name: "blocked", Interval: time.Hour, Run: func(ctx context.Context) (interface{}, error) {
+		close(running)
+		<-release
+		return nil, nil
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	blocked.Start(context.Background())
+	<-running
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := blocked.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
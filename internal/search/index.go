@@ -0,0 +1,400 @@
+package search
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrIndexRebuilding = errors.New("index is already rebuilding")
+
+// 색인 크기 추정에 사용하는 값, map 의 bucket 과 header 를 포함한 대략적인 크기이다
+const (
+	termBytes     = 12 + 48 + 48 // term 과 postings map
+	postingBytes  = 8 + 16
+	documentBytes = 8 + 32 + 48 // item_seq, Document, 매장 위치
+)
+
+type termKind uint8
+
+const (
+	termText termKind = iota + 1
+	termChosung
+)
+
+// term 글자 한두 개, 한 글자이면 gram[1] 은 0 이다
+type term struct {
+	kind termKind
+	gram [2]rune
+}
+
+// Hit 검색어와 일치한 문서, 정렬되어 있지 않다
+type Hit struct {
+	ItemSeq int64
+	Match   Match
+}
+
+type IndexStats struct {
+	Ready           bool
+	Stores          int
+	Documents       int
+	Terms           int
+	Postings        int
+	MemoryBytes     int64
+	RebuildDuration time.Duration
+	RebuiltAt       time.Time
+}
+
+// Index 매장별 검색 대상을 메모리에 두고 글자와 초성의 bigram 역색인으로 후보를 줄인다.
+// 후보는 Query.Match 로 다시 확인하므로 결과는 모든 문서를 확인한 것과 같다
+type Index struct {
+	mu sync.RWMutex
+
+	ready  bool
+	stores map[int64]*storeIndex
+	owners map[int64]int64 // item_seq → store_seq
+
+	// rebuilding 동안의 변경은 pending 에 쌓았다가 새 색인에도 적용한다
+	rebuilding bool
+	pending    []indexOp
+
+	rebuildDuration time.Duration
+	rebuiltAt       time.Time
+}
+
+type indexOp struct {
+	storeSeq int64
+	itemSeq  int64
+	doc      Document
+	remove   bool
+}
+
+type storeIndex struct {
+	docs     map[int64]Document
+	postings map[term]map[int64]struct{}
+}
+
+func NewIndex() *Index {
+	return &Index{
+		stores: make(map[int64]*storeIndex),
+		owners: make(map[int64]int64),
+	}
+}
+
+// Ready 처음 Rebuild 가 끝나기 전에는 색인에 없는 문서가 있으므로 사용하지 않는다
+func (i *Index) Ready() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.ready
+}
+
+// Put 문서를 추가하거나 바꾼다
+func (i *Index) Put(storeSeq, itemSeq int64, doc Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.apply(indexOp{storeSeq: storeSeq, itemSeq: itemSeq, doc: doc})
+}
+
+func (i *Index) Remove(itemSeq int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.apply(indexOp{itemSeq: itemSeq, remove: true})
+}
+
+func (i *Index) apply(op indexOp) {
+	if i.rebuilding {
+		i.pending = append(i.pending, op)
+	}
+
+	i.stores, i.owners = applyOp(i.stores, i.owners, op)
+}
+
+// Rebuild load 로 모든 문서를 읽어 새 색인을 만든 뒤 교체한다. 읽는 동안 호출된 Put, Remove 는 새 색인에도 반영된다
+func (i *Index) Rebuild(load func(add func(storeSeq, itemSeq int64, doc Document)) error) error {
+	i.mu.Lock()
+	if i.rebuilding {
+		i.mu.Unlock()
+		return ErrIndexRebuilding
+	}
+	i.rebuilding, i.pending = true, nil
+	i.mu.Unlock()
+
+	started := time.Now()
+	stores, owners := make(map[int64]*storeIndex), make(map[int64]int64)
+	err := load(func(storeSeq, itemSeq int64, doc Document) {
+		stores, owners = applyOp(stores, owners, indexOp{storeSeq: storeSeq, itemSeq: itemSeq, doc: doc})
+	})
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	pending := i.pending
+	i.rebuilding, i.pending = false, nil
+	if err != nil {
+		return err
+	}
+
+	for _, op := range pending {
+		stores, owners = applyOp(stores, owners, op)
+	}
+
+	i.stores, i.owners, i.ready = stores, owners, true
+	i.rebuildDuration, i.rebuiltAt = time.Since(started), time.Now()
+
+	return nil
+}
+
+func applyOp(stores map[int64]*storeIndex, owners map[int64]int64, op indexOp) (map[int64]*storeIndex, map[int64]int64) {
+	if storeSeq, ok := owners[op.itemSeq]; ok {
+		stores[storeSeq].remove(op.itemSeq)
+		if len(stores[storeSeq].docs) == 0 {
+			delete(stores, storeSeq)
+		}
+		delete(owners, op.itemSeq)
+	}
+
+	if op.remove {
+		return stores, owners
+	}
+
+	store, ok := stores[op.storeSeq]
+	if !ok {
+		store = &storeIndex{docs: make(map[int64]Document), postings: make(map[term]map[int64]struct{})}
+		stores[op.storeSeq] = store
+	}
+	store.put(op.itemSeq, op.doc)
+	owners[op.itemSeq] = op.storeSeq
+
+	return stores, owners
+}
+
+// Search 색인이 준비되지 않았으면 false 를 반환한다
+func (i *Index) Search(storeSeq int64, query Query) ([]Hit, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if !i.ready {
+		return nil, false
+	}
+
+	hits := make([]Hit, 0)
+	store, ok := i.stores[storeSeq]
+	if !ok || query.Empty() {
+		return hits, true
+	}
+
+	for _, itemSeq := range store.candidates(query) {
+		if match, ok := query.Match(store.docs[itemSeq]); ok {
+			hits = append(hits, Hit{ItemSeq: itemSeq, Match: match})
+		}
+	}
+
+	return hits, true
+}
+
+// Stats MemoryBytes 는 문서와 역색인의 크기를 추정한 값이다
+func (i *Index) Stats() IndexStats {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	stats := IndexStats{
+		Ready:           i.ready,
+		RebuildDuration: i.rebuildDuration,
+		RebuiltAt:       i.rebuiltAt,
+	}
+
+	for _, store := range i.stores {
+		store.addStats(&stats)
+	}
+
+	return stats
+}
+
+// StoreStats 한 매장의 색인만 집계한다. 색인을 다시 만든 시간은 서버 전체의 값이다
+func (i *Index) StoreStats(storeSeq int64) IndexStats {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	stats := IndexStats{
+		Ready:           i.ready,
+		RebuildDuration: i.rebuildDuration,
+		RebuiltAt:       i.rebuiltAt,
+	}
+
+	if store, ok := i.stores[storeSeq]; ok {
+		store.addStats(&stats)
+	}
+
+	return stats
+}
+
+func (s *storeIndex) addStats(stats *IndexStats) {
+	var postings int
+	for _, p := range s.postings {
+		postings += len(p)
+	}
+
+	stats.Stores++
+	stats.Documents += len(s.docs)
+	stats.Terms += len(s.postings)
+	stats.Postings += postings
+	stats.MemoryBytes += int64(len(s.postings)*termBytes + postings*postingBytes + len(s.docs)*documentBytes)
+
+	for _, doc := range s.docs {
+		stats.MemoryBytes += int64(len(doc.Name) + len(doc.Description))
+	}
+}
+
+func (s *storeIndex) put(itemSeq int64, doc Document) {
+	s.docs[itemSeq] = doc
+	for t := range documentTerms(doc) {
+		postings, ok := s.postings[t]
+		if !ok {
+			postings = make(map[int64]struct{})
+			s.postings[t] = postings
+		}
+		postings[itemSeq] = struct{}{}
+	}
+}
+
+func (s *storeIndex) remove(itemSeq int64) {
+	doc, ok := s.docs[itemSeq]
+	if !ok {
+		return
+	}
+
+	delete(s.docs, itemSeq)
+	for t := range documentTerms(doc) {
+		delete(s.postings[t], itemSeq)
+		if len(s.postings[t]) == 0 {
+			delete(s.postings, t)
+		}
+	}
+}
+
+// candidates 일치할 수 있는 문서
+//   - 이름, 설명의 부분 일치는 검색어의 모든 gram 이 있어야 한다
+//   - 오타는 한 글자당 gram 을 최대 두 개 바꾸므로, 오타를 k 개 허용하면 gram 이 (개수 - 2k) 개 이상 있어야 한다
+//   - 초성 검색은 이름의 초성에 검색어 초성의 모든 gram 이 있어야 한다
+func (s *storeIndex) candidates(query Query) []int64 {
+	n := len(query.runes)
+
+	textGrams := grams(termText, query.runes)
+	need := len(textGrams)
+	if !query.chosung {
+		need -= 2 * maxTypos(n)
+	}
+
+	if need <= 0 {
+		return s.all()
+	}
+
+	matched := s.count(textGrams, need)
+	if query.chosung {
+		chosungs := make([]rune, n)
+		for i, r := range query.runes {
+			chosungs[i] = Chosung(r)
+		}
+
+		chosungGrams := grams(termChosung, chosungs)
+		matched = append(matched, s.count(chosungGrams, len(chosungGrams))...)
+	}
+
+	return unique(matched)
+}
+
+// count gram 이 need 개 이상 있는 문서, 검색어에 같은 gram 이 여러 번 있으면 각각 센다
+func (s *storeIndex) count(terms []term, need int) []int64 {
+	counts := make(map[int64]int)
+	for _, t := range terms {
+		for itemSeq := range s.postings[t] {
+			counts[itemSeq]++
+		}
+	}
+
+	matched := make([]int64, 0)
+	for itemSeq, count := range counts {
+		if count >= need {
+			matched = append(matched, itemSeq)
+		}
+	}
+
+	return matched
+}
+
+func (s *storeIndex) all() []int64 {
+	all := make([]int64, 0, len(s.docs))
+	for itemSeq := range s.docs {
+		all = append(all, itemSeq)
+	}
+
+	return all
+}
+
+func unique(itemSeqs []int64) []int64 {
+	seen := make(map[int64]struct{}, len(itemSeqs))
+	result := itemSeqs[:0]
+	for _, itemSeq := range itemSeqs {
+		if _, ok := seen[itemSeq]; ok {
+			continue
+		}
+		seen[itemSeq] = struct{}{}
+		result = append(result, itemSeq)
+	}
+
+	return result
+}
+
+// documentTerms 이름과 설명의 글자 gram, 이름의 초성 gram
+func documentTerms(doc Document) map[term]struct{} {
+	name := normalize(doc.Name).runes
+	description := normalize(doc.Description).runes
+
+	chosungs := make([]rune, len(name))
+	for i, r := range name {
+		chosungs[i] = Chosung(r)
+	}
+
+	terms := make(map[term]struct{})
+	for _, t := range allGrams(termText, name) {
+		terms[t] = struct{}{}
+	}
+	for _, t := range allGrams(termText, description) {
+		terms[t] = struct{}{}
+	}
+	for _, t := range allGrams(termChosung, chosungs) {
+		terms[t] = struct{}{}
+	}
+
+	return terms
+}
+
+// allGrams 한 글자 검색어도 찾을 수 있도록 unigram 과 bigram 을 모두 만든다
+func allGrams(kind termKind, runes []rune) []term {
+	terms := make([]term, 0, 2*len(runes))
+	for i, r := range runes {
+		terms = append(terms, term{kind: kind, gram: [2]rune{r, 0}})
+		if i+1 < len(runes) {
+			terms = append(terms, term{kind: kind, gram: [2]rune{r, runes[i+1]}})
+		}
+	}
+
+	return terms
+}
+
+// grams 검색어가 한 글자이면 unigram, 아니면 bigram 을 만든다
+func grams(kind termKind, runes []rune) []term {
+	if len(runes) == 1 {
+		return []term{{kind: kind, gram: [2]rune{runes[0], 0}}}
+	}
+
+	terms := make([]term, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		terms = append(terms, term{kind: kind, gram: [2]rune{runes[i], runes[i+1]}})
+	}
+
+	return terms
+}
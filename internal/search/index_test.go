@@ -0,0 +1,122 @@
+package search
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var indexDocuments = map[int64]Document{
+	1: {Name: "아메리카노", Description: "진한 에스프레소"},
+	2: {Name: "아이스 아메리카노"},
+	3: {Name: "카페라떼", Description: "우유가 들어간 커피"},
+	4: {Name: "Cold Brew"},
+	5: {Name: "바닐라 라떼"},
+	6: {Name: "ㅇㅇ 스페셜"},
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := NewIndex()
+	if _, ok := index.Search(1, NewQuery("라떼")); ok {
+		t.Fatalf("Search() before Rebuild() ok = true, want false")
+	}
+
+	err := index.Rebuild(func(add func(storeSeq, itemSeq int64, doc Document)) error {
+		for itemSeq, doc := range indexDocuments {
+			add(1, itemSeq, doc)
+		}
+		add(2, 100, Document{Name: "카페라떼"})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	// 모든 문서를 Query.Match 로 확인한 결과와 같아야 한다
+	queries := []string{"아메리카노", "아메", "라떼", "ㅇㅁㄹ", "아ㅁ", "ㅇ", "아메리카누", "아메카노", "brew", "bru", "에스프레소", "커피", "ㄹ떼", "녹차", "아메리카노라떼"}
+	for _, text := range queries {
+		t.Run(text, func(t *testing.T) {
+			query := NewQuery(text)
+
+			want := make([]Hit, 0)
+			for itemSeq, doc := range indexDocuments {
+				if match, ok := query.Match(doc); ok {
+					want = append(want, Hit{ItemSeq: itemSeq, Match: match})
+				}
+			}
+
+			got, ok := index.Search(1, query)
+			if !ok {
+				t.Fatalf("Search() ok = false, want true")
+			}
+
+			sortHits(got)
+			sortHits(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Search() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	// 매장별 집계를 더하면 전체 집계와 같다
+	stats, first, second := index.Stats(), index.StoreStats(1), index.StoreStats(2)
+	if first.Documents != len(indexDocuments) || second.Documents != 1 || index.StoreStats(3).Documents != 0 {
+		t.Errorf("StoreStats() = %+v, %+v", first, second)
+	}
+	if stats.Terms != first.Terms+second.Terms || stats.MemoryBytes != first.MemoryBytes+second.MemoryBytes {
+		t.Errorf("Stats() = %+v, StoreStats() = %+v, %+v", stats, first, second)
+	}
+}
+
+func TestIndex_Put(t *testing.T) {
+	index := NewIndex()
+	err := index.Rebuild(func(add func(storeSeq, itemSeq int64, doc Document)) error {
+		add(1, 1, Document{Name: "아메리카노"})
+
+		// 다시 읽는 동안의 변경도 새 색인에 반영된다
+		index.Put(1, 2, Document{Name: "카페라떼"})
+		index.Remove(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	index.Put(1, 2, Document{Name: "바닐라 라떼"})
+	index.Put(1, 3, Document{Name: "아이스 아메리카노"})
+
+	tests := []struct {
+		name string
+		text string
+		want []int64
+	}{
+		{name: "삭제한 문서", text: "아메리카노", want: []int64{3}},
+		{name: "바꾼 문서", text: "바닐라", want: []int64{2}},
+		{name: "바꾸기 전 이름", text: "카페", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, _ := index.Search(1, NewQuery(tt.text))
+			sortHits(hits)
+
+			var got []int64
+			for _, hit := range hits {
+				got = append(got, hit.ItemSeq)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	stats := index.Stats()
+	if !stats.Ready || stats.Stores != 1 || stats.Documents != 2 || stats.MemoryBytes <= 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func sortHits(hits []Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].ItemSeq < hits[j].ItemSeq
+	})
+}
@@ -0,0 +1,272 @@
+// Package search 상품 이름과 설명에서 검색어를 찾아 점수를 매기고 일치한 위치를 알려준다.
+// 공백과 대소문자는 무시하며, 초성 검색과 작은 오타를 허용한다
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+type MatchType string
+
+const (
+	MatchExact       MatchType = "exact"
+	MatchPrefix      MatchType = "prefix"
+	MatchChosung     MatchType = "chosung"
+	MatchSubstring   MatchType = "substring"
+	MatchFuzzy       MatchType = "fuzzy"
+	MatchDescription MatchType = "description"
+)
+
+// 일치 방식별 점수, 같은 방식은 앞쪽에서 일치할수록 높다
+const (
+	scoreExact       = 1000
+	scorePrefix      = 800
+	scoreChosung     = 600
+	scoreSubstring   = 500
+	scoreFuzzy       = 300
+	scoreDescription = 100
+
+	// fuzzyPenalty 오타 한 글자당 감점
+	fuzzyPenalty = 100
+)
+
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+)
+
+// Span 원래 문자열에서 일치한 구간, rune 단위의 [Start, End) 이다
+type Span struct {
+	Start int
+	End   int
+}
+
+type Match struct {
+	Type  MatchType
+	Score int
+	Field string
+	Spans []Span
+}
+
+// Document 검색 대상
+type Document struct {
+	Name        string
+	Description string
+}
+
+// Query 검색어, 공백을 제거하고 소문자로 바꾼다
+type Query struct {
+	runes   []rune
+	chosung bool
+}
+
+func NewQuery(text string) Query {
+	q := Query{runes: normalize(text).runes}
+	for _, r := range q.runes {
+		if isChosung(r) {
+			q.chosung = true
+		}
+	}
+
+	return q
+}
+
+// Empty 공백만 있는 검색어
+func (q Query) Empty() bool {
+	return len(q.runes) == 0
+}
+
+func (q Query) String() string {
+	return string(q.runes)
+}
+
+// Match 이름에서 먼저 찾고, 이름과 일치하지 않으면 설명에서 찾는다
+func (q Query) Match(doc Document) (Match, bool) {
+	if q.Empty() {
+		return Match{}, false
+	}
+
+	name := normalize(doc.Name)
+	if match, ok := q.matchName(name); ok {
+		match.Field = FieldName
+		return match, true
+	}
+
+	description := normalize(doc.Description)
+	if i := index(description.runes, q.runes, runeEqual); i >= 0 {
+		return Match{
+			Type:  MatchDescription,
+			Score: scoreDescription,
+			Field: FieldDescription,
+			Spans: []Span{description.span(i, i+len(q.runes))},
+		}, true
+	}
+
+	return Match{}, false
+}
+
+func (q Query) matchName(name normalized) (Match, bool) {
+	n := len(q.runes)
+
+	switch i := index(name.runes, q.runes, runeEqual); {
+	case i == 0 && len(name.runes) == n:
+		return Match{Type: MatchExact, Score: scoreExact, Spans: []Span{name.span(0, n)}}, true
+	case i == 0:
+		return Match{Type: MatchPrefix, Score: scorePrefix, Spans: []Span{name.span(0, n)}}, true
+	case i > 0:
+		return Match{Type: MatchSubstring, Score: scoreSubstring - position(i), Spans: []Span{name.span(i, i+n)}}, true
+	}
+
+	// ㅇㅁㄹㅋㄴ, 아ㅁㄹ 처럼 초성이 섞인 검색어
+	if q.chosung {
+		if i := index(name.runes, q.runes, chosungEqual); i >= 0 {
+			return Match{Type: MatchChosung, Score: scoreChosung - position(i), Spans: []Span{name.span(i, i+n)}}, true
+		}
+		return Match{}, false
+	}
+
+	if distance, start, end := fuzzyIndex(name.runes, q.runes); distance <= maxTypos(n) {
+		return Match{Type: MatchFuzzy, Score: scoreFuzzy - fuzzyPenalty*(distance-1) - position(start), Spans: []Span{name.span(start, end)}}, true
+	}
+
+	return Match{}, false
+}
+
+// maxTypos 짧은 검색어는 오타를 허용하면 대부분 일치하므로 세 글자부터 허용한다
+func maxTypos(n int) int {
+	switch {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// position 앞쪽에서 일치할수록 점수를 적게 뺀다
+func position(i int) int {
+	if i > 9 {
+		return 9
+	}
+	return i
+}
+
+// normalized 공백을 제거하고 소문자로 바꾼 문자열과 각 글자의 원래 위치
+type normalized struct {
+	runes   []rune
+	offsets []int
+}
+
+func normalize(s string) normalized {
+	n := normalized{}
+	for i, r := range []rune(s) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		n.runes = append(n.runes, unicode.ToLower(r))
+		n.offsets = append(n.offsets, i)
+	}
+
+	return n
+}
+
+// span 정규화한 문자열의 [start, end) 를 원래 문자열의 구간으로 바꾼다
+func (n normalized) span(start, end int) Span {
+	return Span{Start: n.offsets[start], End: n.offsets[end-1] + 1}
+}
+
+func index(text, pattern []rune, equal func(t, p rune) bool) int {
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		matched := true
+		for j, p := range pattern {
+			if !equal(text[i+j], p) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// fuzzyIndex pattern 과 편집 거리가 가장 가까운 text 의 부분 문자열을 찾는다
+func fuzzyIndex(text, pattern []rune) (distance, start, end int) {
+	m := len(pattern)
+
+	// prev[j] : pattern[:i] 를 text[:j] 에서 끝나는 부분 문자열로 만드는 최소 편집 수, prevStart[j] 는 그 시작 위치
+	prev, cur := make([]int, len(text)+1), make([]int, len(text)+1)
+	prevStart, curStart := make([]int, len(text)+1), make([]int, len(text)+1)
+	for j := range prev {
+		prevStart[j] = j
+	}
+
+	for i := 1; i <= m; i++ {
+		cur[0], curStart[0] = i, 0
+		for j := 1; j <= len(text); j++ {
+			cost := 1
+			if text[j-1] == pattern[i-1] {
+				cost = 0
+			}
+
+			cur[j], curStart[j] = prev[j-1]+cost, prevStart[j-1]
+			if prev[j]+1 < cur[j] {
+				cur[j], curStart[j] = prev[j]+1, prevStart[j]
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j], curStart[j] = cur[j-1]+1, curStart[j-1]
+			}
+		}
+		prev, cur = cur, prev
+		prevStart, curStart = curStart, prevStart
+	}
+
+	// 편집 수가 같으면 오타 난 글자까지 포함하도록 더 뒤에서 끝나는 구간을 선택한다
+	distance, start, end = m+1, 0, 0
+	for j := 1; j <= len(text); j++ {
+		if prev[j] <= distance && prevStart[j] < j {
+			distance, start, end = prev[j], prevStart[j], j
+		}
+	}
+
+	return distance, start, end
+}
+
+func runeEqual(t, p rune) bool {
+	return t == p
+}
+
+// chosungEqual 검색어의 글자가 초성이면 음절의 초성과 비교한다
+func chosungEqual(t, p rune) bool {
+	if t == p {
+		return true
+	}
+
+	return isChosung(p) && Chosung(t) == p
+}
+
+const (
+	hangulBase  = 0xAC00
+	hangulLast  = 0xD7A3
+	chosungSize = 21 * 28
+)
+
+// chosungs 한글 음절의 초성 순서, 호환용 자모(ㄱ ~ ㅎ)이다
+var chosungs = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+
+// Chosung 한글 음절이면 초성을, 아니면 그대로 반환한다
+func Chosung(r rune) rune {
+	if r < hangulBase || r > hangulLast {
+		return r
+	}
+
+	return chosungs[(r-hangulBase)/chosungSize]
+}
+
+func isChosung(r rune) bool {
+	return strings.ContainsRune(string(chosungs), r)
+}
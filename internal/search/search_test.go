@@ -0,0 +1,122 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuery_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		doc    Document
+		want   Match
+		wantOK bool
+	}{
+		{
+			name:   "이름 전체 일치",
+			query:  "아메리카노",
+			doc:    Document{Name: "아메리카노"},
+			want:   Match{Type: MatchExact, Score: scoreExact, Field: FieldName, Spans: []Span{{Start: 0, End: 5}}},
+			wantOK: true,
+		},
+		{
+			name:   "띄어쓰기가 달라도 일치",
+			query:  "아이스 아메리카노",
+			doc:    Document{Name: "아이스아메리카노"},
+			want:   Match{Type: MatchExact, Score: scoreExact, Field: FieldName, Spans: []Span{{Start: 0, End: 8}}},
+			wantOK: true,
+		},
+		{
+			name:   "앞부분 일치",
+			query:  "아이스아메",
+			doc:    Document{Name: "아이스 아메리카노"},
+			want:   Match{Type: MatchPrefix, Score: scorePrefix, Field: FieldName, Spans: []Span{{Start: 0, End: 6}}},
+			wantOK: true,
+		},
+		{
+			name:   "초성",
+			query:  "ㅇㅁㄹ",
+			doc:    Document{Name: "아이스 아메리카노"},
+			want:   Match{Type: MatchChosung, Score: scoreChosung - 3, Field: FieldName, Spans: []Span{{Start: 4, End: 7}}},
+			wantOK: true,
+		},
+		{
+			name:   "대소문자 무시한 부분 일치",
+			query:  "brew",
+			doc:    Document{Name: "Cold Brew"},
+			want:   Match{Type: MatchSubstring, Score: scoreSubstring - 4, Field: FieldName, Spans: []Span{{Start: 5, End: 9}}},
+			wantOK: true,
+		},
+		{
+			name:   "오타 한 글자",
+			query:  "아메리카누",
+			doc:    Document{Name: "아이스 아메리카노"},
+			want:   Match{Type: MatchFuzzy, Score: scoreFuzzy - 3, Field: FieldName, Spans: []Span{{Start: 4, End: 9}}},
+			wantOK: true,
+		},
+		{
+			name:   "한 글자 빠짐",
+			query:  "아메카노",
+			doc:    Document{Name: "아메리카노"},
+			want:   Match{Type: MatchFuzzy, Score: scoreFuzzy, Field: FieldName, Spans: []Span{{Start: 0, End: 5}}},
+			wantOK: true,
+		},
+		{
+			name:   "설명 일치",
+			query:  "에스프레소",
+			doc:    Document{Name: "아메리카노", Description: "진한 에스프레소에 물"},
+			want:   Match{Type: MatchDescription, Score: scoreDescription, Field: FieldDescription, Spans: []Span{{Start: 3, End: 8}}},
+			wantOK: true,
+		},
+		{
+			name:   "짧은 검색어는 오타 허용하지 않음",
+			query:  "라테",
+			doc:    Document{Name: "카페라떼"},
+			wantOK: false,
+		},
+		{
+			name:   "오타가 많으면 불일치",
+			query:  "녹차라떼",
+			doc:    Document{Name: "아메리카노"},
+			wantOK: false,
+		},
+		{
+			name:   "공백만 있는 검색어",
+			query:  " ",
+			doc:    Document{Name: "아메리카노"},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewQuery(tt.query).Match(tt.doc)
+			if ok != tt.wantOK {
+				t.Fatalf("Match() ok = %v, want %v (%+v)", ok, tt.wantOK, got)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChosung(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want rune
+	}{
+		{name: "첫 음절", r: '가', want: 'ㄱ'},
+		{name: "마지막 음절", r: '힣', want: 'ㅎ'},
+		{name: "쌍자음", r: '떼', want: 'ㄸ'},
+		{name: "한글이 아닌 글자", r: 'a', want: 'a'},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chosung(tt.r); got != tt.want {
+				t.Errorf("Chosung() = %c, want %c", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"hello-cafe/model"
+	"hello-cafe/service"
+)
+
+const (
+	auditActorKey  = "audit_actor"
+	auditTargetKey = "audit_target"
+
+	// auditRecordTimeout 요청이 취소되거나 시간이 초과되어도 감사 기록은 남긴다
+	auditRecordTimeout = 3 * time.Second
+
+	maxUserAgentLength = 255
+)
+
+// Auditor 라우트마다 action 을 지정해 감사 기록 middleware 를 만든다
+type Auditor func(action model.AuditAction) gin.HandlerFunc
+
+// NewAuditor handler 가 끝난 뒤 응답 코드로 결과를 판단해 기록한다.
+// 인증된 요청은 principal 을, 인증 전 요청은 handler 가 SetAuditActor 로 지정한 관리자를 기록한다.
+// 기록에 실패해도 요청은 실패시키지 않는다.
+func NewAuditor(auditService service.AuditService) Auditor {
+	return func(action model.AuditAction) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+
+			log := model.AuditLog{
+				AdminSeq:  c.GetInt64(auditActorKey),
+				Action:    action,
+				Target:    auditTarget(c),
+				Outcome:   model.AuditOutcomeSuccess,
+				Status:    c.Writer.Status(),
+				IP:        c.ClientIP(),
+				UserAgent: truncate(c.Request.UserAgent(), maxUserAgentLength),
+				RequestID: GetRequestID(c),
+				RegDT:     time.Now(),
+			}
+
+			if log.Status >= http.StatusBadRequest {
+				log.Outcome = model.AuditOutcomeFailure
+			}
+
+			if principal, err := GetPrincipal(c); err == nil {
+				log.AdminSeq = principal.AdminSeq
+				log.StoreSeq = principal.StoreSeq
+			}
+
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), auditRecordTimeout)
+			defer cancel()
+
+			if err := auditService.Record(ctx, log); err != nil {
+				logrus.Errorf("failed to record audit log(%s): %+v", action, err)
+			}
+		}
+	}
+}
+
+// SetAuditActor 인증 전 요청에서 확인된 관리자를 기록한다
+func SetAuditActor(c *gin.Context, adminSeq int64) {
+	c.Set(auditActorKey, adminSeq)
+}
+
+// SetAuditTarget 요청 경로로 알 수 없는 대상을 기록한다
+func SetAuditTarget(c *gin.Context, target string) {
+	c.Set(auditTargetKey, target)
+}
+
+// auditTarget 지정된 대상이 없으면 요청 경로의 파라미터를 사용한다
+func auditTarget(c *gin.Context) string {
+	if target := c.GetString(auditTargetKey); target != "" {
+		return target
+	}
+
+	params := make([]string, 0, len(c.Params))
+	for _, param := range c.Params {
+		params = append(params, fmt.Sprintf("%s=%s", param.Key, param.Value))
+	}
+
+	return truncate(strings.Join(params, ","), 100)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return strings.ToValidUTF8(s[:n], "")
+}
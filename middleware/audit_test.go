@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/model/response"
+)
+
+type recordingAuditService struct {
+	logs model.AuditLogs
+}
+
+func (s *recordingAuditService) Record(ctx context.Context, log model.AuditLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *recordingAuditService) Find(ctx context.Context, storeSeq int64, req request.FindAudit) (*model.AuditLogPage, error) {
+	return &model.AuditLogPage{Logs: s.logs}, nil
+}
+
+func TestNewAuditor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type args struct {
+		principal *model.Principal
+		actor     int64
+		code      int
+	}
+	tests := []struct {
+		name string
+		args args
+		want model.AuditLog
+	}{
+		{
+			name: "인증된 요청 성공",
+			args: args{
+				principal: &model.Principal{AdminSeq: 1, StoreSeq: 2},
+				code:      http.StatusOK,
+			},
+			want: model.AuditLog{StoreSeq: 2, AdminSeq: 1, Outcome: model.AuditOutcomeSuccess, Status: http.StatusOK},
+		},
+		{
+			name: "인증된 요청 실패",
+			args: args{
+				principal: &model.Principal{AdminSeq: 1, StoreSeq: 2},
+				code:      http.StatusPreconditionFailed,
+			},
+			want: model.AuditLog{StoreSeq: 2, AdminSeq: 1, Outcome: model.AuditOutcomeFailure, Status: http.StatusPreconditionFailed},
+		},
+		{
+			name: "인증 전 요청은 handler 가 지정한 관리자",
+			args: args{
+				actor: 3,
+				code:  http.StatusOK,
+			},
+			want: model.AuditLog{AdminSeq: 3, Outcome: model.AuditOutcomeSuccess, Status: http.StatusOK},
+		},
+		{
+			name: "알 수 없는 관리자",
+			args: args{
+				code: http.StatusUnauthorized,
+			},
+			want: model.AuditLog{Outcome: model.AuditOutcomeFailure, Status: http.StatusUnauthorized},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditService := &recordingAuditService{}
+			audit := NewAuditor(auditService)
+
+			engine := gin.New()
+			engine.Use(NewRequestIDMiddleware())
+			engine.PUT("/items/:item_seq", func(c *gin.Context) {
+				if tt.args.principal != nil {
+					c.Set(principalKey, tt.args.principal)
+				}
+				c.Next()
+			}, audit(model.AuditActionItemUpdate), func(c *gin.Context) {
+				if tt.args.actor > 0 {
+					SetAuditActor(c, tt.args.actor)
+				}
+				c.JSON(response.SimpleSuccess(tt.args.code))
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/items/10", nil)
+			req.Header.Set("User-Agent", "test-agent")
+			req.Header.Set(RequestIDHeader, "request-1")
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			if len(auditService.logs) != 1 {
+				t.Fatalf("NewAuditor() recorded = %d, want 1", len(auditService.logs))
+			}
+
+			got := auditService.logs[0]
+			want := tt.want
+			want.Action = model.AuditActionItemUpdate
+			want.Target = "item_seq=10"
+			want.IP = got.IP
+			want.UserAgent = "test-agent"
+			want.RequestID = "request-1"
+			want.RegDT = got.RegDT
+			if got != want {
+				t.Errorf("NewAuditor() got = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
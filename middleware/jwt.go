@@ -1,36 +1,104 @@
 package middleware
 
 import (
-	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
 	"hello-cafe/internal/apierror"
 	"hello-cafe/internal/internaljwt"
-	"hello-cafe/internal/valid"
+	"hello-cafe/model"
 	"hello-cafe/model/response"
+	"hello-cafe/service"
 )
 
-func TokenAuthMiddleware(c *gin.Context) {
-	strToken := c.Request.Header.Get("access-token")
+const (
+	principalKey = "principal"
 
-	if strToken == "" {
-		c.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAccessToken))
-		return
-	}
+	// StoreSeqHeader 기본 매장이 아닌 다른 소속 매장을 선택할 때 사용하는 헤더
+	StoreSeqHeader = "store-seq"
+)
+
+// NewTokenAuthMiddleware access token 을 검증하고 로그아웃 된 토큰은 거부한다.
+// store-seq 헤더가 있으면 해당 매장을 활성 매장으로 사용한다.
+// 토큰 발급 이후 권한이 바뀌거나 매장에서 제외될 수 있으므로 권한은 항상 매장 소속 정보에서 확인한다.
+func NewTokenAuthMiddleware(tokenService service.TokenService, storeService service.StoreService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		strToken := c.Request.Header.Get("access-token")
+
+		if strToken == "" {
+			c.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAccessToken))
+			return
+		}
+
+		claims, err := internaljwt.ParseJWT(strToken)
+		if err != nil {
+			c.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAccessToken))
+			return
+		}
+
+		revoked, err := tokenService.IsRevoked(c.Request.Context(), claims.Id)
+		if err != nil {
+			c.AbortWithStatusJSON(response.Failure(err))
+			return
+		}
+
+		if revoked {
+			c.AbortWithStatusJSON(response.Failure(apierror.ErrAlreadyLogout))
+			return
+		}
 
-	token, err := jwt.Parse(strToken, func(token *jwt.Token) (interface{}, error) {
-		return []byte(internaljwt.GetSecretKey()), nil
-	})
+		// refresh token 재사용으로 폐기된 계열에서 발급된 토큰도 거부한다
+		if claims.FamilyID != "" {
+			if revoked, err = tokenService.IsFamilyRevoked(c.Request.Context(), claims.FamilyID); err != nil {
+				c.AbortWithStatusJSON(response.Failure(err))
+				return
+			}
+
+			if revoked {
+				c.AbortWithStatusJSON(response.Failure(apierror.ErrReusedRefreshToken))
+				return
+			}
+		}
+
+		storeSeq := claims.StoreSeq
+		if header := c.Request.Header.Get(StoreSeqHeader); header != "" {
+			if storeSeq, err = strconv.ParseInt(header, 10, 64); err != nil {
+				c.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidStore))
+				return
+			}
+		}
+
+		role, err := storeService.GetRole(c.Request.Context(), storeSeq, claims.AdminSeq)
+		if err != nil {
+			c.AbortWithStatusJSON(response.Failure(err))
+			return
+		}
+
+		c.Set(principalKey, &model.Principal{
+			AdminSeq: claims.AdminSeq,
+			StoreSeq: storeSeq,
+			Role:     role,
+			Phone:    claims.Subject,
+			TokenID:  claims.Id,
+			ExpireDT: time.Unix(claims.ExpiresAt, 0),
+		})
+
+		c.Next()
+	}
+}
 
-	if !valid.IsNil(token) {
-		fmt.Printf("token raw : %s\n", token.Raw)
+// GetPrincipal NewTokenAuthMiddleware 가 저장한 인증 정보를 가져온다
+func GetPrincipal(c *gin.Context) (*model.Principal, error) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil, apierror.ErrInvalidAccessToken
 	}
 
-	if err != nil {
-		c.AbortWithStatusJSON(response.Failure(apierror.ErrInvalidAccessToken))
-		return
+	principal, ok := v.(*model.Principal)
+	if !ok || principal.AdminSeq <= 0 {
+		return nil, apierror.ErrInvalidAccessToken
 	}
 
-	c.Next()
+	return principal, nil
 }
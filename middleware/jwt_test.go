@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/repository/memory"
+	"hello-cafe/service"
+)
+
+func TestNewTokenAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	type args struct {
+		revoke       bool
+		reuseRefresh bool
+		leaveStore   bool
+		accessToken  func(token string) string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+	}{
+		{
+			name: "유효한 토큰",
+			args: args{
+				accessToken: func(token string) string { return token },
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "로그아웃 된 토큰",
+			args: args{
+				revoke:      true,
+				accessToken: func(token string) string { return token },
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "refresh token 재사용으로 폐기된 계열의 토큰",
+			args: args{
+				reuseRefresh: true,
+				accessToken:  func(token string) string { return token },
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "토큰 발급 이후 매장에서 제외된 관리자",
+			args: args{
+				leaveStore:  true,
+				accessToken: func(token string) string { return token },
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "토큰 누락",
+			args: args{
+				accessToken: func(token string) string { return "" },
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "잘못된 토큰",
+			args: args{
+				accessToken: func(token string) string { return token + "x" },
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+
+			tokenService, err := service.NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			storeService, err := service.NewStoreService(repo)
+			require.NoError(t, err)
+
+			adminService, err := service.NewAdminService(repo, tokenService)
+			require.NoError(t, err)
+			_, err = adminService.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+			require.NoError(t, err)
+
+			token, err := adminService.SignIn(ctx, "010-1234-1111", "12341234")
+			require.NoError(t, err)
+
+			if tt.args.revoke {
+				claims, err := internaljwt.ParseJWT(token.AccessToken)
+				require.NoError(t, err)
+				require.NoError(t, tokenService.Revoke(ctx, claims.AdminSeq, claims.Id, time.Unix(claims.ExpiresAt, 0), ""))
+			}
+
+			if tt.args.reuseRefresh {
+				_, err := tokenService.Refresh(ctx, token.RefreshToken)
+				require.NoError(t, err)
+
+				_, err = tokenService.Refresh(ctx, token.RefreshToken)
+				require.Error(t, err)
+			}
+
+			if tt.args.leaveStore {
+				require.NoError(t, repo.StoreMember().Delete(ctx, 1, 1))
+			}
+
+			engine := gin.New()
+			engine.GET("/", NewTokenAuthMiddleware(tokenService, storeService), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("access-token", tt.args.accessToken(token.AccessToken))
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("NewTokenAuthMiddleware() code = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
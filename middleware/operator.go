@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model/response"
+)
+
+// OperatorTokenHeader 서버 운영 API 인증 헤더
+const OperatorTokenHeader = "X-Operator-Token"
+
+// RequireOperator 매장 권한과 관계없이 서버 전체의 정보를 다루는 운영 API 를 설정의 operator_token 으로 보호한다.
+// token 이 비어 있으면 모든 요청을 거부한다.
+func RequireOperator(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader(OperatorTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(response.Failure(apierror.ErrForbiddenOperator))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireOperator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		token    string
+		header   string
+		wantCode int
+	}{
+		{name: "운영자 토큰 일치", token: "secret", header: "secret", wantCode: http.StatusOK},
+		{name: "운영자 토큰 불일치", token: "secret", header: "wrong", wantCode: http.StatusForbidden},
+		{name: "운영자 토큰 누락", token: "secret", header: "", wantCode: http.StatusForbidden},
+		{name: "운영자 토큰 미설정", token: "", header: "", wantCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", RequireOperator(tt.token), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(OperatorTokenHeader, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader 요청마다 부여하는 ID, 클라이언트가 보낸 값이 있으면 그대로 사용한다
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey = "request_id"
+)
+
+// requestIDRegexp 로그와 감사 기록에 남기므로 허용하는 문자와 길이를 제한한다
+var requestIDRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// NewRequestIDMiddleware 요청 ID 를 context 에 저장하고 응답 헤더로 돌려준다
+func NewRequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDRegexp.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{
+			name:     "클라이언트가 보낸 ID 사용",
+			header:   "request-1",
+			wantSame: true,
+		},
+		{
+			name:     "ID 가 없으면 생성",
+			header:   "",
+			wantSame: false,
+		},
+		{
+			name:     "허용하지 않는 문자가 있으면 생성",
+			header:   "request 1\n",
+			wantSame: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			engine := gin.New()
+			engine.GET("/", NewRequestIDMiddleware(), func(c *gin.Context) {
+				got = GetRequestID(c)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if got == "" || w.Header().Get(RequestIDHeader) != got {
+				t.Fatalf("NewRequestIDMiddleware() request id = %q, header = %q", got, w.Header().Get(RequestIDHeader))
+			}
+
+			if (got == tt.header) != tt.wantSame {
+				t.Errorf("NewRequestIDMiddleware() request id = %q, header %q", got, tt.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/response"
+)
+
+// RequireRole 인증된 관리자의 권한이 roles 중 하나가 아니면 요청을 거부한다.
+// NewTokenAuthMiddleware 다음에 등록해야 한다.
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := GetPrincipal(c)
+		if err != nil {
+			c.AbortWithStatusJSON(response.Failure(err))
+			return
+		}
+
+		if !principal.Role.In(roles...) {
+			c.AbortWithStatusJSON(response.Failure(apierror.ErrForbidden))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/model"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type args struct {
+		principal *model.Principal
+		roles     []model.Role
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+	}{
+		{
+			name: "권한 일치",
+			args: args{
+				principal: &model.Principal{AdminSeq: 1, StoreSeq: 1, Role: model.RoleManager},
+				roles:     []model.Role{model.RoleOwner, model.RoleManager},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "권한 부족",
+			args: args{
+				principal: &model.Principal{AdminSeq: 2, StoreSeq: 1, Role: model.RoleStaff},
+				roles:     []model.Role{model.RoleOwner},
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "인증 정보 누락",
+			args: args{
+				principal: nil,
+				roles:     []model.Role{model.RoleStaff},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", func(c *gin.Context) {
+				if tt.args.principal != nil {
+					c.Set(principalKey, tt.args.principal)
+				}
+				c.Next()
+			}, RequireRole(tt.args.roles...), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("RequireRole() code = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
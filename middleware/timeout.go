@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewTimeoutMiddleware 요청 context 에 timeout 을 설정한다.
+// service 와 repository 는 이 context 로 DB 를 조회하므로, 시간이 초과되거나 클라이언트가 연결을 끊으면 쿼리도 취소된다.
+func NewTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
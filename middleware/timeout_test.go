@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"hello-cafe/model/response"
+)
+
+func TestNewTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type args struct {
+		timeout time.Duration
+		work    time.Duration
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+	}{
+		{
+			name: "시간 내 처리",
+			args: args{
+				timeout: time.Second,
+				work:    0,
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "시간 초과",
+			args: args{
+				timeout: 10 * time.Millisecond,
+				work:    time.Second,
+			},
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", NewTimeoutMiddleware(tt.args.timeout), func(c *gin.Context) {
+				// 쿼리 대신 context 가 취소될 때까지 기다린다
+				select {
+				case <-c.Request.Context().Done():
+					c.JSON(response.Failure(c.Request.Context().Err()))
+				case <-time.After(tt.args.work):
+					c.JSON(response.SimpleSuccess(http.StatusOK))
+				}
+			})
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background()))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("NewTimeoutMiddleware() code = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type Members []Member
+
+type Member struct {
+	AdminSeq int64     `json:"admin_seq"`
+	Phone    string    `json:"phone"`
+	Name     string    `json:"name"`
+	Role     Role      `json:"role"`
+	RegDT    time.Time `json:"reg_dt"`
+}
+
+// InvitedMember 초대 결과이며, 이미 가입된 계정을 매장에 추가한 경우 ExistingAccount 가 true 이다
+type InvitedMember struct {
+	Member
+	ExistingAccount bool `json:"existing_account"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionSignUp  AuditAction = "admin.sign_up"
+	AuditActionSignIn  AuditAction = "admin.sign_in"
+	AuditActionSignOut AuditAction = "admin.sign_out"
+
+	AuditActionMemberInvite     AuditAction = "member.invite"
+	AuditActionMemberUpdateRole AuditAction = "member.update_role"
+	AuditActionMemberDelete     AuditAction = "member.delete"
+
+	AuditActionItemCreate  AuditAction = "item.create"
+	AuditActionItemUpdate  AuditAction = "item.update"
+	AuditActionItemDelete  AuditAction = "item.delete"
+	AuditActionItemRestore AuditAction = "item.restore"
+	AuditActionItemImport  AuditAction = "item.import"
+
+	// 옵션 그룹과 옵션의 변경은 같은 action 으로 남긴다
+	AuditActionItemOptionCreate AuditAction = "item_option.create"
+	AuditActionItemOptionUpdate AuditAction = "item_option.update"
+	AuditActionItemOptionDelete AuditAction = "item_option.delete"
+
+	AuditActionStockMove            AuditAction = "stock.move"
+	AuditActionStockUpdateThreshold AuditAction = "stock.update_threshold"
+	AuditActionLotCreate            AuditAction = "lot.create"
+	AuditActionLotConsume           AuditAction = "lot.consume"
+
+	AuditActionCategoryCreate AuditAction = "category.create"
+	AuditActionCategoryUpdate AuditAction = "category.update"
+	AuditActionCategoryDelete AuditAction = "category.delete"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+type AuditLogs []AuditLog
+
+type AuditLog struct {
+	AuditSeq  int64        `json:"audit_seq"`
+	StoreSeq  int64        `json:"store_seq"`
+	AdminSeq  int64        `json:"admin_seq"`
+	Action    AuditAction  `json:"action"`
+	Target    string       `json:"target"`
+	Outcome   AuditOutcome `json:"outcome"`
+	Status    int          `json:"status"`
+	IP        string       `json:"ip"`
+	UserAgent string       `json:"user_agent"`
+	RequestID string       `json:"request_id"`
+	RegDT     time.Time    `json:"reg_dt"`
+}
+
+// AuditLogPage next_cursor 가 비어 있으면 마지막 페이지다
+type AuditLogPage struct {
+	Logs       AuditLogs `json:"logs"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type Categories []Category
+
+// Category 하위 카테고리는 Children 에 노출 순서대로 담긴다
+type Category struct {
+	CategorySeq  int64      `json:"category_seq"`
+	ParentSeq    int64      `json:"parent_seq"`
+	Name         string     `json:"name"`
+	DisplayOrder int        `json:"display_order"`
+	RegDT        time.Time  `json:"reg_dt"`
+	Children     Categories `json:"children"`
+}
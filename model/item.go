@@ -5,16 +5,32 @@ import "time"
 type Items []Item
 
 type Item struct {
-	ItemSeq     int64     `json:"item_seq,omitempty"`
-	AdminSeq    int64     `json:"admin_seq,omitempty"`
-	Category    int       `json:"category,omitempty"`
-	Barcode     string    `json:"barcode,omitempty"`
-	Price       int64     `json:"price,omitempty"`
-	Cost        int64     `json:"cost,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Description string    `json:"description,omitempty"`
-	ExpireDT    time.Time `json:"expire_dt"`
-	Size        int       `json:"size,omitempty"`
-	RegDT       time.Time `json:"reg_dt"`
-	ModDT       time.Time `json:"mod_dt"`
+	ItemSeq     int64      `json:"item_seq,omitempty"`
+	StoreSeq    int64      `json:"store_seq,omitempty"`
+	Category    int64      `json:"category,omitempty"`
+	Barcode     string     `json:"barcode,omitempty"`
+	Price       int64      `json:"price,omitempty"`
+	Cost        int64      `json:"cost,omitempty"`
+	Margin      int64      `json:"margin"`
+	Name        string     `json:"name,omitempty"`
+	Consonant   string     `json:"consonant,omitempty"`
+	Description string     `json:"description,omitempty"`
+	ExpireDT    time.Time  `json:"expire_dt"`
+	Size        int        `json:"size,omitempty"`
+	Version     int64      `json:"version"`
+	RegDT       time.Time  `json:"reg_dt"`
+	ModDT       time.Time  `json:"mod_dt"`
+	DeletedDT   *time.Time `json:"deleted_dt,omitempty"`
+	Unavailable *time.Time `json:"unavailable_dt,omitempty"` // 유통기한이 지나 판매 중지된 시각
+	Stock       *ItemStock `json:"stock,omitempty"`
+
+	// 상품 상세 조회에서만 내려준다
+	OptionGroups ItemOptionGroups `json:"option_groups,omitempty"`
+	PriceRange   *ItemPriceRange  `json:"price_range,omitempty"`
+	Quote        *ItemQuote       `json:"quote,omitempty"`
+}
+
+type ItemPage struct {
+	Items      Items
+	Pagination Pagination
 }
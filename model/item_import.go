@@ -0,0 +1,29 @@
+package model
+
+type ItemImportStatus string
+
+const (
+	ItemImportCreated    ItemImportStatus = "created"
+	ItemImportReady      ItemImportStatus = "ready"      // dry run 또는 다른 행의 실패로 등록되지 않았지만 등록 가능한 행
+	ItemImportDuplicated ItemImportStatus = "duplicated" // 이미 등록된 바코드라서 건너뛴 행
+	ItemImportInvalid    ItemImportStatus = "invalid"    // 값이 잘못되어 등록할 수 없는 행
+)
+
+type ItemImportRow struct {
+	Line    int              `json:"line"`
+	Barcode string           `json:"barcode,omitempty"`
+	Name    string           `json:"name,omitempty"`
+	Status  ItemImportStatus `json:"status"`
+	Message string           `json:"message,omitempty"`
+}
+
+// ItemImportResult applied 가 false 이면 아무것도 등록되지 않았다
+type ItemImportResult struct {
+	DryRun     bool            `json:"dry_run"`
+	Mode       string          `json:"mode"`
+	Applied    bool            `json:"applied"`
+	Created    int             `json:"created"`
+	Duplicated int             `json:"duplicated"`
+	Invalid    int             `json:"invalid"`
+	Rows       []ItemImportRow `json:"rows"`
+}
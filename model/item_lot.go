@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type ItemLots []ItemLot
+
+// ItemLot Quantity 는 입고한 수량이고 Remaining 은 남은 수량이다
+type ItemLot struct {
+	LotSeq     int64     `json:"lot_seq"`
+	ItemSeq    int64     `json:"item_seq"`
+	LotNumber  string    `json:"lot_number"`
+	Quantity   int64     `json:"quantity"`
+	Remaining  int64     `json:"remaining"`
+	ReceivedDT time.Time `json:"received_dt"`
+	ExpireDT   time.Time `json:"expire_dt"`
+	Expired    bool      `json:"expired"`
+}
+
+type ExpiringLots []ExpiringLot
+
+// ExpiringLot 유통기한 임박 lot 과 상품 정보
+type ExpiringLot struct {
+	ItemLot
+	Barcode string `json:"barcode"`
+	Name    string `json:"name"`
+}
@@ -0,0 +1,44 @@
+package model
+
+type ItemOptionGroups []ItemOptionGroup
+
+// ItemOptionGroup max_select 0 은 개수 제한이 없다
+type ItemOptionGroup struct {
+	OptionGroupSeq int64       `json:"option_group_seq"`
+	Name           string      `json:"name"`
+	Required       bool        `json:"required"`
+	MultiSelect    bool        `json:"multi_select"`
+	MinSelect      int         `json:"min_select"`
+	MaxSelect      int         `json:"max_select"`
+	DisplayOrder   int         `json:"display_order"`
+	Options        ItemOptions `json:"options"`
+}
+
+type ItemOptions []ItemOption
+
+// ItemOption Price, Cost 는 상품 가격, 원가에 옵션의 추가 가격, 원가를 더한 값이다
+type ItemOption struct {
+	OptionSeq    int64  `json:"option_seq"`
+	Name         string `json:"name"`
+	PriceDelta   int64  `json:"price_delta"`
+	CostDelta    int64  `json:"cost_delta"`
+	Price        int64  `json:"price"`
+	Cost         int64  `json:"cost"`
+	Barcode      string `json:"barcode,omitempty"`
+	SKU          string `json:"sku,omitempty"`
+	DisplayOrder int    `json:"display_order"`
+}
+
+// ItemPriceRange 옵션 그룹의 선택 조건을 지켜서 고를 수 있는 최저, 최고 가격
+type ItemPriceRange struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+}
+
+// ItemQuote 선택한 옵션을 반영한 가격
+type ItemQuote struct {
+	OptionSeqs []int64 `json:"option_seqs"`
+	Price      int64   `json:"price"`
+	Cost       int64   `json:"cost"`
+	Margin     int64   `json:"margin"`
+}
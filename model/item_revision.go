@@ -0,0 +1,35 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ItemChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+type ItemRevisions []ItemRevision
+
+// ItemRevision 상품의 등록, 수정, 삭제, 복구 이력
+type ItemRevision struct {
+	RevisionSeq int64                 `json:"revision_seq"`
+	ItemSeq     int64                 `json:"item_seq"`
+	AdminSeq    int64                 `json:"admin_seq"`
+	Action      string                `json:"action"`
+	Version     int64                 `json:"version"`
+	Changes     map[string]ItemChange `json:"changes"`
+	RegDT       time.Time             `json:"reg_dt"`
+}
+
+type ItemPrices []ItemPrice
+
+// ItemPrice ChangedDT 부터 적용된 가격과 원가
+type ItemPrice struct {
+	RevisionSeq int64     `json:"revision_seq"`
+	Price       int64     `json:"price"`
+	Cost        int64     `json:"cost"`
+	AdminSeq    int64     `json:"admin_seq"`
+	ChangedDT   time.Time `json:"changed_dt"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Highlight 일치한 구간, 글자(rune) 단위의 [start, end) 이다
+type Highlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// ItemMatch 검색어와 일치한 방식(exact, prefix, chosung, substring, fuzzy, description)과 위치
+type ItemMatch struct {
+	Type       string      `json:"type"`
+	Score      int         `json:"score"`
+	Field      string      `json:"field"`
+	Highlights []Highlight `json:"highlights"`
+}
+
+type SearchedItems []SearchedItem
+
+type SearchedItem struct {
+	Item
+	Match ItemMatch `json:"match"`
+}
+
+type ItemSearchPage struct {
+	Items      SearchedItems
+	Pagination Pagination
+}
+
+// SearchIndexStats 매장의 상품 검색 색인 상태, memory_bytes 는 추정치이다
+type SearchIndexStats struct {
+	Ready         bool       `json:"ready"`
+	Items         int        `json:"items"`
+	Terms         int        `json:"terms"`
+	Postings      int        `json:"postings"`
+	MemoryBytes   int64      `json:"memory_bytes"`
+	RebuildMillis int64      `json:"rebuild_ms"`
+	RebuiltDT     *time.Time `json:"rebuilt_dt,omitempty"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// ItemStock LowStockThreshold 는 상품에 설정한 기준이며, 없으면 기본값이고 DefaultThreshold 가 true 이다
+type ItemStock struct {
+	Quantity          int64     `json:"quantity"`
+	LowStockThreshold int64     `json:"low_stock_threshold"`
+	DefaultThreshold  bool      `json:"default_threshold"`
+	LowStock          bool      `json:"low_stock"`
+	ModDT             time.Time `json:"mod_dt"`
+}
+
+type StockMovements []StockMovement
+
+// StockMovement 재고 변동 원장, Quantity 는 감소일 때 음수이고 Balance 는 변동 후 재고이다
+type StockMovement struct {
+	MovementSeq      int64     `json:"movement_seq"`
+	ItemSeq          int64     `json:"item_seq"`
+	StoreSeq         int64     `json:"store_seq"`
+	AdminSeq         int64     `json:"admin_seq"`
+	Type             string    `json:"type"`
+	Quantity         int64     `json:"quantity"`
+	Balance          int64     `json:"balance"`
+	Reason           string    `json:"reason"`
+	TransferStoreSeq *int64    `json:"transfer_store_seq,omitempty"`
+	LotSeq           *int64    `json:"lot_seq,omitempty"`
+	RegDT            time.Time `json:"reg_dt"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// Pagination 리스트 응답의 페이지 정보, next_cursor 를 다음 요청의 cursor 로 보낸다
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+
+	// Total with_total=true 로 요청한 경우에만 조건에 맞는 전체 개수를 내려준다
+	Total *int64 `json:"total,omitempty"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Principal access token 으로 인증된 관리자 정보
+type Principal struct {
+	AdminSeq int64
+	StoreSeq int64
+	Role     Role
+	Phone    string
+	TokenID  string
+	ExpireDT time.Time
+}
@@ -32,8 +32,9 @@ func (a *Admin) Validate() error {
 }
 
 type SignOut struct {
-	Phone *string `json:"phone"`
-	Token *string `json:"token"`
+	Phone        *string `json:"phone"`
+	Token        *string `json:"token"`
+	RefreshToken string  `json:"refresh_token"`
 }
 
 func (l *SignOut) Validate() error {
@@ -50,3 +51,15 @@ func (l *SignOut) Validate() error {
 
 	return nil
 }
+
+type RefreshToken struct {
+	RefreshToken *string `json:"refresh_token"`
+}
+
+func (r *RefreshToken) Validate() error {
+	if valid.IsNil(r.RefreshToken) || *r.RefreshToken == "" {
+		return apierror.ErrNilRefreshToken
+	}
+
+	return nil
+}
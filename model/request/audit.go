@@ -0,0 +1,53 @@
+package request
+
+import (
+	"strconv"
+	"time"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+const maxAuditLimit = 100
+
+type FindAudit struct {
+	AdminSeq int64      `form:"admin_seq"`
+	Action   string     `form:"action"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+	Cursor   string     `form:"cursor"`
+	Limit    int        `form:"limit"`
+}
+
+func (a *FindAudit) Validate() error {
+	switch {
+	case a.AdminSeq < 0:
+		return apierror.ErrInvalidAdmin
+	case a.Limit < 0 || a.Limit > maxAuditLimit:
+		return apierror.ErrInvalidLimit
+	}
+
+	if !valid.IsNil(a.From) && !valid.IsNil(a.To) && !a.From.Before(*a.To) {
+		return apierror.ErrInvalidPeriod
+	}
+
+	if _, err := a.LastAuditSeq(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LastAuditSeq cursor 는 이전 페이지의 마지막 audit_seq 이다
+func (a *FindAudit) LastAuditSeq() (int64, error) {
+	if a.Cursor == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(a.Cursor, 10, 64)
+	if err != nil || seq <= 0 {
+		return 0, apierror.ErrInvalidCursor
+	}
+
+	return seq, nil
+}
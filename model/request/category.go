@@ -0,0 +1,55 @@
+package request
+
+import (
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+// CreateCategory ParentSeq 가 0 이면 최상위 카테고리이다
+type CreateCategory struct {
+	ParentSeq    int64   `json:"parent_seq"`
+	Name         *string `json:"name"`
+	DisplayOrder int     `json:"display_order"`
+}
+
+func (c *CreateCategory) Validate() error {
+	switch {
+	case valid.IsNil(c.Name):
+		return apierror.ErrNilCategoryName
+	case c.ParentSeq < 0:
+		return apierror.ErrInvalidParent
+	}
+
+	return nil
+}
+
+// UpdateCategory 입력한 값만 변경하며, 최상위로 옮길 때는 parent_seq 를 0 으로 보낸다
+type UpdateCategory struct {
+	CategorySeq  int64   `uri:"category_seq"`
+	ParentSeq    *int64  `json:"parent_seq"`
+	Name         *string `json:"name"`
+	DisplayOrder *int    `json:"display_order"`
+}
+
+func (c *UpdateCategory) Validate() error {
+	switch {
+	case c.CategorySeq <= 0:
+		return apierror.ErrInvalidCategory
+	case !valid.IsNil(c.ParentSeq) && *c.ParentSeq < 0:
+		return apierror.ErrInvalidParent
+	}
+
+	return nil
+}
+
+type DeleteCategory struct {
+	CategorySeq int64 `uri:"category_seq"`
+}
+
+func (c *DeleteCategory) Validate() error {
+	if c.CategorySeq <= 0 {
+		return apierror.ErrInvalidCategory
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -8,22 +9,6 @@ import (
 	"hello-cafe/internal/valid"
 )
 
-type ItemCategory int
-
-const (
-	ItemCategoryBeverage ItemCategory = iota
-	ItemCategoryFood
-)
-
-func (c ItemCategory) Validate() error {
-	switch c {
-	case ItemCategoryBeverage, ItemCategoryFood:
-		return nil
-	default:
-		return apierror.ErrInvalidCategory
-	}
-}
-
 type ItemSize int
 
 const (
@@ -41,21 +26,21 @@ func (s ItemSize) Validate() error {
 }
 
 type CreateItem struct {
-	AdminSeq    int64         `json:"admin_seq"`
-	Category    *ItemCategory `json:"category"`
-	Barcode     *string       `json:"barcode"`
-	Price       *int64        `json:"price"`
-	Cost        *int64        `json:"cost"`
-	Name        *string       `json:"name"`
-	Description *string       `json:"description"`
-	ExpireDT    *time.Time    `json:"expire_dt"`
-	Size        *ItemSize     `json:"size"`
+	StoreSeq    int64      `json:"-"`
+	Category    *int64     `json:"category"`
+	Barcode     *string    `json:"barcode"`
+	Price       *int64     `json:"price"`
+	Cost        *int64     `json:"cost"`
+	Name        *string    `json:"name"`
+	Description *string    `json:"description"`
+	ExpireDT    *time.Time `json:"expire_dt"`
+	Size        *ItemSize  `json:"size"`
 }
 
 func (i *CreateItem) Validate() error {
 	switch {
-	case i.AdminSeq < 0:
-		return apierror.ErrInvalidAdmin
+	case i.StoreSeq < 0:
+		return apierror.ErrInvalidStore
 	case valid.IsNil(i.Category):
 		return apierror.ErrNilCategory
 	case valid.IsNil(i.Barcode):
@@ -74,8 +59,9 @@ func (i *CreateItem) Validate() error {
 		return apierror.ErrNilSize
 	}
 
-	if err := i.Category.Validate(); err != nil {
-		return errors.Wrapf(err, "category(%d) is invalid", i.Category)
+	// 매장의 카테고리인지는 service 에서 확인한다
+	if *i.Category <= 0 {
+		return apierror.ErrInvalidCategory
 	}
 
 	if err := i.Size.Validate(); err != nil {
@@ -86,15 +72,18 @@ func (i *CreateItem) Validate() error {
 }
 
 type UpdateItem struct {
-	ItemSeq     int64         `uri:"item_seq"`
-	Category    *ItemCategory `json:"category"`
-	Barcode     *string       `json:"barcode"`
-	Price       *int64        `json:"price"`
-	Cost        *int64        `json:"cost"`
-	Name        *string       `json:"name"`
-	Description *string       `json:"description"`
-	ExpireDT    *time.Time    `json:"expire_dt"`
-	Size        *ItemSize     `json:"size"`
+	ItemSeq     int64      `uri:"item_seq"`
+	Category    *int64     `json:"category"`
+	Barcode     *string    `json:"barcode"`
+	Price       *int64     `json:"price"`
+	Cost        *int64     `json:"cost"`
+	Name        *string    `json:"name"`
+	Description *string    `json:"description"`
+	ExpireDT    *time.Time `json:"expire_dt"`
+	Size        *ItemSize  `json:"size"`
+
+	// Version 조회한 상품의 버전, If-Match 헤더로도 받는다
+	Version *int64 `json:"version"`
 }
 
 func (i *UpdateItem) Validate() error {
@@ -102,10 +91,12 @@ func (i *UpdateItem) Validate() error {
 		return apierror.ErrInvalidItem
 	}
 
-	if !valid.IsNil(i.Category) {
-		if err := i.Category.Validate(); err != nil {
-			return errors.Wrapf(err, "category(%d) is invalid", i.Category)
-		}
+	if !valid.IsNil(i.Version) && *i.Version <= 0 {
+		return apierror.ErrInvalidItemVersion
+	}
+
+	if !valid.IsNil(i.Category) && *i.Category <= 0 {
+		return apierror.ErrInvalidCategory
 	}
 
 	if !valid.IsNil(i.Size) {
@@ -119,6 +110,9 @@ func (i *UpdateItem) Validate() error {
 
 type DeleteItem struct {
 	ItemSeq int64 `uri:"item_seq"`
+
+	// Version 조회한 상품의 버전, If-Match 헤더로도 받는다
+	Version *int64 `form:"version"`
 }
 
 func (i *DeleteItem) Validate() error {
@@ -126,11 +120,18 @@ func (i *DeleteItem) Validate() error {
 		return apierror.ErrInvalidItem
 	}
 
+	if !valid.IsNil(i.Version) && *i.Version <= 0 {
+		return apierror.ErrInvalidItemVersion
+	}
+
 	return nil
 }
 
 type GetItem struct {
 	ItemSeq int64 `uri:"item_seq"`
+
+	// OptionSeqs 선택한 옵션(options=1&options=2)의 가격을 계산한다
+	OptionSeqs []int64 `form:"options"`
 }
 
 func (i *GetItem) Validate() error {
@@ -138,5 +139,184 @@ func (i *GetItem) Validate() error {
 		return apierror.ErrInvalidItem
 	}
 
+	for _, optionSeq := range i.OptionSeqs {
+		if optionSeq <= 0 {
+			return apierror.ErrInvalidOption
+		}
+	}
+
+	return nil
+}
+
+type RestoreItem struct {
+	ItemSeq int64 `uri:"item_seq"`
+}
+
+func (i *RestoreItem) Validate() error {
+	if i.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	return nil
+}
+
+type FindItemHistory struct {
+	ItemSeq         int64 `uri:"item_seq"`
+	LastRevisionSeq int64 `form:"last_revision_seq"`
+	Limit           int   `form:"limit"`
+}
+
+func (i *FindItemHistory) Validate() error {
+	if i.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	return nil
+}
+
+type FindItemPrices struct {
+	ItemSeq int64      `uri:"item_seq"`
+	From    *time.Time `form:"from"`
+	To      *time.Time `form:"to"`
+}
+
+func (i *FindItemPrices) Validate() error {
+	if i.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	if !valid.IsNil(i.From) && !valid.IsNil(i.To) && !i.From.Before(*i.To) {
+		return apierror.ErrInvalidPeriod
+	}
+
+	return nil
+}
+
+// ItemSort 상품 리스트 정렬 기준, 같은 값은 item_seq 로 정렬한다
+type ItemSort string
+
+const (
+	ItemSortItemSeq  ItemSort = "item_seq"
+	ItemSortName     ItemSort = "name"
+	ItemSortPrice    ItemSort = "price"
+	ItemSortMargin   ItemSort = "margin"
+	ItemSortExpireDT ItemSort = "expire_dt"
+	ItemSortRegDT    ItemSort = "reg_dt"
+)
+
+func (s ItemSort) Validate() error {
+	switch s {
+	case ItemSortItemSeq, ItemSortName, ItemSortPrice, ItemSortMargin, ItemSortExpireDT, ItemSortRegDT:
+		return nil
+	default:
+		return apierror.ErrInvalidItemSort
+	}
+}
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+
+	maxItemLimit = 100
+)
+
+// FindItems 기간 조건은 from 이상 to 미만이며, 모든 조건은 함께 적용된다
+type FindItems struct {
+	Category     *int64     `form:"category"`
+	Size         *ItemSize  `form:"size"`
+	MinPrice     *int64     `form:"min_price"`
+	MaxPrice     *int64     `form:"max_price"`
+	MinCost      *int64     `form:"min_cost"`
+	MaxCost      *int64     `form:"max_cost"`
+	ExpireAfter  *time.Time `form:"expire_after"`
+	ExpireBefore *time.Time `form:"expire_before"`
+	RegFrom      *time.Time `form:"reg_from"`
+	RegTo        *time.Time `form:"reg_to"`
+	ModFrom      *time.Time `form:"mod_from"`
+	ModTo        *time.Time `form:"mod_to"`
+
+	// 기본으로 판매 중지된 상품은 제외하며, OnlyUnavailable 이 IncludeUnavailable 보다 우선한다
+	IncludeUnavailable bool `form:"include_unavailable"`
+	OnlyUnavailable    bool `form:"only_unavailable"`
+
+	// Sort 기본값은 item_seq, Order 기본값은 desc
+	Sort  ItemSort `form:"sort"`
+	Order string   `form:"order"`
+
+	// Cursor 이전 응답의 next_cursor, 조회 조건과 정렬 조건이 같아야 한다
+	Cursor string `form:"cursor"`
+	// LastItemSeq 기본 정렬(item_seq desc)에서만 사용하는 이전 방식의 페이지 정보
+	LastItemSeq int64 `form:"last_item_seq"`
+	Limit       int   `form:"limit"`
+	WithTotal   bool  `form:"with_total"`
+}
+
+func (i *FindItems) Validate() error {
+	if i.Sort == "" {
+		i.Sort = ItemSortItemSeq
+	}
+
+	if i.Order == "" {
+		i.Order = SortOrderDesc
+	}
+
+	switch {
+	case i.Order != SortOrderAsc && i.Order != SortOrderDesc:
+		return apierror.ErrInvalidSortOrder
+	case i.Limit < 0 || i.Limit > maxItemLimit:
+		return apierror.ErrInvalidLimit
+	case i.LastItemSeq < 0:
+		return apierror.ErrInvalidCursor
+	case i.LastItemSeq > 0 && (i.Sort != ItemSortItemSeq || i.Order != SortOrderDesc):
+		return apierror.ErrInvalidCursor
+	}
+
+	if err := i.Sort.Validate(); err != nil {
+		return errors.Wrapf(err, "sort(%s) is invalid", i.Sort)
+	}
+
+	if !valid.IsNil(i.Category) && *i.Category <= 0 {
+		return apierror.ErrInvalidCategory
+	}
+
+	if !valid.IsNil(i.Size) {
+		if err := i.Size.Validate(); err != nil {
+			return errors.Wrapf(err, "size(%d) is invalid", *i.Size)
+		}
+	}
+
+	if !valid.IsNil(i.MinPrice) && !valid.IsNil(i.MaxPrice) && *i.MinPrice > *i.MaxPrice {
+		return apierror.ErrInvalidPrice
+	}
+
+	if !valid.IsNil(i.MinCost) && !valid.IsNil(i.MaxCost) && *i.MinCost > *i.MaxCost {
+		return apierror.ErrInvalidCost
+	}
+
+	periods := [][2]*time.Time{{i.ExpireAfter, i.ExpireBefore}, {i.RegFrom, i.RegTo}, {i.ModFrom, i.ModTo}}
+	for _, period := range periods {
+		if !valid.IsNil(period[0]) && !valid.IsNil(period[1]) && !period[0].Before(*period[1]) {
+			return apierror.ErrInvalidPeriod
+		}
+	}
+
+	return nil
+}
+
+type SearchItems struct {
+	Text      string `form:"text"`
+	Cursor    string `form:"cursor"`
+	Limit     int    `form:"limit"`
+	WithTotal bool   `form:"with_total"`
+}
+
+func (i *SearchItems) Validate() error {
+	switch {
+	case strings.TrimSpace(i.Text) == "":
+		return apierror.ErrNilSearchText
+	case i.Limit < 0 || i.Limit > maxItemLimit:
+		return apierror.ErrInvalidLimit
+	}
+
 	return nil
 }
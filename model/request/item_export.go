@@ -0,0 +1,47 @@
+package request
+
+import (
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+type ItemExportFormat string
+
+const (
+	ItemExportCSV   ItemExportFormat = "csv"
+	ItemExportJSONL ItemExportFormat = "jsonl"
+	ItemExportXLSX  ItemExportFormat = "xlsx"
+)
+
+type ExportItems struct {
+	Format ItemExportFormat `form:"format"`
+
+	// Header 컬럼 이름 언어, ko 이면 한글로 출력한다 (csv, xlsx)
+	Header string `form:"header"`
+
+	// BOM csv 앞에 UTF-8 BOM 을 붙인다, 엑셀에서 한글이 깨지지 않도록 기본값은 true
+	BOM *bool `form:"bom"`
+}
+
+func (i *ExportItems) Validate() error {
+	switch i.Format {
+	case "":
+		i.Format = ItemExportCSV
+	case ItemExportCSV, ItemExportJSONL, ItemExportXLSX:
+	default:
+		return apierror.ErrInvalidExportFormat
+	}
+
+	switch i.Header {
+	case "", "en", "ko":
+	default:
+		return apierror.ErrInvalidExportHeader
+	}
+
+	if valid.IsNil(i.BOM) {
+		bom := true
+		i.BOM = &bom
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package request
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+)
+
+type ItemImportMode string
+
+const (
+	// ItemImportAllOrNothing 하나라도 실패하면 모두 등록하지 않는다
+	ItemImportAllOrNothing ItemImportMode = "all_or_nothing"
+	// ItemImportBestEffort 실패한 행만 제외하고 등록한다
+	ItemImportBestEffort ItemImportMode = "best_effort"
+
+	MaxItemImportRows = 1000
+)
+
+// itemCSVColumns CreateItem 의 json 이름과 같은 컬럼을 사용한다
+var itemCSVColumns = []string{"category", "barcode", "price", "cost", "name", "description", "expire_dt", "size"}
+
+type ImportItems struct {
+	DryRun bool           `form:"dry_run"`
+	Mode   ItemImportMode `form:"mode"`
+
+	Rows []ItemImportRow `form:"-"`
+}
+
+func (i *ImportItems) Validate() error {
+	switch i.Mode {
+	case "":
+		i.Mode = ItemImportAllOrNothing
+	case ItemImportAllOrNothing, ItemImportBestEffort:
+	default:
+		return apierror.ErrInvalidImportMode
+	}
+
+	switch {
+	case len(i.Rows) == 0:
+		return apierror.ErrEmptyImportFile
+	case len(i.Rows) > MaxItemImportRows:
+		return apierror.ErrTooManyImportRows
+	}
+
+	return nil
+}
+
+// ItemImportRow CSV 의 한 행, 값을 읽지 못한 경우 Err 에 사유가 있다
+type ItemImportRow struct {
+	Line int
+	Item CreateItem
+	Err  error
+}
+
+// ParseItemCSV 첫 행은 컬럼 이름이며, 순서는 자유롭고 모르는 컬럼은 무시한다
+func ParseItemCSV(r io.Reader) ([]ItemImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apierror.ErrEmptyImportFile
+	}
+	if err != nil {
+		return nil, apierror.ErrInvalidImportFile.SetInternal(err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// 엑셀에서 저장한 파일은 BOM 이 붙어 있다
+		name = strings.TrimPrefix(name, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, column := range itemCSVColumns {
+		if _, ok := index[column]; !ok {
+			return nil, apierror.ErrInvalidImportFile.SetInternal(errors.Errorf("column(%s) is missing", column))
+		}
+	}
+
+	rows := make([]ItemImportRow, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apierror.ErrInvalidImportFile.SetInternal(err)
+		}
+
+		if len(rows) >= MaxItemImportRows {
+			return nil, apierror.ErrTooManyImportRows
+		}
+
+		get := func(column string) string {
+			if i := index[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item, err := parseItemRecord(get)
+		rows = append(rows, ItemImportRow{Line: line, Item: item, Err: err})
+	}
+
+	return rows, nil
+}
+
+// parseItemRecord 비어 있는 값은 nil 로 두어 CreateItem.Validate 에서 누락 오류가 나도록 한다
+func parseItemRecord(get func(column string) string) (CreateItem, error) {
+	item := CreateItem{}
+
+	if v := get("category"); v != "" {
+		category, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return item, apierror.ErrInvalidCategory
+		}
+		item.Category = &category
+	}
+
+	if v := get("barcode"); v != "" {
+		item.Barcode = &v
+	}
+
+	if v := get("price"); v != "" {
+		price, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return item, apierror.ErrInvalidPrice
+		}
+		item.Price = &price
+	}
+
+	if v := get("cost"); v != "" {
+		cost, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return item, apierror.ErrInvalidCost
+		}
+		item.Cost = &cost
+	}
+
+	if v := get("name"); v != "" {
+		item.Name = &v
+	}
+
+	// 설명은 빈 값도 허용한다
+	description := get("description")
+	item.Description = &description
+
+	if v := get("expire_dt"); v != "" {
+		expireDT, err := parseImportTime(v)
+		if err != nil {
+			return item, apierror.ErrInvalidExpireDT
+		}
+		item.ExpireDT = &expireDT
+	}
+
+	if v := get("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return item, apierror.ErrInvalidSize
+		}
+		s := ItemSize(size)
+		item.Size = &s
+	}
+
+	return item, nil
+}
+
+// parseImportTime RFC3339, 내보내기 형식(2006-01-02 15:04:05) 또는 날짜(2006-01-02)를 허용한다
+func parseImportTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation(time.DateTime, v, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation(time.DateOnly, v, time.Local)
+}
@@ -0,0 +1,99 @@
+package request
+
+import (
+	"time"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+const (
+	// defaultExpiringWithin 유통기한 임박 lot 조회 기본 기간
+	defaultExpiringWithin = 48 * time.Hour
+	// maxExpiringWithin 유통기한 임박 lot 조회 최대 기간
+	maxExpiringWithin = 30 * 24 * time.Hour
+)
+
+// CreateItemLot lot 을 등록하고 quantity 만큼 입고한다. received_dt 를 입력하지 않으면 등록 시점이다
+type CreateItemLot struct {
+	ItemSeq    int64      `uri:"item_seq" json:"-"`
+	LotNumber  *string    `json:"lot_number"`
+	Quantity   *int64     `json:"quantity"`
+	ReceivedDT *time.Time `json:"received_dt"`
+	ExpireDT   *time.Time `json:"expire_dt"`
+	Reason     string     `json:"reason"`
+}
+
+func (l *CreateItemLot) Validate() error {
+	switch {
+	case l.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case valid.IsNil(l.LotNumber):
+		return apierror.ErrNilLotNumber
+	case valid.IsNil(l.Quantity) || *l.Quantity <= 0:
+		return apierror.ErrInvalidQuantity
+	case valid.IsNil(l.ExpireDT):
+		return apierror.ErrNilExpireDT
+	case len([]rune(l.Reason)) > maxStockReasonLength:
+		return apierror.ErrInvalidStockReason
+	}
+
+	return nil
+}
+
+// ConsumeItemLots 판매, 폐기로 lot 의 재고를 차감한다. lot_seq 를 입력하지 않으면 유통기한이 빠른 lot 부터 차감한다
+type ConsumeItemLots struct {
+	ItemSeq  int64             `uri:"item_seq" json:"-"`
+	Type     StockMovementType `json:"type"`
+	Quantity *int64            `json:"quantity"`
+	Reason   string            `json:"reason"`
+	LotSeq   int64             `json:"lot_seq"`
+}
+
+func (c *ConsumeItemLots) Validate() error {
+	if c.Type != StockMovementSell && c.Type != StockMovementWaste {
+		return apierror.ErrInvalidStockType
+	}
+
+	movement := c.Movement()
+	return movement.Validate()
+}
+
+func (c *ConsumeItemLots) Movement() CreateStockMovement {
+	return CreateStockMovement{
+		ItemSeq:  c.ItemSeq,
+		Type:     c.Type,
+		Quantity: c.Quantity,
+		Reason:   c.Reason,
+		LotSeq:   c.LotSeq,
+	}
+}
+
+type FindItemLots struct {
+	ItemSeq int64 `uri:"item_seq"`
+}
+
+func (l *FindItemLots) Validate() error {
+	if l.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	return nil
+}
+
+// FindExpiringLots within(48h, 7d 가 아닌 168h 형식) 안에 유통기한이 끝나는 lot, 이미 지난 lot 도 포함한다
+type FindExpiringLots struct {
+	Within time.Duration `form:"within"`
+}
+
+func (l *FindExpiringLots) Validate() error {
+	if l.Within == 0 {
+		l.Within = defaultExpiringWithin
+	}
+
+	if l.Within < 0 || l.Within > maxExpiringWithin {
+		return apierror.ErrInvalidPeriod
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package request
+
+import (
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+// CreateItemOptionGroup required, multi_select 로 선택 조건을 정하며, min_select, max_select 를 입력하면 그 값을 사용한다.
+// max_select 0 은 개수 제한이 없다
+type CreateItemOptionGroup struct {
+	ItemSeq      int64              `uri:"item_seq"`
+	Name         *string            `json:"name"`
+	Required     bool               `json:"required"`
+	MultiSelect  bool               `json:"multi_select"`
+	MinSelect    *int               `json:"min_select"`
+	MaxSelect    *int               `json:"max_select"`
+	DisplayOrder int                `json:"display_order"`
+	Options      []CreateItemOption `json:"options"`
+}
+
+func (g *CreateItemOptionGroup) Validate() error {
+	switch {
+	case g.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case valid.IsNil(g.Name):
+		return apierror.ErrNilOptionName
+	}
+
+	for _, option := range g.Options {
+		if valid.IsNil(option.Name) {
+			return apierror.ErrNilOptionName
+		}
+	}
+
+	return nil
+}
+
+// SelectRange 최소, 최대 선택 개수
+func (g *CreateItemOptionGroup) SelectRange() (minSelect, maxSelect int) {
+	return selectRange(g.Required, g.MultiSelect, g.MinSelect, g.MaxSelect)
+}
+
+// UpdateItemOptionGroup 입력한 값만 변경한다
+type UpdateItemOptionGroup struct {
+	ItemSeq        int64   `uri:"item_seq"`
+	OptionGroupSeq int64   `uri:"option_group_seq"`
+	Name           *string `json:"name"`
+	Required       *bool   `json:"required"`
+	MultiSelect    *bool   `json:"multi_select"`
+	MinSelect      *int    `json:"min_select"`
+	MaxSelect      *int    `json:"max_select"`
+	DisplayOrder   *int    `json:"display_order"`
+}
+
+func (g *UpdateItemOptionGroup) Validate() error {
+	switch {
+	case g.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case g.OptionGroupSeq <= 0:
+		return apierror.ErrInvalidOptionGroup
+	}
+
+	return nil
+}
+
+// SelectRange 입력하지 않은 조건은 현재 최소, 최대 선택 개수를 유지한다
+func (g *UpdateItemOptionGroup) SelectRange(minSelect, maxSelect int) (int, int) {
+	if !valid.IsNil(g.Required) {
+		minSelect, _ = selectRange(*g.Required, false, g.MinSelect, nil)
+	} else if !valid.IsNil(g.MinSelect) {
+		minSelect = *g.MinSelect
+	}
+
+	if !valid.IsNil(g.MultiSelect) {
+		_, maxSelect = selectRange(false, *g.MultiSelect, nil, g.MaxSelect)
+	} else if !valid.IsNil(g.MaxSelect) {
+		maxSelect = *g.MaxSelect
+	}
+
+	return minSelect, maxSelect
+}
+
+func selectRange(required, multiSelect bool, minSelect, maxSelect *int) (int, int) {
+	lower, upper := 0, 1
+	if required {
+		lower = 1
+	}
+	if multiSelect {
+		upper = 0
+	}
+
+	if !valid.IsNil(minSelect) {
+		lower = *minSelect
+	}
+	if !valid.IsNil(maxSelect) {
+		upper = *maxSelect
+	}
+
+	return lower, upper
+}
+
+type DeleteItemOptionGroup struct {
+	ItemSeq        int64 `uri:"item_seq"`
+	OptionGroupSeq int64 `uri:"option_group_seq"`
+}
+
+func (g *DeleteItemOptionGroup) Validate() error {
+	switch {
+	case g.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case g.OptionGroupSeq <= 0:
+		return apierror.ErrInvalidOptionGroup
+	}
+
+	return nil
+}
+
+// CreateItemOption 빈 barcode, sku 는 입력하지 않은 것으로 본다
+type CreateItemOption struct {
+	ItemSeq        int64   `uri:"item_seq" json:"-"`
+	OptionGroupSeq int64   `uri:"option_group_seq" json:"-"`
+	Name           *string `json:"name"`
+	PriceDelta     int64   `json:"price_delta"`
+	CostDelta      int64   `json:"cost_delta"`
+	Barcode        *string `json:"barcode"`
+	SKU            *string `json:"sku"`
+	DisplayOrder   int     `json:"display_order"`
+}
+
+func (o *CreateItemOption) Validate() error {
+	switch {
+	case o.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case o.OptionGroupSeq <= 0:
+		return apierror.ErrInvalidOptionGroup
+	case valid.IsNil(o.Name):
+		return apierror.ErrNilOptionName
+	}
+
+	return nil
+}
+
+// UpdateItemOption 입력한 값만 변경하며, barcode, sku 는 빈 값으로 지울 수 있다
+type UpdateItemOption struct {
+	ItemSeq        int64   `uri:"item_seq"`
+	OptionGroupSeq int64   `uri:"option_group_seq"`
+	OptionSeq      int64   `uri:"option_seq"`
+	Name           *string `json:"name"`
+	PriceDelta     *int64  `json:"price_delta"`
+	CostDelta      *int64  `json:"cost_delta"`
+	Barcode        *string `json:"barcode"`
+	SKU            *string `json:"sku"`
+	DisplayOrder   *int    `json:"display_order"`
+}
+
+func (o *UpdateItemOption) Validate() error {
+	switch {
+	case o.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case o.OptionGroupSeq <= 0:
+		return apierror.ErrInvalidOptionGroup
+	case o.OptionSeq <= 0:
+		return apierror.ErrInvalidOption
+	}
+
+	return nil
+}
+
+type DeleteItemOption struct {
+	ItemSeq        int64 `uri:"item_seq"`
+	OptionGroupSeq int64 `uri:"option_group_seq"`
+	OptionSeq      int64 `uri:"option_seq"`
+}
+
+func (o *DeleteItemOption) Validate() error {
+	switch {
+	case o.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case o.OptionGroupSeq <= 0:
+		return apierror.ErrInvalidOptionGroup
+	case o.OptionSeq <= 0:
+		return apierror.ErrInvalidOption
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package request
+
+import (
+	"strings"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+// maxStockReasonLength stock_movement.reason 컬럼 길이
+const maxStockReasonLength = 200
+
+type StockMovementType string
+
+const (
+	StockMovementReceive  StockMovementType = "receive"
+	StockMovementSell     StockMovementType = "sell"
+	StockMovementWaste    StockMovementType = "waste"
+	StockMovementAdjust   StockMovementType = "adjust"
+	StockMovementTransfer StockMovementType = "transfer"
+)
+
+func (t StockMovementType) Validate() error {
+	switch t {
+	case StockMovementReceive, StockMovementSell, StockMovementWaste, StockMovementAdjust, StockMovementTransfer:
+		return nil
+	default:
+		return apierror.ErrInvalidStockType
+	}
+}
+
+// ManagerOnly 재고 조정과 매장 간 이동은 manager 이상만 할 수 있다
+func (t StockMovementType) ManagerOnly() bool {
+	return t == StockMovementAdjust || t == StockMovementTransfer
+}
+
+// CreateStockMovement quantity 는 항상 양수이며, adjust 만 음수로 재고를 줄일 수 있다.
+// waste, adjust 는 사유가 필요하고, transfer 는 to_store_seq 매장의 같은 바코드 상품으로 재고를 옮긴다.
+// lot_seq 를 입력하면 그 lot 의 재고를 바꾸며, 입력하지 않은 감소는 유통기한이 빠른 lot 부터 차감한다
+type CreateStockMovement struct {
+	ItemSeq    int64             `uri:"item_seq" json:"-"`
+	Type       StockMovementType `json:"type"`
+	Quantity   *int64            `json:"quantity"`
+	Reason     string            `json:"reason"`
+	ToStoreSeq int64             `json:"to_store_seq"`
+	LotSeq     int64             `json:"lot_seq"`
+}
+
+func (m *CreateStockMovement) Validate() error {
+	if m.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	if err := m.Type.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case valid.IsNil(m.Quantity) || *m.Quantity == 0:
+		return apierror.ErrInvalidQuantity
+	case *m.Quantity < 0 && m.Type != StockMovementAdjust:
+		return apierror.ErrInvalidQuantity
+	case len([]rune(m.Reason)) > maxStockReasonLength:
+		return apierror.ErrInvalidStockReason
+	case (m.Type == StockMovementWaste || m.Type == StockMovementAdjust) && strings.TrimSpace(m.Reason) == "":
+		return apierror.ErrNilStockReason
+	case (m.Type == StockMovementTransfer) != (m.ToStoreSeq > 0), m.ToStoreSeq < 0:
+		return apierror.ErrInvalidTransfer
+	case m.LotSeq < 0:
+		return apierror.ErrInvalidLot
+	}
+
+	return nil
+}
+
+// Delta 재고에 더할 수량, 판매와 폐기, 이동은 재고를 줄인다
+func (m *CreateStockMovement) Delta() int64 {
+	switch m.Type {
+	case StockMovementSell, StockMovementWaste, StockMovementTransfer:
+		return -*m.Quantity
+	default:
+		return *m.Quantity
+	}
+}
+
+type GetItemStock struct {
+	ItemSeq int64 `uri:"item_seq"`
+}
+
+func (s *GetItemStock) Validate() error {
+	if s.ItemSeq <= 0 {
+		return apierror.ErrInvalidItem
+	}
+
+	return nil
+}
+
+// UpdateItemStock low_stock_threshold 가 null 이면 설정의 기본값을 사용한다
+type UpdateItemStock struct {
+	ItemSeq           int64  `uri:"item_seq" json:"-"`
+	LowStockThreshold *int64 `json:"low_stock_threshold"`
+}
+
+func (s *UpdateItemStock) Validate() error {
+	switch {
+	case s.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case !valid.IsNil(s.LowStockThreshold) && *s.LowStockThreshold < 0:
+		return apierror.ErrInvalidThreshold
+	}
+
+	return nil
+}
+
+type FindStockMovements struct {
+	ItemSeq         int64 `uri:"item_seq"`
+	LastMovementSeq int64 `form:"last_movement_seq"`
+	Limit           int   `form:"limit"`
+}
+
+func (m *FindStockMovements) Validate() error {
+	switch {
+	case m.ItemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case m.Limit < 0 || m.Limit > maxItemLimit:
+		return apierror.ErrInvalidLimit
+	}
+
+	return nil
+}
+
+type FindLowStock struct {
+	LastItemSeq int64 `form:"last_item_seq"`
+	Limit       int   `form:"limit"`
+}
+
+func (s *FindLowStock) Validate() error {
+	switch {
+	case s.LastItemSeq < 0:
+		return apierror.ErrInvalidItem
+	case s.Limit < 0 || s.Limit > maxItemLimit:
+		return apierror.ErrInvalidLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/strcheck"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+)
+
+// InviteMember 이미 가입된 관리자는 매장에 추가만 하고, 가입되지 않은 경우 비밀번호로 계정을 만든다.
+// 이미 가입된 관리자의 비밀번호는 바꿀 수 없으므로 비밀번호를 함께 입력하면 거부한다.
+type InviteMember struct {
+	Phone    *string     `json:"phone"`
+	Password *string     `json:"password"`
+	Name     string      `json:"name"`
+	Role     *model.Role `json:"role"`
+}
+
+func (m *InviteMember) Validate() error {
+	if valid.IsNil(m.Phone) {
+		return apierror.ErrNilPhone
+	}
+
+	if !strcheck.ValidatePhone(*m.Phone) {
+		return apierror.ErrInvalidPhone
+	}
+
+	if !valid.IsNil(m.Password) && !strcheck.ValidatePassword(*m.Password) {
+		return apierror.ErrInvalidPassword
+	}
+
+	if valid.IsNil(m.Role) {
+		return apierror.ErrNilRole
+	}
+
+	// owner 는 회원가입으로만 만들 수 있다
+	if err := m.Role.Validate(); err != nil || *m.Role == model.RoleOwner {
+		return apierror.ErrInvalidRole
+	}
+
+	return nil
+}
+
+type UpdateMemberRole struct {
+	AdminSeq int64       `uri:"admin_seq"`
+	Role     *model.Role `json:"role"`
+}
+
+func (m *UpdateMemberRole) Validate() error {
+	if m.AdminSeq <= 0 {
+		return apierror.ErrInvalidMember
+	}
+
+	if valid.IsNil(m.Role) {
+		return apierror.ErrNilRole
+	}
+
+	if err := m.Role.Validate(); err != nil || *m.Role == model.RoleOwner {
+		return apierror.ErrInvalidRole
+	}
+
+	return nil
+}
+
+type DeleteMember struct {
+	AdminSeq int64 `uri:"admin_seq"`
+}
+
+func (m *DeleteMember) Validate() error {
+	if m.AdminSeq <= 0 {
+		return apierror.ErrInvalidMember
+	}
+
+	return nil
+}
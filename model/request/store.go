@@ -0,0 +1,20 @@
+package request
+
+import (
+	"strings"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+)
+
+type CreateStore struct {
+	Name *string `json:"name"`
+}
+
+func (s *CreateStore) Validate() error {
+	if valid.IsNil(s.Name) || strings.TrimSpace(*s.Name) == "" {
+		return apierror.ErrNilStoreName
+	}
+
+	return nil
+}
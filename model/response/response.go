@@ -1,9 +1,12 @@
 package response
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
 )
 
 type Meta struct {
@@ -14,6 +17,9 @@ type Meta struct {
 type Response struct {
 	Meta Meta        `json:"meta"`
 	Data interface{} `json:"data"`
+
+	// Pagination 리스트 응답에만 포함된다
+	Pagination *model.Pagination `json:"pagination,omitempty"`
 }
 
 const defaultSuccessMsg = "ok"
@@ -38,10 +44,31 @@ func Success(data interface{}) (int, Response) {
 	}
 }
 
+// SuccessWithPagination data 는 리스트이며 페이지 정보를 함께 내려준다
+func SuccessWithPagination(data interface{}, pagination model.Pagination) (int, Response) {
+	code, resp := Success(data)
+	resp.Pagination = &pagination
+
+	return code, resp
+}
+
+// FailureWithData 실패 응답에 현재 상태 등을 함께 내려준다
+func FailureWithData(err error, data interface{}) (int, Response) {
+	code, resp := Failure(err)
+	resp.Data = data
+
+	return code, resp
+}
+
 func Failure(err error) (int, Response) {
 	code := http.StatusInternalServerError
 	msg := "서버 내부 오류 입니다."
 
+	// 요청 timeout 으로 취소된 쿼리
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = apierror.ErrRequestTimeout
+	}
+
 	if apiErr, isAPIErr := apierror.IsAPIError(err); isAPIErr {
 		code = apiErr.Code
 		msg = apiErr.Message()
@@ -0,0 +1,34 @@
+package model
+
+import "hello-cafe/internal/apierror"
+
+// Role 관리자 권한
+//   - owner: 상품 삭제, 직원 초대 및 권한 변경
+//   - manager: 상품 등록 및 가격 수정, 재고 조정 및 매장 간 이동
+//   - staff: 상품 조회 및 검색, 재고 입고/판매/폐기 등록, lot 입고 및 차감
+type Role string
+
+const (
+	RoleOwner   Role = "owner"
+	RoleManager Role = "manager"
+	RoleStaff   Role = "staff"
+)
+
+func (r Role) Validate() error {
+	switch r {
+	case RoleOwner, RoleManager, RoleStaff:
+		return nil
+	default:
+		return apierror.ErrInvalidRole
+	}
+}
+
+// In 권한이 roles 중 하나인지 확인한다
+func (r Role) In(roles ...Role) bool {
+	for _, role := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
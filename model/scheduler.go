@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+type SchedulerJobs []SchedulerJob
+
+// SchedulerJob 스케줄러 작업의 상태, LastResult 는 작업마다 다르다
+type SchedulerJob struct {
+	Name           string      `json:"name"`
+	IntervalMillis int64       `json:"interval_ms"`
+	Running        bool        `json:"running"`
+	Runs           int64       `json:"runs"`
+	Failures       int64       `json:"failures"`
+	LastStartedDT  *time.Time  `json:"last_started_dt,omitempty"`
+	LastFinishedDT *time.Time  `json:"last_finished_dt,omitempty"`
+	LastMillis     int64       `json:"last_ms"`
+	LastResult     interface{} `json:"last_result,omitempty"`
+	LastError      string      `json:"last_error,omitempty"`
+	NextRunDT      *time.Time  `json:"next_run_dt,omitempty"`
+}
+
+// ItemExpiryResult 유통기한이 지난 상품 판매 중지 작업의 결과
+type ItemExpiryResult struct {
+	Hidden         int `json:"hidden"`
+	Stores         int `json:"stores"`
+	Notified       int `json:"notified"`
+	NotifyFailures int `json:"notify_failures"`
+}
+
+type ExpiredItems []ExpiredItem
+
+// ExpiredItem 판매 중지 알림에 담는 상품 정보
+type ExpiredItem struct {
+	ItemSeq  int64     `json:"item_seq"`
+	Barcode  string    `json:"barcode"`
+	Name     string    `json:"name"`
+	ExpireDT time.Time `json:"expire_dt"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Stores []Store
+
+type Store struct {
+	StoreSeq int64     `json:"store_seq"`
+	Name     string    `json:"name"`
+	Role     Role      `json:"role"`
+	RegDT    time.Time `json:"reg_dt"`
+}
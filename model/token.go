@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Token struct {
+	// AdminSeq 토큰을 발급받은 관리자, 응답에는 포함하지 않는다
+	AdminSeq int64 `json:"-"`
+
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpireDT     time.Time `json:"expire_dt"`
+}
@@ -1,49 +1,53 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
-	"hello-cafe/internal/db"
+	"gorm.io/gorm"
 	"hello-cafe/repository/dao"
 )
 
 type AdminRepository interface {
-	Create(phone, password, name string) error
-	Get(adminSeq int64) (*dao.Admin, error)
-	GetAdminByPhone(phone string) (*dao.Admin, error)
+	Create(ctx context.Context, phone, password, name string) (*dao.Admin, error)
+	Get(ctx context.Context, adminSeq int64) (*dao.Admin, error)
+	GetAdminByPhone(ctx context.Context, phone string) (*dao.Admin, error)
 }
 
-type adminRepository struct{}
+type adminRepository struct {
+	conn *gorm.DB
+}
 
-func NewAdminRepository() AdminRepository {
-	return &adminRepository{}
+func NewAdminRepository(conn *gorm.DB) AdminRepository {
+	return &adminRepository{conn: conn}
 }
 
-func (r *adminRepository) Create(phone, password, name string) error {
+func (r *adminRepository) Create(ctx context.Context, phone, password, name string) (*dao.Admin, error) {
 	admin, err := dao.NewAdmin(phone, password, name)
 	if err != nil {
-		return errors.Wrap(err, "failed to create new admin")
+		return nil, errors.Wrap(err, "failed to create new admin")
 	}
 
-	if err := db.Conn().Create(&admin).Error; err != nil {
-		return errors.Wrap(err, "failed to create admin")
+	if err := r.conn.WithContext(ctx).Create(&admin).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create admin")
 	}
 
-	return nil
+	return admin, nil
 }
 
-func (r *adminRepository) Get(adminSeq int64) (*dao.Admin, error) {
+func (r *adminRepository) Get(ctx context.Context, adminSeq int64) (*dao.Admin, error) {
 	admin := new(dao.Admin)
-	if err := db.Conn().First(&admin, adminSeq).Error; err != nil {
+	if err := r.conn.WithContext(ctx).First(&admin, adminSeq).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to get admin by admin sequence")
 	}
 
 	return admin, nil
 }
 
-func (r *adminRepository) GetAdminByPhone(phone string) (*dao.Admin, error) {
+func (r *adminRepository) GetAdminByPhone(ctx context.Context, phone string) (*dao.Admin, error) {
 	admin := new(dao.Admin)
 
-	if err := db.Conn().Where("phone = ?", phone).Take(&admin).Error; err != nil {
+	if err := r.conn.WithContext(ctx).Where("phone = ?", phone).Take(&admin).Error; err != nil {
 		return nil, errors.Wrapf(err, "failed to get admin by phone(%s)", phone)
 	}
 
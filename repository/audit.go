@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+// AuditFilter 값이 비어 있는 조건은 사용하지 않는다
+type AuditFilter struct {
+	StoreSeq     int64
+	AdminSeq     int64
+	Action       string
+	From         time.Time
+	To           time.Time
+	LastAuditSeq int64
+	Limit        int
+}
+
+// AuditRepository 감사 기록은 추가만 하며 수정, 삭제하지 않는다
+type AuditRepository interface {
+	Create(ctx context.Context, log dao.AuditLog) error
+	Find(ctx context.Context, filter AuditFilter) (dao.AuditLogs, error)
+}
+
+type auditRepository struct {
+	conn *gorm.DB
+}
+
+func NewAuditRepository(conn *gorm.DB) AuditRepository {
+	return &auditRepository{conn: conn}
+}
+
+func (r *auditRepository) Create(ctx context.Context, log dao.AuditLog) error {
+	log.AuditSeq = 0
+	if err := r.conn.WithContext(ctx).Create(&log).Error; err != nil {
+		return errors.Wrap(err, "failed to create audit log")
+	}
+
+	return nil
+}
+
+// Find 최근 기록부터 조회한다. 매장을 지정하면 인증 전 요청(store_seq = 0)은 매장 소속 관리자의 기록만 포함한다
+func (r *auditRepository) Find(ctx context.Context, filter AuditFilter) (dao.AuditLogs, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	tx := r.conn.WithContext(ctx).
+		Limit(filter.Limit).
+		Order("audit_seq DESC")
+
+	if filter.StoreSeq > 0 {
+		members := r.conn.Model(&dao.StoreMember{}).Select("admin_seq").Where("store_seq = ?", filter.StoreSeq)
+		tx = tx.Where("(store_seq = ? OR (store_seq = 0 AND admin_seq IN (?)))", filter.StoreSeq, members)
+	}
+
+	if filter.AdminSeq > 0 {
+		tx = tx.Where("admin_seq = ?", filter.AdminSeq)
+	}
+
+	if filter.Action != "" {
+		tx = tx.Where("action = ?", filter.Action)
+	}
+
+	if !filter.From.IsZero() {
+		tx = tx.Where("reg_dt >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		tx = tx.Where("reg_dt < ?", filter.To)
+	}
+
+	if filter.LastAuditSeq > 0 {
+		tx = tx.Where("audit_seq < ?", filter.LastAuditSeq)
+	}
+
+	logs := make(dao.AuditLogs, 0)
+	if err := tx.Find(&logs).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find audit logs")
+	}
+
+	return logs, nil
+}
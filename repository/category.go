@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category dao.Category) (*dao.Category, error)
+	Get(ctx context.Context, categorySeq int64) (*dao.Category, error)
+	Find(ctx context.Context, storeSeq int64) (dao.Categories, error)
+	Update(ctx context.Context, category dao.Category) error
+	Delete(ctx context.Context, categorySeq int64) error
+}
+
+type categoryRepository struct {
+	conn *gorm.DB
+}
+
+func NewCategoryRepository(conn *gorm.DB) CategoryRepository {
+	return &categoryRepository{conn: conn}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category dao.Category) (*dao.Category, error) {
+	if err := r.conn.WithContext(ctx).Create(&category).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create category")
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) Get(ctx context.Context, categorySeq int64) (*dao.Category, error) {
+	category := new(dao.Category)
+	if err := r.conn.WithContext(ctx).Take(&category, categorySeq).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get category by category_seq(%d)", categorySeq)
+	}
+
+	return category, nil
+}
+
+// Find 매장의 모든 카테고리를 상위 카테고리, 노출 순서, 등록 순서로 조회한다
+func (r *categoryRepository) Find(ctx context.Context, storeSeq int64) (dao.Categories, error) {
+	categories := make(dao.Categories, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Order("parent_seq ASC, display_order ASC, category_seq ASC").
+		Find(&categories).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find categories by store_seq(%d)", storeSeq)
+	}
+
+	return categories, nil
+}
+
+// Update 이름, 상위 카테고리, 노출 순서를 변경한다
+func (r *categoryRepository) Update(ctx context.Context, category dao.Category) error {
+	result := r.conn.WithContext(ctx).
+		Model(&dao.Category{}).
+		Where("category_seq = ?", category.CategorySeq).
+		Updates(map[string]interface{}{
+			"parent_seq":    category.ParentSeq,
+			"name":          category.Name,
+			"display_order": category.DisplayOrder,
+			"mod_dt":        time.Now(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, "failed to update category_seq(%d)", category.CategorySeq)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "failed to update category_seq(%d)", category.CategorySeq)
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, categorySeq int64) error {
+	if err := r.conn.WithContext(ctx).
+		Delete(&dao.Category{}, categorySeq).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete category_seq(%d)", categorySeq)
+	}
+
+	return nil
+}
@@ -16,7 +16,7 @@ type ColumnMap interface {
 }
 
 type UpdateItem struct {
-	Category    *dao.ItemCategory
+	Category    *int64
 	Barcode     *string
 	Price       *int64
 	Cost        *int64
@@ -32,6 +32,7 @@ func NewUpdateItem(r request.UpdateItem) (*UpdateItem, error) {
 	}
 
 	item := &UpdateItem{
+		Category:    r.Category,
 		Barcode:     r.Barcode,
 		Price:       r.Price,
 		Cost:        r.Cost,
@@ -40,10 +41,6 @@ func NewUpdateItem(r request.UpdateItem) (*UpdateItem, error) {
 		ExpireDT:    r.ExpireDT,
 	}
 
-	if !valid.IsNil(r.Category) {
-		item.Category, _ = dao.NewItemCategory(int(*r.Category))
-	}
-
 	if !valid.IsNil(r.Size) {
 		item.Size, _ = dao.NewItemSize(int(*r.Size))
 	}
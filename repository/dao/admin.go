@@ -25,8 +25,9 @@ func NewAdmin(phone, password, name string) (*Admin, error) {
 		return nil, apierror.ErrInvalidPhone
 	}
 
-	if !strcheck.ValidatePassword(password) {
-		return nil, apierror.ErrInvalidPassword
+	// password 는 암호화된 값이므로 형식은 암호화 전에 검증한다
+	if password == "" {
+		return nil, apierror.ErrNilPassword
 	}
 
 	return &Admin{
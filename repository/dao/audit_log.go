@@ -0,0 +1,23 @@
+package dao
+
+import "time"
+
+type AuditLogs []AuditLog
+
+type AuditLog struct {
+	AuditSeq  int64     `gorm:"Column:audit_seq;PRIMARY_KEY"`
+	StoreSeq  int64     `gorm:"Column:store_seq"`
+	AdminSeq  int64     `gorm:"Column:admin_seq"`
+	Action    string    `gorm:"Column:action"`
+	Target    string    `gorm:"Column:target"`
+	Outcome   string    `gorm:"Column:outcome"`
+	Status    int       `gorm:"Column:status"`
+	IP        string    `gorm:"Column:ip"`
+	UserAgent string    `gorm:"Column:user_agent"`
+	RequestID string    `gorm:"Column:request_id"`
+	RegDT     time.Time `gorm:"Column:reg_dt"`
+}
+
+func (a AuditLog) TableName() string {
+	return "audit_log"
+}
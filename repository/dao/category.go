@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"strings"
+	"time"
+
+	"hello-cafe/internal/apierror"
+)
+
+// maxCategoryNameLength category.name 컬럼 길이
+const maxCategoryNameLength = 50
+
+type Categories []Category
+
+// Category 매장별 상품 카테고리, ParentSeq 가 0 이면 최상위이다
+type Category struct {
+	CategorySeq  int64     `gorm:"Column:category_seq;PRIMARY_KEY"`
+	StoreSeq     int64     `gorm:"Column:store_seq"`
+	ParentSeq    int64     `gorm:"Column:parent_seq"`
+	Name         string    `gorm:"Column:name"`
+	DisplayOrder int       `gorm:"Column:display_order"`
+	RegDT        time.Time `gorm:"Column:reg_dt"`
+	ModDT        time.Time `gorm:"Column:mod_dt"`
+}
+
+func (c Category) TableName() string {
+	return "category"
+}
+
+func NewCategory(storeSeq, parentSeq int64, name string, displayOrder int) (*Category, error) {
+	name, err := CategoryName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Category{
+		StoreSeq:     storeSeq,
+		ParentSeq:    parentSeq,
+		Name:         name,
+		DisplayOrder: displayOrder,
+		RegDT:        now,
+		ModDT:        now,
+	}, nil
+}
+
+// CategoryName 앞뒤 공백을 제거한 이름
+func CategoryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", apierror.ErrNilCategoryName
+	case len([]rune(name)) > maxCategoryNameLength:
+		return "", apierror.ErrInvalidCategoryName
+	}
+
+	return name, nil
+}
@@ -11,23 +11,6 @@ import (
 	"hello-cafe/model/request"
 )
 
-type ItemCategory int
-
-const (
-	ItemCategoryBeverage ItemCategory = iota
-	ItemCategoryFood
-)
-
-func NewItemCategory(category int) (*ItemCategory, error) {
-	itemCategory := ItemCategory(category)
-	switch itemCategory {
-	case ItemCategoryBeverage, ItemCategoryFood:
-		return &itemCategory, nil
-	default:
-		return nil, apierror.ErrNilCategory.SetInternal(fmt.Errorf("category(%d) is invalid", category))
-	}
-}
-
 type ItemSize int
 
 const (
@@ -48,19 +31,24 @@ func NewItemSize(size int) (*ItemSize, error) {
 type Items []Item
 
 type Item struct {
-	ItemSeq     int64        `gorm:"Column:item_seq;PRIMARY_KEY"`
-	AdminSeq    int64        `gorm:"Column:admin_seq"`
-	Category    ItemCategory `gorm:"Column:category"`
-	Barcode     string       `gorm:"Column:barcode"`
-	Price       int64        `gorm:"Column:price"`
-	Cost        int64        `gorm:"Column:cost"`
-	Name        string       `gorm:"Column:name"`
-	Consonant   string       `gorm:"Column:consonant"`
-	Description string       `gorm:"Column:description"`
-	ExpireDT    time.Time    `gorm:"Column:expire_dt"`
-	Size        ItemSize     `gorm:"Column:size"`
-	RegDT       time.Time    `gorm:"Column:reg_dt"`
-	ModDT       time.Time    `gorm:"Column:mod_dt"`
+	ItemSeq     int64      `gorm:"Column:item_seq;PRIMARY_KEY"`
+	StoreSeq    int64      `gorm:"Column:store_seq"`
+	Category    int64      `gorm:"Column:category"` // category_seq
+	Barcode     string     `gorm:"Column:barcode"`
+	Price       int64      `gorm:"Column:price"`
+	Cost        int64      `gorm:"Column:cost"`
+	Name        string     `gorm:"Column:name"`
+	Consonant   string     `gorm:"Column:consonant"`
+	Description string     `gorm:"Column:description"`
+	ExpireDT    time.Time  `gorm:"Column:expire_dt"`
+	Size        ItemSize   `gorm:"Column:size"`
+	Version     int64      `gorm:"Column:version"`
+	RegDT       time.Time  `gorm:"Column:reg_dt"`
+	ModDT       time.Time  `gorm:"Column:mod_dt"`
+	DeletedDT   *time.Time `gorm:"Column:deleted_dt"`
+
+	// UnavailableDT 유통기한이 지나 판매 중지된 시각, 판매 중지된 상품은 리스트와 검색에서 제외한다
+	UnavailableDT *time.Time `gorm:"Column:unavailable_dt"`
 }
 
 func NewItem(r request.CreateItem) (*Item, error) {
@@ -68,13 +56,12 @@ func NewItem(r request.CreateItem) (*Item, error) {
 		return nil, errors.Wrap(err, "failed to create new item")
 	}
 
-	category, _ := NewItemCategory(int(*r.Category))
 	size, _ := NewItemSize(int(*r.Size))
 	now := time.Now()
 
 	item := &Item{
-		AdminSeq:    r.AdminSeq,
-		Category:    *category,
+		StoreSeq:    r.StoreSeq,
+		Category:    *r.Category,
 		Barcode:     *r.Barcode,
 		Price:       *r.Price,
 		Cost:        *r.Cost,
@@ -82,6 +69,7 @@ func NewItem(r request.CreateItem) (*Item, error) {
 		Description: *r.Description,
 		ExpireDT:    *r.ExpireDT,
 		Size:        *size,
+		Version:     1,
 		RegDT:       now,
 		ModDT:       now,
 	}
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"strings"
+	"time"
+
+	"hello-cafe/internal/apierror"
+)
+
+// maxLotNumberLength item_lot.lot_number 컬럼 길이
+const maxLotNumberLength = 50
+
+type ItemLots []ItemLot
+
+// ItemLot 입고한 lot, Quantity 는 입고한 수량이고 Remaining 은 남은 수량이다
+type ItemLot struct {
+	LotSeq     int64     `gorm:"Column:lot_seq;PRIMARY_KEY"`
+	ItemSeq    int64     `gorm:"Column:item_seq"`
+	StoreSeq   int64     `gorm:"Column:store_seq"`
+	LotNumber  string    `gorm:"Column:lot_number"`
+	Quantity   int64     `gorm:"Column:quantity"`
+	Remaining  int64     `gorm:"Column:remaining"`
+	ReceivedDT time.Time `gorm:"Column:received_dt"`
+	ExpireDT   time.Time `gorm:"Column:expire_dt"`
+	RegDT      time.Time `gorm:"Column:reg_dt"`
+	ModDT      time.Time `gorm:"Column:mod_dt"`
+}
+
+func (l ItemLot) TableName() string {
+	return "item_lot"
+}
+
+// NewItemLot 수량 없이 lot 을 만들며, 입고 변동으로 수량을 더한다
+func NewItemLot(item Item, lotNumber string, receivedDT, expireDT time.Time) (*ItemLot, error) {
+	lotNumber = strings.TrimSpace(lotNumber)
+	switch {
+	case lotNumber == "":
+		return nil, apierror.ErrNilLotNumber
+	case len([]rune(lotNumber)) > maxLotNumberLength:
+		return nil, apierror.ErrInvalidLotNumber
+	case expireDT.Before(receivedDT):
+		return nil, apierror.ErrInvalidLotExpireDT
+	}
+
+	now := time.Now()
+	return &ItemLot{
+		ItemSeq:    item.ItemSeq,
+		StoreSeq:   item.StoreSeq,
+		LotNumber:  lotNumber,
+		ReceivedDT: receivedDT,
+		ExpireDT:   expireDT,
+		RegDT:      now,
+		ModDT:      now,
+	}, nil
+}
+
+// Expired 유통기한이 now 이전이면 지난 lot 이다
+func (l ItemLot) Expired(now time.Time) bool {
+	return l.ExpireDT.Before(now)
+}
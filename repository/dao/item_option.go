@@ -0,0 +1,179 @@
+package dao
+
+import (
+	"strings"
+	"time"
+
+	"hello-cafe/internal/apierror"
+)
+
+const (
+	// maxOptionNameLength item_option_group.name, item_option.name 컬럼 길이
+	maxOptionNameLength = 50
+	// maxOptionBarcodeLength item_option.barcode 컬럼 길이
+	maxOptionBarcodeLength = 100
+	// maxOptionSKULength item_option.sku 컬럼 길이
+	maxOptionSKULength = 50
+)
+
+type ItemOptionGroups []ItemOptionGroup
+
+// ItemOptionGroup MinSelect 가 1 이상이면 필수이고, MaxSelect 가 0 이면 개수 제한이 없다
+type ItemOptionGroup struct {
+	OptionGroupSeq int64     `gorm:"Column:option_group_seq;PRIMARY_KEY"`
+	ItemSeq        int64     `gorm:"Column:item_seq"`
+	StoreSeq       int64     `gorm:"Column:store_seq"`
+	Name           string    `gorm:"Column:name"`
+	MinSelect      int       `gorm:"Column:min_select"`
+	MaxSelect      int       `gorm:"Column:max_select"`
+	DisplayOrder   int       `gorm:"Column:display_order"`
+	RegDT          time.Time `gorm:"Column:reg_dt"`
+	ModDT          time.Time `gorm:"Column:mod_dt"`
+}
+
+func (g ItemOptionGroup) TableName() string {
+	return "item_option_group"
+}
+
+func (g ItemOptionGroup) Required() bool {
+	return g.MinSelect > 0
+}
+
+func (g ItemOptionGroup) MultiSelect() bool {
+	return g.MaxSelect != 1
+}
+
+// AllowSelect count 개를 선택할 수 있는지 확인한다
+func (g ItemOptionGroup) AllowSelect(count int) bool {
+	return count >= g.MinSelect && (g.MaxSelect == 0 || count <= g.MaxSelect)
+}
+
+func NewItemOptionGroup(item Item, name string, minSelect, maxSelect, displayOrder int) (*ItemOptionGroup, error) {
+	name, err := OptionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := OptionSelect(minSelect, maxSelect); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ItemOptionGroup{
+		ItemSeq:      item.ItemSeq,
+		StoreSeq:     item.StoreSeq,
+		Name:         name,
+		MinSelect:    minSelect,
+		MaxSelect:    maxSelect,
+		DisplayOrder: displayOrder,
+		RegDT:        now,
+		ModDT:        now,
+	}, nil
+}
+
+type ItemOptions []ItemOption
+
+// ItemOption Barcode, SKU 는 입력하지 않으면 nil 이며, 입력하면 매장 안에서 고유하다
+type ItemOption struct {
+	OptionSeq      int64     `gorm:"Column:option_seq;PRIMARY_KEY"`
+	OptionGroupSeq int64     `gorm:"Column:option_group_seq"`
+	ItemSeq        int64     `gorm:"Column:item_seq"`
+	StoreSeq       int64     `gorm:"Column:store_seq"`
+	Name           string    `gorm:"Column:name"`
+	PriceDelta     int64     `gorm:"Column:price_delta"`
+	CostDelta      int64     `gorm:"Column:cost_delta"`
+	Barcode        *string   `gorm:"Column:barcode"`
+	SKU            *string   `gorm:"Column:sku"`
+	DisplayOrder   int       `gorm:"Column:display_order"`
+	RegDT          time.Time `gorm:"Column:reg_dt"`
+	ModDT          time.Time `gorm:"Column:mod_dt"`
+}
+
+func (o ItemOption) TableName() string {
+	return "item_option"
+}
+
+func NewItemOption(group ItemOptionGroup, name string, priceDelta, costDelta int64, barcode, sku *string, displayOrder int) (*ItemOption, error) {
+	name, err := OptionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if barcode, err = OptionBarcode(barcode); err != nil {
+		return nil, err
+	}
+
+	if sku, err = OptionSKU(sku); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ItemOption{
+		OptionGroupSeq: group.OptionGroupSeq,
+		ItemSeq:        group.ItemSeq,
+		StoreSeq:       group.StoreSeq,
+		Name:           name,
+		PriceDelta:     priceDelta,
+		CostDelta:      costDelta,
+		Barcode:        barcode,
+		SKU:            sku,
+		DisplayOrder:   displayOrder,
+		RegDT:          now,
+		ModDT:          now,
+	}, nil
+}
+
+// OptionName 앞뒤 공백을 제거한 옵션 그룹, 옵션 이름
+func OptionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", apierror.ErrNilOptionName
+	case len([]rune(name)) > maxOptionNameLength:
+		return "", apierror.ErrInvalidOptionName
+	}
+
+	return name, nil
+}
+
+// OptionSelect 최소, 최대 선택 개수를 확인한다. 최대 0 은 제한 없음이다
+func OptionSelect(minSelect, maxSelect int) error {
+	if minSelect < 0 || maxSelect < 0 || (maxSelect > 0 && minSelect > maxSelect) {
+		return apierror.ErrInvalidOptionSelect
+	}
+
+	return nil
+}
+
+// OptionBarcode 앞뒤 공백을 제거하며, 비어 있으면 nil 이다
+func OptionBarcode(barcode *string) (*string, error) {
+	barcode = trimKey(barcode)
+	if barcode != nil && len([]rune(*barcode)) > maxOptionBarcodeLength {
+		return nil, apierror.ErrInvalidOption
+	}
+
+	return barcode, nil
+}
+
+// OptionSKU 앞뒤 공백을 제거하며, 비어 있으면 nil 이다
+func OptionSKU(sku *string) (*string, error) {
+	sku = trimKey(sku)
+	if sku != nil && len([]rune(*sku)) > maxOptionSKULength {
+		return nil, apierror.ErrInvalidOptionSKU
+	}
+
+	return sku, nil
+}
+
+func trimKey(key *string) *string {
+	if key == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*key)
+	if trimmed == "" {
+		return nil
+	}
+
+	return &trimmed
+}
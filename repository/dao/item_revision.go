@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ItemAction string
+
+const (
+	ItemActionCreate  ItemAction = "create"
+	ItemActionUpdate  ItemAction = "update"
+	ItemActionDelete  ItemAction = "delete"
+	ItemActionRestore ItemAction = "restore"
+	ItemActionHide    ItemAction = "hide"
+)
+
+// ItemChange 컬럼의 변경 전후 값, 등록 시에는 before 가 null 이다
+type ItemChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// ItemChanges 컬럼 이름별 변경 내역
+type ItemChanges map[string]ItemChange
+
+type ItemRevisions []ItemRevision
+
+type ItemRevision struct {
+	RevisionSeq  int64       `gorm:"Column:revision_seq;PRIMARY_KEY"`
+	ItemSeq      int64       `gorm:"Column:item_seq"`
+	StoreSeq     int64       `gorm:"Column:store_seq"`
+	AdminSeq     int64       `gorm:"Column:admin_seq"`
+	Action       ItemAction  `gorm:"Column:action"`
+	Version      int64       `gorm:"Column:version"`
+	Changes      ItemChanges `gorm:"Column:changes;serializer:json"`
+	Price        int64       `gorm:"Column:price"`
+	Cost         int64       `gorm:"Column:cost"`
+	PriceChanged bool        `gorm:"Column:price_changed"`
+	RegDT        time.Time   `gorm:"Column:reg_dt"`
+}
+
+// NewItemRevision 변경 전후의 상품을 비교하여 이력을 만든다. before 가 nil 이면 등록으로 본다
+func NewItemRevision(adminSeq int64, action ItemAction, before *Item, after Item) (*ItemRevision, error) {
+	var beforeColumns map[string]interface{}
+	if before != nil {
+		beforeColumns = before.revisionColumns()
+	}
+
+	changes, err := diffColumns(beforeColumns, after.revisionColumns())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	_, priceChanged := changes["price"]
+	_, costChanged := changes["cost"]
+
+	return &ItemRevision{
+		ItemSeq:      after.ItemSeq,
+		StoreSeq:     after.StoreSeq,
+		AdminSeq:     adminSeq,
+		Action:       action,
+		Version:      after.Version,
+		Changes:      changes,
+		Price:        after.Price,
+		Cost:         after.Cost,
+		PriceChanged: priceChanged || costChanged,
+		RegDT:        time.Now(),
+	}, nil
+}
+
+// revisionColumns 이력으로 남기는 컬럼, consonant 는 name 에서 만들어지므로 제외한다
+func (i Item) revisionColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"category":       i.Category,
+		"barcode":        i.Barcode,
+		"price":          i.Price,
+		"cost":           i.Cost,
+		"name":           i.Name,
+		"description":    i.Description,
+		"expire_dt":      i.ExpireDT,
+		"size":           i.Size,
+		"deleted_dt":     i.DeletedDT,
+		"unavailable_dt": i.UnavailableDT,
+	}
+}
+
+// diffColumns json 으로 표현한 값이 다른 컬럼만 남긴다
+func diffColumns(before, after map[string]interface{}) (ItemChanges, error) {
+	changes := make(ItemChanges)
+	for column, afterValue := range after {
+		afterJSON, err := json.Marshal(afterValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal column(%s)", column)
+		}
+
+		beforeJSON := json.RawMessage("null")
+		if beforeValue, ok := before[column]; ok {
+			if beforeJSON, err = json.Marshal(beforeValue); err != nil {
+				return nil, errors.Wrapf(err, "failed to marshal column(%s)", column)
+			}
+		}
+
+		if bytes.Equal(beforeJSON, afterJSON) {
+			continue
+		}
+
+		changes[column] = ItemChange{Before: beforeJSON, After: afterJSON}
+	}
+
+	return changes, nil
+}
+
+func (r ItemRevision) TableName() string {
+	return "item_revision"
+}
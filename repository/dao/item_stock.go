@@ -0,0 +1,68 @@
+package dao
+
+import "time"
+
+type ItemStocks []ItemStock
+
+// ItemStock 상품의 현재 재고, LowStockThreshold 가 nil 이면 설정의 기본값을 사용한다
+type ItemStock struct {
+	ItemSeq           int64     `gorm:"Column:item_seq;PRIMARY_KEY;autoIncrement:false"`
+	StoreSeq          int64     `gorm:"Column:store_seq"`
+	Quantity          int64     `gorm:"Column:quantity"`
+	LowStockThreshold *int64    `gorm:"Column:low_stock_threshold"`
+	ModDT             time.Time `gorm:"Column:mod_dt"`
+}
+
+func (s ItemStock) TableName() string {
+	return "item_stock"
+}
+
+// Threshold 상품에 설정한 기준이 없으면 defaultThreshold 를 사용한다
+func (s ItemStock) Threshold(defaultThreshold int64) int64 {
+	if s.LowStockThreshold != nil {
+		return *s.LowStockThreshold
+	}
+
+	return defaultThreshold
+}
+
+// LowStock 재고가 기준 이하이면 부족으로 본다
+func (s ItemStock) LowStock(defaultThreshold int64) bool {
+	return s.Quantity <= s.Threshold(defaultThreshold)
+}
+
+func NewItemStock(item Item) ItemStock {
+	return ItemStock{ItemSeq: item.ItemSeq, StoreSeq: item.StoreSeq, ModDT: time.Now()}
+}
+
+type StockMovementType string
+
+const (
+	StockMovementReceive  StockMovementType = "receive"
+	StockMovementSell     StockMovementType = "sell"
+	StockMovementWaste    StockMovementType = "waste"
+	StockMovementAdjust   StockMovementType = "adjust"
+	StockMovementTransfer StockMovementType = "transfer"
+)
+
+type StockMovements []StockMovement
+
+// StockMovement 재고 변동 원장, Quantity 는 감소일 때 음수이고 Balance 는 변동 후 재고이다.
+// lot 의 재고가 바뀐 변동은 LotSeq 가 있다
+type StockMovement struct {
+	MovementSeq      int64             `gorm:"Column:movement_seq;PRIMARY_KEY"`
+	ItemSeq          int64             `gorm:"Column:item_seq"`
+	StoreSeq         int64             `gorm:"Column:store_seq"`
+	AdminSeq         int64             `gorm:"Column:admin_seq"`
+	Type             StockMovementType `gorm:"Column:type"`
+	Quantity         int64             `gorm:"Column:quantity"`
+	Balance          int64             `gorm:"Column:balance"`
+	Reason           string            `gorm:"Column:reason"`
+	TransferStoreSeq *int64            `gorm:"Column:transfer_store_seq"`
+	LotSeq           *int64            `gorm:"Column:lot_seq"`
+	RegDT            time.Time         `gorm:"Column:reg_dt"`
+}
+
+func (m StockMovement) TableName() string {
+	return "stock_movement"
+}
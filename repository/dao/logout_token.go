@@ -1,9 +1,13 @@
 package dao
 
+import "time"
+
 type LogoutToken struct {
-	TokenSeq int64  `gorm:"Column:token_seq;PRIMARY_KEY"`
-	AdminSeq int64  `gorm:"Column:admin_seq"`
-	Token    string `gorm:"Column:token"`
+	TokenSeq int64     `gorm:"Column:token_seq;PRIMARY_KEY"`
+	AdminSeq int64     `gorm:"Column:admin_seq"`
+	TokenID  string    `gorm:"Column:token_id"`
+	ExpireDT time.Time `gorm:"Column:expire_dt"`
+	RegDT    time.Time `gorm:"Column:reg_dt"`
 }
 
 func (l LogoutToken) TableName() string {
@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+type RefreshToken struct {
+	TokenSeq  int64      `gorm:"Column:token_seq;PRIMARY_KEY"`
+	AdminSeq  int64      `gorm:"Column:admin_seq"`
+	FamilyID  string     `gorm:"Column:family_id"`
+	TokenHash string     `gorm:"Column:token_hash"`
+	ExpireDT  time.Time  `gorm:"Column:expire_dt"`
+	UsedDT    *time.Time `gorm:"Column:used_dt"`
+	RevokedDT *time.Time `gorm:"Column:revoked_dt"`
+	RegDT     time.Time  `gorm:"Column:reg_dt"`
+}
+
+func (r RefreshToken) TableName() string {
+	return "refresh_token"
+}
@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"strings"
+	"time"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+)
+
+type Store struct {
+	StoreSeq int64     `gorm:"Column:store_seq;PRIMARY_KEY"`
+	Name     string    `gorm:"Column:name"`
+	RegDT    time.Time `gorm:"Column:reg_dt"`
+	ModDT    time.Time `gorm:"Column:mod_dt"`
+}
+
+func (s Store) TableName() string {
+	return "store"
+}
+
+func NewStore(name string) (*Store, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apierror.ErrNilStoreName
+	}
+
+	now := time.Now()
+	return &Store{
+		Name:  name,
+		RegDT: now,
+		ModDT: now,
+	}, nil
+}
+
+// StoreMember 관리자의 매장 소속 및 매장 내 권한
+type StoreMember struct {
+	StoreSeq int64      `gorm:"Column:store_seq;PRIMARY_KEY;autoIncrement:false"`
+	AdminSeq int64      `gorm:"Column:admin_seq;PRIMARY_KEY;autoIncrement:false"`
+	Role     model.Role `gorm:"Column:role"`
+	RegDT    time.Time  `gorm:"Column:reg_dt"`
+}
+
+func (m StoreMember) TableName() string {
+	return "store_member"
+}
+
+// StoreInfo 관리자가 소속된 매장 정보 (store_member + store)
+type StoreInfo struct {
+	StoreSeq int64      `gorm:"Column:store_seq"`
+	Name     string     `gorm:"Column:name"`
+	Role     model.Role `gorm:"Column:role"`
+	RegDT    time.Time  `gorm:"Column:reg_dt"`
+}
+
+// MemberInfo 매장에 소속된 관리자 정보 (store_member + admin)
+type MemberInfo struct {
+	StoreSeq int64      `gorm:"Column:store_seq"`
+	AdminSeq int64      `gorm:"Column:admin_seq"`
+	Phone    string     `gorm:"Column:phone"`
+	Name     string     `gorm:"Column:name"`
+	Role     model.Role `gorm:"Column:role"`
+	RegDT    time.Time  `gorm:"Column:reg_dt"`
+}
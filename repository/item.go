@@ -1,52 +1,146 @@
 package repository
 
 import (
-	"strings"
+	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"hello-cafe/internal/apierror"
-	"hello-cafe/internal/db"
 	"hello-cafe/internal/valid"
 	"hello-cafe/model/request"
 	"hello-cafe/repository/dao"
 )
 
+// sortColumn 정렬과 cursor 비교에 사용하는 SQL 식, 같은 값은 item_seq 로 정렬한다
+func sortColumn(s request.ItemSort) string {
+	switch s {
+	case request.ItemSortName, request.ItemSortPrice, request.ItemSortExpireDT, request.ItemSortRegDT:
+		return string(s)
+	case request.ItemSortMargin:
+		return "(price - cost)"
+	default:
+		return "item_seq"
+	}
+}
+
+// ItemSortValue 상품의 정렬 값, 이름은 string, 시간은 time.Time, 나머지는 int64 이다
+func ItemSortValue(s request.ItemSort, item dao.Item) any {
+	switch s {
+	case request.ItemSortName:
+		return item.Name
+	case request.ItemSortPrice:
+		return item.Price
+	case request.ItemSortMargin:
+		return item.Price - item.Cost
+	case request.ItemSortExpireDT:
+		return item.ExpireDT
+	case request.ItemSortRegDT:
+		return item.RegDT
+	default:
+		return item.ItemSeq
+	}
+}
+
+// ItemCursor 이전 페이지 마지막 상품의 정렬 값(ItemSortValue)과 item_seq
+type ItemCursor struct {
+	Value   any
+	ItemSeq int64
+}
+
+// ItemAvailability 판매 중지된 상품의 조회 여부
+type ItemAvailability int
+
+const (
+	ItemAvailabilityAll         ItemAvailability = iota // 판매 중지된 상품도 포함
+	ItemAvailabilityAvailable                           // 판매 중인 상품만
+	ItemAvailabilityUnavailable                         // 판매 중지된 상품만
+)
+
+// ItemFilter 값이 비어 있는 조건은 사용하지 않는다. 기간은 From 이상 To 미만이다
+type ItemFilter struct {
+	StoreSeq   int64
+	Categories []int64
+	Size       *int
+	MinPrice   *int64
+	MaxPrice   *int64
+	MinCost    *int64
+	MaxCost    *int64
+	ExpireFrom time.Time
+	ExpireTo   time.Time
+	RegFrom    time.Time
+	RegTo      time.Time
+	ModFrom    time.Time
+	ModTo      time.Time
+
+	// Availability 가 비어 있으면 판매 중지된 상품도 조회한다
+	Availability ItemAvailability
+
+	// Sort 가 비어 있으면 item_seq, Asc 가 아니면 내림차순
+	Sort  request.ItemSort
+	Asc   bool
+	After *ItemCursor
+	Limit int
+}
+
 type ItemRepository interface {
-	Create(item request.CreateItem) error
-	Update(item request.UpdateItem) error
-	Delete(itemSeq int64) error
-	Find(adminSeq, lastItemSeq int64, limit int) (dao.Items, error)
-	Get(itemSeq int64) (*dao.Item, error)
-	GetByBarcode(barcode string) (*dao.Item, error)
-	Search(adminSeq int64, text string) (dao.Items, error)
+	Create(ctx context.Context, item request.CreateItem) error
+	Update(ctx context.Context, item request.UpdateItem) error
+	Delete(ctx context.Context, itemSeq, version int64) error
+	Find(ctx context.Context, filter ItemFilter) (dao.Items, error)
+	Count(ctx context.Context, filter ItemFilter) (int64, error)
+	Get(ctx context.Context, itemSeq int64) (*dao.Item, error)
+	GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error)
+	FindAll(ctx context.Context, storeSeq int64) (dao.Items, error)
+	FindBySeqs(ctx context.Context, storeSeq int64, itemSeqs []int64) (dao.Items, error)
+	Scan(ctx context.Context, afterItemSeq int64, limit int) (dao.Items, error)
+	CountByCategory(ctx context.Context, categorySeq int64) (int64, error)
+
+	// 유통기한
+	FindExpired(ctx context.Context, expiredBefore time.Time, limit int) (dao.Items, error)
+	MarkUnavailable(ctx context.Context, itemSeq int64, expiredBefore time.Time) error
+
+	// 휴지통
+	FindDeleted(ctx context.Context, storeSeq, lastItemSeq int64, limit int) (dao.Items, error)
+	GetDeleted(ctx context.Context, itemSeq int64) (*dao.Item, error)
+	GetDeletedByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error)
+	Restore(ctx context.Context, itemSeq int64) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
-type itemRepository struct{}
+type itemRepository struct {
+	conn *gorm.DB
+}
 
-func NewItemRepository() ItemRepository {
-	return &itemRepository{}
+func NewItemRepository(conn *gorm.DB) ItemRepository {
+	return &itemRepository{conn: conn}
 }
 
-func (r *itemRepository) Create(item request.CreateItem) error {
+func (r *itemRepository) Create(ctx context.Context, item request.CreateItem) error {
 	newItem, err := dao.NewItem(item)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	if err := db.Conn().Create(&newItem).Error; err != nil {
+	if err := r.conn.WithContext(ctx).Create(&newItem).Error; err != nil {
 		return errors.Wrap(err, "failed to create item")
 	}
 
 	return nil
 }
 
-func (r *itemRepository) Update(item request.UpdateItem) error {
+// Update item.Version 이 저장된 버전과 같을 때만 수정하며, 수정할 때마다 버전이 증가한다
+func (r *itemRepository) Update(ctx context.Context, item request.UpdateItem) error {
+	if valid.IsNil(item.Version) {
+		return apierror.ErrNilItemVersion
+	}
+
 	updateItem, err := NewUpdateItem(item)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	i, err := r.Get(item.ItemSeq)
+	i, err := r.Get(ctx, item.ItemSeq)
 	if err != nil {
 		return errors.Wrap(err, "failed to get item")
 	}
@@ -55,15 +149,23 @@ func (r *itemRepository) Update(item request.UpdateItem) error {
 		return apierror.ErrNotExistItem
 	}
 
-	if err := db.Conn().Model(&i).Updates(updateItem.ToMap()).Error; err != nil {
-		return errors.Wrap(err, "failed to update item info")
+	// mod_dt 는 ON UPDATE 를 지원하지 않는 DB 도 있으므로 직접 갱신한다
+	now := time.Now()
+	columns := updateItem.ToMap()
+	columns["mod_dt"] = now
+	columns["version"] = gorm.Expr("version + 1")
+
+	// 유통기한을 늘리면 다시 판매한다
+	if !valid.IsNil(updateItem.ExpireDT) && updateItem.ExpireDT.After(now) {
+		columns["unavailable_dt"] = nil
 	}
 
-	return nil
+	return r.updateVersion(ctx, item.ItemSeq, *item.Version, columns, "failed to update item info")
 }
 
-func (r *itemRepository) Delete(itemSeq int64) error {
-	item, err := r.Get(itemSeq)
+// Delete 상품을 휴지통으로 옮긴다. 영구 삭제는 보관 기간이 지난 후 Purge 로 처리한다
+func (r *itemRepository) Delete(ctx context.Context, itemSeq, version int64) error {
+	item, err := r.Get(ctx, itemSeq)
 	if err != nil {
 		return errors.Wrap(err, "failed to get item")
 	}
@@ -72,26 +174,57 @@ func (r *itemRepository) Delete(itemSeq int64) error {
 		return apierror.ErrNotExistItem
 	}
 
-	return errors.WithStack(db.Conn().Delete(&item).Error)
+	columns := map[string]interface{}{
+		"deleted_dt": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	}
+
+	return r.updateVersion(ctx, itemSeq, version, columns, "failed to delete item")
 }
 
-func (r *itemRepository) Find(adminSeq int64, lastItemSeq int64, limit int) (dao.Items, error) {
-	if adminSeq < 0 {
-		return nil, apierror.ErrInvalidAdmin
+// updateVersion 다른 요청이 먼저 변경하여 버전이 달라졌다면 ErrStaleItem 을 반환한다
+func (r *itemRepository) updateVersion(ctx context.Context, itemSeq, version int64, columns map[string]interface{}, msg string) error {
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.Item{}).
+		Where("item_seq = ?", itemSeq).
+		Where("version = ?", version).
+		Where("deleted_dt IS NULL").
+		Updates(columns)
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, msg)
 	}
 
-	if limit <= 0 {
-		limit = 10
+	if tx.RowsAffected == 0 {
+		return apierror.ErrStaleItem
 	}
 
-	tx := db.Conn().
-		Table("item").
-		Select("*").
-		Limit(limit).
-		Order("item_seq DESC")
+	return nil
+}
 
-	if lastItemSeq > 0 {
-		tx = tx.Where("item_seq < ?", lastItemSeq)
+func (r *itemRepository) Find(ctx context.Context, filter ItemFilter) (dao.Items, error) {
+	if filter.StoreSeq < 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	column := sortColumn(filter.Sort)
+	direction, compare := "DESC", "<"
+	if filter.Asc {
+		direction, compare = "ASC", ">"
+	}
+
+	tx := r.where(r.conn.WithContext(ctx).Table("item").Select("*"), filter).
+		Limit(filter.Limit).
+		Order(column + " " + direction).
+		Order("item_seq " + direction)
+
+	// 정렬 값이 같으면 item_seq 로 이어서 조회한다
+	if !valid.IsNil(filter.After) {
+		tx = tx.Where("("+column+" "+compare+" ? OR ("+column+" = ? AND item_seq "+compare+" ?))",
+			filter.After.Value, filter.After.Value, filter.After.ItemSeq)
 	}
 
 	items := make(dao.Items, 0)
@@ -102,48 +235,293 @@ func (r *itemRepository) Find(adminSeq int64, lastItemSeq int64, limit int) (dao
 	return items, nil
 }
 
-func (r *itemRepository) Get(itemSeq int64) (*dao.Item, error) {
+// Count Find 와 같은 조건의 상품 수, 정렬과 After, Limit 는 사용하지 않는다
+func (r *itemRepository) Count(ctx context.Context, filter ItemFilter) (int64, error) {
+	if filter.StoreSeq < 0 {
+		return 0, apierror.ErrInvalidStore
+	}
+
+	var count int64
+	if err := r.where(r.conn.WithContext(ctx).Table("item"), filter).Count(&count).Error; err != nil {
+		return 0, errors.Wrap(err, "failed to count items")
+	}
+
+	return count, nil
+}
+
+// where 정렬과 페이지를 제외한 ItemFilter 조건
+func (r *itemRepository) where(tx *gorm.DB, filter ItemFilter) *gorm.DB {
+	tx = tx.Where("store_seq = ?", filter.StoreSeq).
+		Where("deleted_dt IS NULL")
+
+	switch filter.Availability {
+	case ItemAvailabilityAvailable:
+		tx = tx.Where("unavailable_dt IS NULL")
+	case ItemAvailabilityUnavailable:
+		tx = tx.Where("unavailable_dt IS NOT NULL")
+	}
+
+	if !valid.IsNil(filter.Categories) {
+		tx = tx.Where("category IN ?", filter.Categories)
+	}
+
+	if !valid.IsNil(filter.Size) {
+		tx = tx.Where("size = ?", *filter.Size)
+	}
+
+	if !valid.IsNil(filter.MinPrice) {
+		tx = tx.Where("price >= ?", *filter.MinPrice)
+	}
+
+	if !valid.IsNil(filter.MaxPrice) {
+		tx = tx.Where("price <= ?", *filter.MaxPrice)
+	}
+
+	if !valid.IsNil(filter.MinCost) {
+		tx = tx.Where("cost >= ?", *filter.MinCost)
+	}
+
+	if !valid.IsNil(filter.MaxCost) {
+		tx = tx.Where("cost <= ?", *filter.MaxCost)
+	}
+
+	periods := []struct {
+		column   string
+		from, to time.Time
+	}{
+		{column: "expire_dt", from: filter.ExpireFrom, to: filter.ExpireTo},
+		{column: "reg_dt", from: filter.RegFrom, to: filter.RegTo},
+		{column: "mod_dt", from: filter.ModFrom, to: filter.ModTo},
+	}
+	for _, period := range periods {
+		if !period.from.IsZero() {
+			tx = tx.Where(period.column+" >= ?", period.from)
+		}
+		if !period.to.IsZero() {
+			tx = tx.Where(period.column+" < ?", period.to)
+		}
+	}
+
+	return tx
+}
+
+func (r *itemRepository) Get(ctx context.Context, itemSeq int64) (*dao.Item, error) {
 	if itemSeq < 0 {
 		return nil, apierror.ErrInvalidItem
 	}
 
 	var item dao.Item
-	if err := db.Conn().Take(&item, itemSeq).Error; err != nil {
+	if err := r.conn.WithContext(ctx).
+		Where("deleted_dt IS NULL").
+		Take(&item, itemSeq).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to take item info")
 	}
 
 	return &item, nil
 }
 
-func (r *itemRepository) GetByBarcode(barcode string) (*dao.Item, error) {
+func (r *itemRepository) GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error) {
 	var item dao.Item
-	if err := db.Conn().Where("barcode = ?", barcode).Take(&item).Error; err != nil {
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("barcode = ?", barcode).
+		Where("deleted_dt IS NULL").
+		Take(&item).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to take item info")
 	}
 
 	return &item, nil
 }
 
-func (r *itemRepository) Search(adminSeq int64, text string) (dao.Items, error) {
-	switch {
-	case adminSeq < 0:
-		return nil, apierror.ErrInvalidAdmin
-	case len(strings.TrimSpace(text)) == 0:
-		return nil, errors.New("text is empty")
+// FindAll 휴지통과 판매 중지된 상품을 제외한 매장의 모든 상품을 item_seq 역순으로 조회한다. 검색 대상을 가져올 때 사용한다
+func (r *itemRepository) FindAll(ctx context.Context, storeSeq int64) (dao.Items, error) {
+	if storeSeq < 0 {
+		return nil, apierror.ErrInvalidStore
 	}
 
-	consonant := "%" + text + "%"
-	tx := db.Conn().
+	items := make(dao.Items, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("deleted_dt IS NULL").
+		Where("unavailable_dt IS NULL").
+		Order("item_seq DESC").
+		Find(&items).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find all items")
+	}
+
+	return items, nil
+}
+
+// FindBySeqs 휴지통을 제외한 매장의 상품 중 itemSeqs 에 해당하는 상품을 조회한다. 순서는 보장하지 않는다
+func (r *itemRepository) FindBySeqs(ctx context.Context, storeSeq int64, itemSeqs []int64) (dao.Items, error) {
+	items := make(dao.Items, 0, len(itemSeqs))
+	if len(itemSeqs) == 0 {
+		return items, nil
+	}
+
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("item_seq IN ?", itemSeqs).
+		Where("deleted_dt IS NULL").
+		Find(&items).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find items by seq")
+	}
+
+	return items, nil
+}
+
+// Scan 휴지통과 판매 중지된 상품을 제외한 모든 매장의 상품을 item_seq 순서로 afterItemSeq 다음부터 조회한다. 검색 색인을 만들 때 사용한다
+func (r *itemRepository) Scan(ctx context.Context, afterItemSeq int64, limit int) (dao.Items, error) {
+	items := make(dao.Items, 0, limit)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq > ?", afterItemSeq).
+		Where("deleted_dt IS NULL").
+		Where("unavailable_dt IS NULL").
+		Order("item_seq ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to scan items")
+	}
+
+	return items, nil
+}
+
+// CountByCategory 휴지통의 상품도 포함한 카테고리의 상품 개수
+func (r *itemRepository) CountByCategory(ctx context.Context, categorySeq int64) (int64, error) {
+	var count int64
+	if err := r.conn.WithContext(ctx).
+		Model(&dao.Item{}).
+		Where("category = ?", categorySeq).
+		Count(&count).Error; err != nil {
+		return 0, errors.Wrapf(err, "failed to count items by category(%d)", categorySeq)
+	}
+
+	return count, nil
+}
+
+// FindExpired 모든 매장에서 유통기한이 expiredBefore 이전인데 판매 중인 상품을 item_seq 순서로 limit 개 조회한다
+func (r *itemRepository) FindExpired(ctx context.Context, expiredBefore time.Time, limit int) (dao.Items, error) {
+	items := make(dao.Items, 0, limit)
+	if err := r.conn.WithContext(ctx).
+		Where("expire_dt < ?", expiredBefore).
+		Where("unavailable_dt IS NULL").
+		Where("deleted_dt IS NULL").
+		Order("item_seq ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find expired items")
+	}
+
+	return items, nil
+}
+
+// MarkUnavailable 유통기한이 expiredBefore 이전인 판매 중인 상품을 판매 중지한다.
+// 그 사이 다른 서버가 먼저 판매 중지했거나 유통기한을 수정했다면 ErrStaleItem 을 반환한다
+func (r *itemRepository) MarkUnavailable(ctx context.Context, itemSeq int64, expiredBefore time.Time) error {
+	now := time.Now()
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.Item{}).
+		Where("item_seq = ?", itemSeq).
+		Where("expire_dt < ?", expiredBefore).
+		Where("unavailable_dt IS NULL").
+		Where("deleted_dt IS NULL").
+		Updates(map[string]interface{}{
+			"unavailable_dt": now,
+			"mod_dt":         now,
+			"version":        gorm.Expr("version + 1"),
+		})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "failed to mark item(%d) unavailable", itemSeq)
+	}
+
+	if tx.RowsAffected == 0 {
+		return apierror.ErrStaleItem
+	}
+
+	return nil
+}
+
+// FindDeleted 휴지통의 상품을 item_seq 역순으로 조회한다
+func (r *itemRepository) FindDeleted(ctx context.Context, storeSeq, lastItemSeq int64, limit int) (dao.Items, error) {
+	if storeSeq < 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	tx := r.conn.WithContext(ctx).
 		Table("item").
 		Select("*").
-		Where("admin_seq = ?", adminSeq).
-		Where("(name LIKE ? OR consonant LIKE ?)", consonant, consonant).
+		Where("store_seq = ?", storeSeq).
+		Where("deleted_dt IS NOT NULL").
+		Limit(limit).
 		Order("item_seq DESC")
 
+	if lastItemSeq > 0 {
+		tx = tx.Where("item_seq < ?", lastItemSeq)
+	}
+
 	items := make(dao.Items, 0)
 	if err := tx.Find(&items).Error; err != nil {
-		return nil, errors.Wrap(err, "failed to search items")
+		return nil, errors.Wrap(err, "failed to find deleted items")
 	}
 
 	return items, nil
 }
+
+func (r *itemRepository) GetDeleted(ctx context.Context, itemSeq int64) (*dao.Item, error) {
+	var item dao.Item
+	if err := r.conn.WithContext(ctx).
+		Where("deleted_dt IS NOT NULL").
+		Take(&item, itemSeq).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to take deleted item info")
+	}
+
+	return &item, nil
+}
+
+func (r *itemRepository) GetDeletedByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error) {
+	var item dao.Item
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("barcode = ?", barcode).
+		Where("deleted_dt IS NOT NULL").
+		Take(&item).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to take deleted item info")
+	}
+
+	return &item, nil
+}
+
+func (r *itemRepository) Restore(ctx context.Context, itemSeq int64) error {
+	if _, err := r.GetDeleted(ctx, itemSeq); err != nil {
+		return errors.Wrap(err, "failed to get deleted item")
+	}
+
+	if err := r.conn.WithContext(ctx).
+		Model(&dao.Item{}).
+		Where("item_seq = ?", itemSeq).
+		Updates(map[string]interface{}{
+			"deleted_dt": nil,
+			"mod_dt":     time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return errors.Wrap(err, "failed to restore item")
+	}
+
+	return nil
+}
+
+// Purge deletedBefore 이전에 휴지통으로 옮겨진 상품을 영구 삭제한다
+func (r *itemRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	tx := r.conn.WithContext(ctx).
+		Where("deleted_dt < ?", deletedBefore).
+		Delete(&dao.Item{})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "failed to purge deleted items")
+	}
+
+	return tx.RowsAffected, nil
+}
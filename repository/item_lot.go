@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/repository/dao"
+)
+
+// ItemLotRepository lot 의 수량은 Add 로만 바꾸며, 상품 재고와 같은 transaction 에서 변경한다
+type ItemLotRepository interface {
+	Create(ctx context.Context, lot dao.ItemLot) (*dao.ItemLot, error)
+	Get(ctx context.Context, lotSeq int64) (*dao.ItemLot, error)
+	GetByNumber(ctx context.Context, itemSeq int64, lotNumber string) (*dao.ItemLot, error)
+	FindRemaining(ctx context.Context, itemSeq int64) (dao.ItemLots, error)
+	FindExpiring(ctx context.Context, storeSeq int64, before time.Time) (dao.ItemLots, error)
+	Add(ctx context.Context, lotSeq, quantity int64) error
+
+	// PurgeOrphans 영구 삭제된 상품의 lot 을 삭제한다
+	PurgeOrphans(ctx context.Context) (int64, error)
+}
+
+type itemLotRepository struct {
+	conn *gorm.DB
+}
+
+func NewItemLotRepository(conn *gorm.DB) ItemLotRepository {
+	return &itemLotRepository{conn: conn}
+}
+
+func (r *itemLotRepository) Create(ctx context.Context, lot dao.ItemLot) (*dao.ItemLot, error) {
+	if err := r.conn.WithContext(ctx).Create(&lot).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create item lot")
+	}
+
+	return &lot, nil
+}
+
+func (r *itemLotRepository) Get(ctx context.Context, lotSeq int64) (*dao.ItemLot, error) {
+	lot := new(dao.ItemLot)
+	if err := r.conn.WithContext(ctx).Take(&lot, lotSeq).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item lot by lot_seq(%d)", lotSeq)
+	}
+
+	return lot, nil
+}
+
+func (r *itemLotRepository) GetByNumber(ctx context.Context, itemSeq int64, lotNumber string) (*dao.ItemLot, error) {
+	lot := new(dao.ItemLot)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ? AND lot_number = ?", itemSeq, lotNumber).
+		Take(&lot).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item lot by lot_number(%s)", lotNumber)
+	}
+
+	return lot, nil
+}
+
+// FindRemaining 남은 수량이 있는 lot 을 유통기한이 빠른 순서로 조회한다
+func (r *itemLotRepository) FindRemaining(ctx context.Context, itemSeq int64) (dao.ItemLots, error) {
+	lots := make(dao.ItemLots, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Where("remaining > 0").
+		Order("expire_dt ASC, lot_seq ASC").
+		Find(&lots).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find item lots by item_seq(%d)", itemSeq)
+	}
+
+	return lots, nil
+}
+
+// FindExpiring 휴지통을 제외한 매장의 상품 중 before 이전에 유통기한이 끝나는, 남은 수량이 있는 lot 을 유통기한 순서로 조회한다
+func (r *itemLotRepository) FindExpiring(ctx context.Context, storeSeq int64, before time.Time) (dao.ItemLots, error) {
+	items := r.conn.Model(&dao.Item{}).
+		Select("item_seq").
+		Where("store_seq = ?", storeSeq).
+		Where("deleted_dt IS NULL")
+
+	lots := make(dao.ItemLots, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("expire_dt < ?", before).
+		Where("remaining > 0").
+		Where("item_seq IN (?)", items).
+		Order("expire_dt ASC, lot_seq ASC").
+		Find(&lots).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find expiring lots by store_seq(%d)", storeSeq)
+	}
+
+	return lots, nil
+}
+
+// Add 남은 수량에 quantity 를 더하며 입고(양수)는 입고 수량에도 더한다.
+// 남은 수량이 음수가 되면 변경하지 않고 ErrInsufficientLot 을 반환한다
+func (r *itemLotRepository) Add(ctx context.Context, lotSeq, quantity int64) error {
+	received := max(quantity, 0)
+
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.ItemLot{}).
+		Where("lot_seq = ?", lotSeq).
+		Where("remaining + ? >= 0", quantity).
+		Updates(map[string]interface{}{
+			"quantity":  gorm.Expr("quantity + ?", received),
+			"remaining": gorm.Expr("remaining + ?", quantity),
+			"mod_dt":    time.Now(),
+		})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "failed to add item lot by lot_seq(%d)", lotSeq)
+	}
+
+	if tx.RowsAffected == 0 {
+		return apierror.ErrInsufficientLot
+	}
+
+	return nil
+}
+
+func (r *itemLotRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	items := r.conn.Model(&dao.Item{}).Select("item_seq")
+
+	tx := r.conn.WithContext(ctx).
+		Where("item_seq NOT IN (?)", items).
+		Delete(&dao.ItemLot{})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "failed to purge item lots")
+	}
+
+	return tx.RowsAffected, nil
+}
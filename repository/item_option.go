@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+type ItemOptionRepository interface {
+	CreateGroup(ctx context.Context, group dao.ItemOptionGroup) (*dao.ItemOptionGroup, error)
+	GetGroup(ctx context.Context, optionGroupSeq int64) (*dao.ItemOptionGroup, error)
+	FindGroups(ctx context.Context, itemSeq int64) (dao.ItemOptionGroups, error)
+	UpdateGroup(ctx context.Context, group dao.ItemOptionGroup) error
+	DeleteGroup(ctx context.Context, optionGroupSeq int64) error
+
+	Create(ctx context.Context, option dao.ItemOption) (*dao.ItemOption, error)
+	Get(ctx context.Context, optionSeq int64) (*dao.ItemOption, error)
+	Find(ctx context.Context, itemSeq int64) (dao.ItemOptions, error)
+	GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.ItemOption, error)
+	Update(ctx context.Context, option dao.ItemOption) error
+	Delete(ctx context.Context, optionSeq int64) error
+
+	// PurgeOrphans 영구 삭제된 상품의 옵션 그룹과 옵션을 삭제한다
+	PurgeOrphans(ctx context.Context) (int64, error)
+}
+
+type itemOptionRepository struct {
+	conn *gorm.DB
+}
+
+func NewItemOptionRepository(conn *gorm.DB) ItemOptionRepository {
+	return &itemOptionRepository{conn: conn}
+}
+
+func (r *itemOptionRepository) CreateGroup(ctx context.Context, group dao.ItemOptionGroup) (*dao.ItemOptionGroup, error) {
+	if err := r.conn.WithContext(ctx).Create(&group).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create item option group")
+	}
+
+	return &group, nil
+}
+
+func (r *itemOptionRepository) GetGroup(ctx context.Context, optionGroupSeq int64) (*dao.ItemOptionGroup, error) {
+	group := new(dao.ItemOptionGroup)
+	if err := r.conn.WithContext(ctx).Take(&group, optionGroupSeq).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item option group by option_group_seq(%d)", optionGroupSeq)
+	}
+
+	return group, nil
+}
+
+// FindGroups 상품의 옵션 그룹을 노출 순서, 등록 순서로 조회한다
+func (r *itemOptionRepository) FindGroups(ctx context.Context, itemSeq int64) (dao.ItemOptionGroups, error) {
+	groups := make(dao.ItemOptionGroups, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Order("display_order ASC, option_group_seq ASC").
+		Find(&groups).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find item option groups by item_seq(%d)", itemSeq)
+	}
+
+	return groups, nil
+}
+
+// UpdateGroup 이름, 선택 개수, 노출 순서를 변경한다
+func (r *itemOptionRepository) UpdateGroup(ctx context.Context, group dao.ItemOptionGroup) error {
+	result := r.conn.WithContext(ctx).
+		Model(&dao.ItemOptionGroup{}).
+		Where("option_group_seq = ?", group.OptionGroupSeq).
+		Updates(map[string]interface{}{
+			"name":          group.Name,
+			"min_select":    group.MinSelect,
+			"max_select":    group.MaxSelect,
+			"display_order": group.DisplayOrder,
+			"mod_dt":        time.Now(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, "failed to update option_group_seq(%d)", group.OptionGroupSeq)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "failed to update option_group_seq(%d)", group.OptionGroupSeq)
+	}
+
+	return nil
+}
+
+// DeleteGroup 옵션 그룹의 옵션도 함께 삭제한다
+func (r *itemOptionRepository) DeleteGroup(ctx context.Context, optionGroupSeq int64) error {
+	if err := r.conn.WithContext(ctx).
+		Where("option_group_seq = ?", optionGroupSeq).
+		Delete(&dao.ItemOption{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete item options by option_group_seq(%d)", optionGroupSeq)
+	}
+
+	if err := r.conn.WithContext(ctx).
+		Delete(&dao.ItemOptionGroup{}, optionGroupSeq).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete option_group_seq(%d)", optionGroupSeq)
+	}
+
+	return nil
+}
+
+func (r *itemOptionRepository) Create(ctx context.Context, option dao.ItemOption) (*dao.ItemOption, error) {
+	if err := r.conn.WithContext(ctx).Create(&option).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create item option")
+	}
+
+	return &option, nil
+}
+
+func (r *itemOptionRepository) Get(ctx context.Context, optionSeq int64) (*dao.ItemOption, error) {
+	option := new(dao.ItemOption)
+	if err := r.conn.WithContext(ctx).Take(&option, optionSeq).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item option by option_seq(%d)", optionSeq)
+	}
+
+	return option, nil
+}
+
+// Find 상품의 모든 옵션을 노출 순서, 등록 순서로 조회한다
+func (r *itemOptionRepository) Find(ctx context.Context, itemSeq int64) (dao.ItemOptions, error) {
+	options := make(dao.ItemOptions, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Order("display_order ASC, option_seq ASC").
+		Find(&options).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find item options by item_seq(%d)", itemSeq)
+	}
+
+	return options, nil
+}
+
+func (r *itemOptionRepository) GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.ItemOption, error) {
+	option := new(dao.ItemOption)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ? AND barcode = ?", storeSeq, barcode).
+		Take(&option).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item option by barcode(%s)", barcode)
+	}
+
+	return option, nil
+}
+
+// Update 이름, 가격, 원가, 바코드, SKU, 노출 순서를 변경한다
+func (r *itemOptionRepository) Update(ctx context.Context, option dao.ItemOption) error {
+	result := r.conn.WithContext(ctx).
+		Model(&dao.ItemOption{}).
+		Where("option_seq = ?", option.OptionSeq).
+		Updates(map[string]interface{}{
+			"name":          option.Name,
+			"price_delta":   option.PriceDelta,
+			"cost_delta":    option.CostDelta,
+			"barcode":       option.Barcode,
+			"sku":           option.SKU,
+			"display_order": option.DisplayOrder,
+			"mod_dt":        time.Now(),
+		})
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, "failed to update option_seq(%d)", option.OptionSeq)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "failed to update option_seq(%d)", option.OptionSeq)
+	}
+
+	return nil
+}
+
+func (r *itemOptionRepository) Delete(ctx context.Context, optionSeq int64) error {
+	if err := r.conn.WithContext(ctx).
+		Delete(&dao.ItemOption{}, optionSeq).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete option_seq(%d)", optionSeq)
+	}
+
+	return nil
+}
+
+func (r *itemOptionRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	items := func() *gorm.DB {
+		return r.conn.Model(&dao.Item{}).Select("item_seq")
+	}
+
+	options := r.conn.WithContext(ctx).
+		Where("item_seq NOT IN (?)", items()).
+		Delete(&dao.ItemOption{})
+	if options.Error != nil {
+		return 0, errors.Wrap(options.Error, "failed to purge item options")
+	}
+
+	groups := r.conn.WithContext(ctx).
+		Where("item_seq NOT IN (?)", items()).
+		Delete(&dao.ItemOptionGroup{})
+	if groups.Error != nil {
+		return 0, errors.Wrap(groups.Error, "failed to purge item option groups")
+	}
+
+	return options.RowsAffected + groups.RowsAffected, nil
+}
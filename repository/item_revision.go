@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+// ItemRevisionRepository 이력은 추가만 하며 수정, 삭제하지 않는다
+type ItemRevisionRepository interface {
+	Create(ctx context.Context, revision dao.ItemRevision) error
+	Find(ctx context.Context, itemSeq, lastRevisionSeq int64, limit int) (dao.ItemRevisions, error)
+	FindPriceChanges(ctx context.Context, itemSeq int64, from, to time.Time) (dao.ItemRevisions, error)
+	GetPriceAt(ctx context.Context, itemSeq int64, at time.Time) (*dao.ItemRevision, error)
+}
+
+type itemRevisionRepository struct {
+	conn *gorm.DB
+}
+
+func NewItemRevisionRepository(conn *gorm.DB) ItemRevisionRepository {
+	return &itemRevisionRepository{conn: conn}
+}
+
+func (r *itemRevisionRepository) Create(ctx context.Context, revision dao.ItemRevision) error {
+	revision.RevisionSeq = 0
+	if revision.Changes == nil {
+		revision.Changes = make(dao.ItemChanges)
+	}
+
+	if err := r.conn.WithContext(ctx).Create(&revision).Error; err != nil {
+		return errors.Wrap(err, "failed to create item revision")
+	}
+
+	return nil
+}
+
+// Find 최근 이력부터 조회한다
+func (r *itemRevisionRepository) Find(ctx context.Context, itemSeq, lastRevisionSeq int64, limit int) (dao.ItemRevisions, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	tx := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Limit(limit).
+		Order("revision_seq DESC")
+
+	if lastRevisionSeq > 0 {
+		tx = tx.Where("revision_seq < ?", lastRevisionSeq)
+	}
+
+	revisions := make(dao.ItemRevisions, 0)
+	if err := tx.Find(&revisions).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find item revisions")
+	}
+
+	return revisions, nil
+}
+
+// FindPriceChanges from 이후 to 이전에 가격이나 원가가 바뀐 이력을 오래된 순서로 조회한다
+func (r *itemRevisionRepository) FindPriceChanges(ctx context.Context, itemSeq int64, from, to time.Time) (dao.ItemRevisions, error) {
+	revisions := make(dao.ItemRevisions, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Where("price_changed = ?", true).
+		Where("reg_dt >= ?", from).
+		Where("reg_dt < ?", to).
+		Order("revision_seq ASC").
+		Find(&revisions).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find item price changes")
+	}
+
+	return revisions, nil
+}
+
+// GetPriceAt at 시점에 적용되어 있던 가격의 이력
+func (r *itemRevisionRepository) GetPriceAt(ctx context.Context, itemSeq int64, at time.Time) (*dao.ItemRevision, error) {
+	var revision dao.ItemRevision
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Where("price_changed = ?", true).
+		Where("reg_dt < ?", at).
+		Order("revision_seq DESC").
+		Take(&revision).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to take item price")
+	}
+
+	return &revision, nil
+}
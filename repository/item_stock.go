@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/repository/dao"
+)
+
+// ItemStockRepository 재고는 Add 로만 바꾸며, 변동 내역은 StockMovementRepository 에 남긴다
+type ItemStockRepository interface {
+	Create(ctx context.Context, stock dao.ItemStock) error
+	Get(ctx context.Context, itemSeq int64) (*dao.ItemStock, error)
+	FindByItems(ctx context.Context, itemSeqs []int64) (dao.ItemStocks, error)
+	FindLow(ctx context.Context, storeSeq, defaultThreshold, lastItemSeq int64, limit int) (dao.ItemStocks, error)
+	Add(ctx context.Context, itemSeq, quantity int64) error
+	UpdateThreshold(ctx context.Context, itemSeq int64, threshold *int64) error
+
+	// PurgeOrphans 영구 삭제된 상품의 재고를 삭제한다. 변동 내역은 남긴다
+	PurgeOrphans(ctx context.Context) (int64, error)
+}
+
+type itemStockRepository struct {
+	conn *gorm.DB
+}
+
+func NewItemStockRepository(conn *gorm.DB) ItemStockRepository {
+	return &itemStockRepository{conn: conn}
+}
+
+func (r *itemStockRepository) Create(ctx context.Context, stock dao.ItemStock) error {
+	if err := r.conn.WithContext(ctx).Create(&stock).Error; err != nil {
+		return errors.Wrapf(err, "failed to create item stock by item_seq(%d)", stock.ItemSeq)
+	}
+
+	return nil
+}
+
+func (r *itemStockRepository) Get(ctx context.Context, itemSeq int64) (*dao.ItemStock, error) {
+	stock := new(dao.ItemStock)
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Take(&stock).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get item stock by item_seq(%d)", itemSeq)
+	}
+
+	return stock, nil
+}
+
+// FindByItems 순서는 보장하지 않으며, 재고가 없는 상품은 빠진다
+func (r *itemStockRepository) FindByItems(ctx context.Context, itemSeqs []int64) (dao.ItemStocks, error) {
+	stocks := make(dao.ItemStocks, 0, len(itemSeqs))
+	if len(itemSeqs) == 0 {
+		return stocks, nil
+	}
+
+	if err := r.conn.WithContext(ctx).
+		Where("item_seq IN ?", itemSeqs).
+		Find(&stocks).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find item stocks")
+	}
+
+	return stocks, nil
+}
+
+// FindLow 휴지통과 판매 중지된 상품을 제외한 매장의 상품 중 재고가 기준 이하인 상품을 item_seq 순서로 조회한다
+func (r *itemStockRepository) FindLow(ctx context.Context, storeSeq, defaultThreshold, lastItemSeq int64, limit int) (dao.ItemStocks, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	items := r.conn.Model(&dao.Item{}).
+		Select("item_seq").
+		Where("store_seq = ?", storeSeq).
+		Where("deleted_dt IS NULL").
+		Where("unavailable_dt IS NULL")
+
+	stocks := make(dao.ItemStocks, 0)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("item_seq > ?", lastItemSeq).
+		Where("item_seq IN (?)", items).
+		Where("quantity <= COALESCE(low_stock_threshold, ?)", defaultThreshold).
+		Order("item_seq ASC").
+		Limit(limit).
+		Find(&stocks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find low stocks by store_seq(%d)", storeSeq)
+	}
+
+	return stocks, nil
+}
+
+// Add 재고에 quantity 를 더하며, 재고가 음수가 되면 변경하지 않고 ErrInsufficientStock 을 반환한다
+func (r *itemStockRepository) Add(ctx context.Context, itemSeq, quantity int64) error {
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.ItemStock{}).
+		Where("item_seq = ?", itemSeq).
+		Where("quantity + ? >= 0", quantity).
+		Updates(map[string]interface{}{
+			"quantity": gorm.Expr("quantity + ?", quantity),
+			"mod_dt":   time.Now(),
+		})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "failed to add item stock by item_seq(%d)", itemSeq)
+	}
+
+	if tx.RowsAffected == 0 {
+		return apierror.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// UpdateThreshold threshold 가 nil 이면 기본값을 사용하도록 지운다
+func (r *itemStockRepository) UpdateThreshold(ctx context.Context, itemSeq int64, threshold *int64) error {
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.ItemStock{}).
+		Where("item_seq = ?", itemSeq).
+		Updates(map[string]interface{}{
+			"low_stock_threshold": threshold,
+			"mod_dt":              time.Now(),
+		})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "failed to update low stock threshold by item_seq(%d)", itemSeq)
+	}
+
+	if tx.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "failed to update low stock threshold by item_seq(%d)", itemSeq)
+	}
+
+	return nil
+}
+
+func (r *itemStockRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	items := r.conn.Model(&dao.Item{}).Select("item_seq")
+
+	tx := r.conn.WithContext(ctx).
+		Where("item_seq NOT IN (?)", items).
+		Delete(&dao.ItemStock{})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "failed to purge item stocks")
+	}
+
+	return tx.RowsAffected, nil
+}
@@ -1,44 +1,63 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/pkg/errors"
-	"hello-cafe/internal/db"
+	"gorm.io/gorm"
 	"hello-cafe/repository/dao"
 )
 
 type LogoutTokenRepository interface {
-	Create(adminSeq int64, token string) error
-	GetLogoutToken(adminSeq int64, token string) (*dao.LogoutToken, error)
+	Create(ctx context.Context, adminSeq int64, tokenID string, expireDT time.Time) error
+	GetLogoutToken(ctx context.Context, tokenID string) (*dao.LogoutToken, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
 }
 
-type logoutTokenRepository struct{}
+type logoutTokenRepository struct {
+	conn *gorm.DB
+}
 
-func NewLogoutTokenRepository() LogoutTokenRepository {
-	return &logoutTokenRepository{}
+func NewLogoutTokenRepository(conn *gorm.DB) LogoutTokenRepository {
+	return &logoutTokenRepository{conn: conn}
 }
 
-func (r *logoutTokenRepository) Create(adminSeq int64, token string) error {
+func (r *logoutTokenRepository) Create(ctx context.Context, adminSeq int64, tokenID string, expireDT time.Time) error {
 	t := &dao.LogoutToken{
 		AdminSeq: adminSeq,
-		Token:    token,
+		TokenID:  tokenID,
+		ExpireDT: expireDT,
+		RegDT:    time.Now(),
 	}
 
-	if err := db.Conn().Create(&t).Error; err != nil {
+	if err := r.conn.WithContext(ctx).Create(&t).Error; err != nil {
 		return errors.Wrap(err, "failed to create logout")
 	}
 
 	return nil
 }
 
-func (r *logoutTokenRepository) GetLogoutToken(adminSeq int64, token string) (*dao.LogoutToken, error) {
+func (r *logoutTokenRepository) GetLogoutToken(ctx context.Context, tokenID string) (*dao.LogoutToken, error) {
 	t := new(dao.LogoutToken)
 
-	if err := db.Conn().
-		Where("admin_seq = ?", adminSeq).
-		Where("token = ?", token).
+	if err := r.conn.WithContext(ctx).
+		Where("token_id = ?", tokenID).
 		Take(&t).Error; err != nil {
-		return nil, errors.Wrapf(err, "failed to get logout token by admin_seq(%d) and token (%s)", adminSeq, token)
+		return nil, errors.Wrapf(err, "failed to get logout token by token_id(%s)", tokenID)
 	}
 
 	return t, nil
 }
+
+// DeleteExpired 이미 만료된 토큰은 거부 목록에 남겨둘 필요가 없으므로 삭제한다
+func (r *logoutTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tx := r.conn.WithContext(ctx).
+		Where("expire_dt < ?", now).
+		Delete(&dao.LogoutToken{})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "failed to delete expired logout tokens")
+	}
+
+	return tx.RowsAffected, nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"hello-cafe/repository/dao"
+)
+
+type adminRepository struct {
+	*data
+}
+
+func (r *adminRepository) Create(ctx context.Context, phone, password, name string) (*dao.Admin, error) {
+	admin, err := dao.NewAdmin(phone, password, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create new admin")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, a := range r.admins {
+		if a.Phone == phone {
+			return nil, duplicated("failed to create admin, phone(%s) exists", phone)
+		}
+	}
+
+	admin.AdminSeq = r.nextSeq("admin")
+	r.admins[admin.AdminSeq] = *admin
+
+	return admin, nil
+}
+
+func (r *adminRepository) Get(ctx context.Context, adminSeq int64) (*dao.Admin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	admin, ok := r.admins[adminSeq]
+	if !ok {
+		return nil, notFound("failed to get admin by admin sequence")
+	}
+
+	return &admin, nil
+}
+
+func (r *adminRepository) GetAdminByPhone(ctx context.Context, phone string) (*dao.Admin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, admin := range r.admins {
+		if admin.Phone == phone {
+			return &admin, nil
+		}
+	}
+
+	return nil, notFound("failed to get admin by phone(%s)", phone)
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type auditRepository struct {
+	*data
+}
+
+func (r *auditRepository) Create(ctx context.Context, log dao.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log.AuditSeq = r.nextSeq("audit_log")
+	r.auditLogs[log.AuditSeq] = log
+
+	return nil
+}
+
+func (r *auditRepository) Find(ctx context.Context, filter repository.AuditFilter) (dao.AuditLogs, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logs := make(dao.AuditLogs, 0)
+	for _, log := range r.auditLogs {
+		if r.match(filter, log) {
+			logs = append(logs, log)
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].AuditSeq > logs[j].AuditSeq
+	})
+
+	if len(logs) > filter.Limit {
+		logs = logs[:filter.Limit]
+	}
+
+	return logs, nil
+}
+
+func (r *auditRepository) match(filter repository.AuditFilter, log dao.AuditLog) bool {
+	if filter.StoreSeq > 0 && log.StoreSeq != filter.StoreSeq {
+		if _, ok := r.members[memberKey{storeSeq: filter.StoreSeq, adminSeq: log.AdminSeq}]; log.StoreSeq != 0 || !ok {
+			return false
+		}
+	}
+
+	switch {
+	case filter.AdminSeq > 0 && log.AdminSeq != filter.AdminSeq:
+		return false
+	case filter.Action != "" && log.Action != filter.Action:
+		return false
+	case !filter.From.IsZero() && log.RegDT.Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !log.RegDT.Before(filter.To):
+		return false
+	case filter.LastAuditSeq > 0 && log.AuditSeq >= filter.LastAuditSeq:
+		return false
+	}
+
+	return true
+}
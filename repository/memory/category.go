@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hello-cafe/repository/dao"
+)
+
+type categoryRepository struct {
+	*data
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category dao.Category) (*dao.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.categoryExists(category) {
+		return nil, duplicated("failed to create category, name(%s) exists", category.Name)
+	}
+
+	category.CategorySeq = r.nextSeq("category")
+	r.categories[category.CategorySeq] = category
+
+	return &category, nil
+}
+
+func (r *categoryRepository) Get(ctx context.Context, categorySeq int64) (*dao.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[categorySeq]
+	if !ok {
+		return nil, notFound("failed to get category by category_seq(%d)", categorySeq)
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) Find(ctx context.Context, storeSeq int64) (dao.Categories, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make(dao.Categories, 0)
+	for _, category := range r.categories {
+		if category.StoreSeq == storeSeq {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		switch {
+		case a.ParentSeq != b.ParentSeq:
+			return a.ParentSeq < b.ParentSeq
+		case a.DisplayOrder != b.DisplayOrder:
+			return a.DisplayOrder < b.DisplayOrder
+		default:
+			return a.CategorySeq < b.CategorySeq
+		}
+	})
+
+	return categories, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category dao.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.categories[category.CategorySeq]
+	if !ok {
+		return notFound("failed to update category_seq(%d)", category.CategorySeq)
+	}
+
+	c.ParentSeq, c.Name, c.DisplayOrder = category.ParentSeq, category.Name, category.DisplayOrder
+	if r.categoryExists(c) {
+		return duplicated("failed to update category_seq(%d), name(%s) exists", c.CategorySeq, c.Name)
+	}
+
+	c.ModDT = time.Now()
+	r.categories[c.CategorySeq] = c
+
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, categorySeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.categories, categorySeq)
+
+	return nil
+}
+
+// categoryExists store_seq, parent_seq, name 고유 키
+func (r *categoryRepository) categoryExists(category dao.Category) bool {
+	for _, c := range r.categories {
+		if c.CategorySeq != category.CategorySeq && c.StoreSeq == category.StoreSeq && c.ParentSeq == category.ParentSeq && c.Name == category.Name {
+			return true
+		}
+	}
+
+	return false
+}
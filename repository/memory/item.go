@@ -0,0 +1,465 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type itemRepository struct {
+	*data
+}
+
+func (r *itemRepository) Create(ctx context.Context, item request.CreateItem) error {
+	newItem, err := dao.NewItem(item)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.barcodeExists(newItem.StoreSeq, newItem.Barcode, 0) {
+		return duplicated("failed to create item, barcode(%s) exists", newItem.Barcode)
+	}
+
+	newItem.ItemSeq = r.nextSeq("item")
+	r.items[newItem.ItemSeq] = *newItem
+
+	return nil
+}
+
+func (r *itemRepository) Update(ctx context.Context, item request.UpdateItem) error {
+	if valid.IsNil(item.Version) {
+		return apierror.ErrNilItemVersion
+	}
+
+	updateItem, err := repository.NewUpdateItem(item)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.items[item.ItemSeq]
+	if !ok || i.DeletedDT != nil {
+		return errors.Wrap(notFound("failed to take item info"), "failed to get item")
+	}
+
+	if i.Version != *item.Version {
+		return apierror.ErrStaleItem
+	}
+
+	if !valid.IsNil(updateItem.Barcode) && r.barcodeExists(i.StoreSeq, *updateItem.Barcode, i.ItemSeq) {
+		return duplicated("failed to update item info, barcode(%s) exists", *updateItem.Barcode)
+	}
+
+	if !valid.IsNil(updateItem.Category) {
+		i.Category = *updateItem.Category
+	}
+
+	if !valid.IsNil(updateItem.Barcode) {
+		i.Barcode = *updateItem.Barcode
+	}
+
+	if !valid.IsNil(updateItem.Price) {
+		i.Price = *updateItem.Price
+	}
+
+	if !valid.IsNil(updateItem.Cost) {
+		i.Cost = *updateItem.Cost
+	}
+
+	if !valid.IsNil(updateItem.Name) {
+		i.Name = *updateItem.Name
+		i.Consonant = ""
+		i.SetConsonant()
+	}
+
+	if !valid.IsNil(updateItem.Description) {
+		i.Description = *updateItem.Description
+	}
+
+	now := time.Now()
+	if !valid.IsNil(updateItem.ExpireDT) {
+		i.ExpireDT = *updateItem.ExpireDT
+		if i.ExpireDT.After(now) {
+			i.UnavailableDT = nil
+		}
+	}
+
+	if !valid.IsNil(updateItem.Size) {
+		i.Size = *updateItem.Size
+	}
+
+	i.ModDT = now
+	i.Version++
+	r.items[i.ItemSeq] = i
+
+	return nil
+}
+
+func (r *itemRepository) Delete(ctx context.Context, itemSeq, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[itemSeq]
+	if !ok || item.DeletedDT != nil {
+		return errors.Wrap(notFound("failed to take item info"), "failed to get item")
+	}
+
+	if item.Version != version {
+		return apierror.ErrStaleItem
+	}
+
+	now := time.Now()
+	item.DeletedDT = &now
+	item.Version++
+	r.items[itemSeq] = item
+
+	return nil
+}
+
+func (r *itemRepository) Find(ctx context.Context, filter repository.ItemFilter) (dao.Items, error) {
+	if filter.StoreSeq < 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	// compare 정렬 방향을 반영하여 a 가 b 보다 앞이면 음수
+	compare := func(aValue any, aSeq int64, bValue any, bSeq int64) int {
+		c := compareSortValue(aValue, bValue)
+		if c == 0 {
+			c = compareSortValue(aSeq, bSeq)
+		}
+		if !filter.Asc {
+			c = -c
+		}
+		return c
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(func(item dao.Item) bool {
+		if !valid.IsNil(filter.After) && compare(repository.ItemSortValue(filter.Sort, item), item.ItemSeq, filter.After.Value, filter.After.ItemSeq) <= 0 {
+			return false
+		}
+
+		return matchItemFilter(item, filter)
+	})
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return compare(repository.ItemSortValue(filter.Sort, items[i]), items[i].ItemSeq, repository.ItemSortValue(filter.Sort, items[j]), items[j].ItemSeq) < 0
+	})
+
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+
+	return items, nil
+}
+
+func (r *itemRepository) Count(ctx context.Context, filter repository.ItemFilter) (int64, error) {
+	if filter.StoreSeq < 0 {
+		return 0, apierror.ErrInvalidStore
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(func(item dao.Item) bool {
+		return matchItemFilter(item, filter)
+	})
+
+	return int64(len(items)), nil
+}
+
+// matchItemFilter 정렬과 페이지를 제외한 ItemFilter 조건
+func matchItemFilter(item dao.Item, filter repository.ItemFilter) bool {
+	if item.StoreSeq != filter.StoreSeq || item.DeletedDT != nil {
+		return false
+	}
+
+	switch {
+	case filter.Availability == repository.ItemAvailabilityAvailable && item.UnavailableDT != nil,
+		filter.Availability == repository.ItemAvailabilityUnavailable && item.UnavailableDT == nil,
+		!valid.IsNil(filter.Categories) && !slices.Contains(filter.Categories, item.Category),
+		!valid.IsNil(filter.Size) && int(item.Size) != *filter.Size,
+		!valid.IsNil(filter.MinPrice) && item.Price < *filter.MinPrice,
+		!valid.IsNil(filter.MaxPrice) && item.Price > *filter.MaxPrice,
+		!valid.IsNil(filter.MinCost) && item.Cost < *filter.MinCost,
+		!valid.IsNil(filter.MaxCost) && item.Cost > *filter.MaxCost:
+		return false
+	}
+
+	return inPeriod(item.ExpireDT, filter.ExpireFrom, filter.ExpireTo) &&
+		inPeriod(item.RegDT, filter.RegFrom, filter.RegTo) &&
+		inPeriod(item.ModDT, filter.ModFrom, filter.ModTo)
+}
+
+// compareSortValue repository.ItemSortValue 로 얻은 같은 타입의 값을 비교한다
+func compareSortValue(a, b any) int {
+	switch v := a.(type) {
+	case int64:
+		w := b.(int64)
+		switch {
+		case v < w:
+			return -1
+		case v > w:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(v, b.(string))
+	case time.Time:
+		return v.Compare(b.(time.Time))
+	default:
+		return 0
+	}
+}
+
+func inPeriod(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
+
+func (r *itemRepository) Get(ctx context.Context, itemSeq int64) (*dao.Item, error) {
+	if itemSeq < 0 {
+		return nil, apierror.ErrInvalidItem
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[itemSeq]
+	if !ok || item.DeletedDT != nil {
+		return nil, notFound("failed to take item info")
+	}
+
+	return &item, nil
+}
+
+func (r *itemRepository) GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, item := range r.items {
+		if item.StoreSeq == storeSeq && item.Barcode == barcode && item.DeletedDT == nil {
+			return &item, nil
+		}
+	}
+
+	return nil, notFound("failed to take item info")
+}
+
+func (r *itemRepository) FindAll(ctx context.Context, storeSeq int64) (dao.Items, error) {
+	if storeSeq < 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.filter(func(item dao.Item) bool {
+		return item.StoreSeq == storeSeq && item.DeletedDT == nil && item.UnavailableDT == nil
+	}), nil
+}
+
+func (r *itemRepository) FindBySeqs(ctx context.Context, storeSeq int64, itemSeqs []int64) (dao.Items, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make(dao.Items, 0, len(itemSeqs))
+	for _, itemSeq := range itemSeqs {
+		if item, ok := r.items[itemSeq]; ok && item.StoreSeq == storeSeq && item.DeletedDT == nil {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (r *itemRepository) Scan(ctx context.Context, afterItemSeq int64, limit int) (dao.Items, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(func(item dao.Item) bool {
+		return item.ItemSeq > afterItemSeq && item.DeletedDT == nil && item.UnavailableDT == nil
+	})
+
+	// filter 는 역순이므로 뒤에서부터 limit 개를 item_seq 순서로 담는다
+	scanned := make(dao.Items, 0, limit)
+	for i := len(items) - 1; i >= 0 && len(scanned) < limit; i-- {
+		scanned = append(scanned, items[i])
+	}
+
+	return scanned, nil
+}
+
+func (r *itemRepository) CountByCategory(ctx context.Context, categorySeq int64) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, item := range r.items {
+		if item.Category == categorySeq {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *itemRepository) FindExpired(ctx context.Context, expiredBefore time.Time, limit int) (dao.Items, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(func(item dao.Item) bool {
+		return item.ExpireDT.Before(expiredBefore) && item.UnavailableDT == nil && item.DeletedDT == nil
+	})
+
+	// filter 는 역순이므로 뒤에서부터 limit 개를 item_seq 순서로 담는다
+	expired := make(dao.Items, 0, limit)
+	for i := len(items) - 1; i >= 0 && len(expired) < limit; i-- {
+		expired = append(expired, items[i])
+	}
+
+	return expired, nil
+}
+
+func (r *itemRepository) MarkUnavailable(ctx context.Context, itemSeq int64, expiredBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[itemSeq]
+	if !ok || !item.ExpireDT.Before(expiredBefore) || item.UnavailableDT != nil || item.DeletedDT != nil {
+		return apierror.ErrStaleItem
+	}
+
+	now := time.Now()
+	item.UnavailableDT = &now
+	item.ModDT = now
+	item.Version++
+	r.items[itemSeq] = item
+
+	return nil
+}
+
+func (r *itemRepository) FindDeleted(ctx context.Context, storeSeq, lastItemSeq int64, limit int) (dao.Items, error) {
+	if storeSeq < 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(func(item dao.Item) bool {
+		return item.StoreSeq == storeSeq && item.DeletedDT != nil && (lastItemSeq <= 0 || item.ItemSeq < lastItemSeq)
+	})
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, nil
+}
+
+func (r *itemRepository) GetDeleted(ctx context.Context, itemSeq int64) (*dao.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[itemSeq]
+	if !ok || item.DeletedDT == nil {
+		return nil, notFound("failed to take deleted item info")
+	}
+
+	return &item, nil
+}
+
+func (r *itemRepository) GetDeletedByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, item := range r.items {
+		if item.StoreSeq == storeSeq && item.Barcode == barcode && item.DeletedDT != nil {
+			return &item, nil
+		}
+	}
+
+	return nil, notFound("failed to take deleted item info")
+}
+
+func (r *itemRepository) Restore(ctx context.Context, itemSeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[itemSeq]
+	if !ok || item.DeletedDT == nil {
+		return errors.Wrap(notFound("failed to take deleted item info"), "failed to get deleted item")
+	}
+
+	item.DeletedDT = nil
+	item.ModDT = time.Now()
+	item.Version++
+	r.items[itemSeq] = item
+
+	return nil
+}
+
+func (r *itemRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for seq, item := range r.items {
+		if item.DeletedDT != nil && item.DeletedDT.Before(deletedBefore) {
+			delete(r.items, seq)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// filter 조건에 맞는 상품을 item_seq 역순으로 반환한다
+func (r *itemRepository) filter(match func(item dao.Item) bool) dao.Items {
+	items := make(dao.Items, 0)
+	for _, item := range r.items {
+		if match(item) {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ItemSeq > items[j].ItemSeq
+	})
+
+	return items
+}
+
+func (r *itemRepository) barcodeExists(storeSeq int64, barcode string, exceptItemSeq int64) bool {
+	for _, item := range r.items {
+		if item.StoreSeq == storeSeq && item.Barcode == barcode && item.ItemSeq != exceptItemSeq {
+			return true
+		}
+	}
+
+	return false
+}
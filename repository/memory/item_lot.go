@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/repository/dao"
+)
+
+type itemLotRepository struct {
+	*data
+}
+
+func (r *itemLotRepository) Create(ctx context.Context, lot dao.ItemLot) (*dao.ItemLot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, l := range r.lots {
+		if l.ItemSeq == lot.ItemSeq && l.LotNumber == lot.LotNumber {
+			return nil, duplicated("failed to create item lot, lot_number(%s) exists", lot.LotNumber)
+		}
+	}
+
+	lot.LotSeq = r.nextSeq("item_lot")
+	r.lots[lot.LotSeq] = lot
+
+	return &lot, nil
+}
+
+func (r *itemLotRepository) Get(ctx context.Context, lotSeq int64) (*dao.ItemLot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lot, ok := r.lots[lotSeq]
+	if !ok {
+		return nil, notFound("failed to get item lot by lot_seq(%d)", lotSeq)
+	}
+
+	return &lot, nil
+}
+
+func (r *itemLotRepository) GetByNumber(ctx context.Context, itemSeq int64, lotNumber string) (*dao.ItemLot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, lot := range r.lots {
+		if lot.ItemSeq == itemSeq && lot.LotNumber == lotNumber {
+			return &lot, nil
+		}
+	}
+
+	return nil, notFound("failed to get item lot by lot_number(%s)", lotNumber)
+}
+
+func (r *itemLotRepository) FindRemaining(ctx context.Context, itemSeq int64) (dao.ItemLots, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.filter(func(lot dao.ItemLot) bool {
+		return lot.ItemSeq == itemSeq && lot.Remaining > 0
+	}), nil
+}
+
+func (r *itemLotRepository) FindExpiring(ctx context.Context, storeSeq int64, before time.Time) (dao.ItemLots, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.filter(func(lot dao.ItemLot) bool {
+		item, ok := r.items[lot.ItemSeq]
+		return ok && item.DeletedDT == nil &&
+			lot.StoreSeq == storeSeq && lot.Remaining > 0 && lot.ExpireDT.Before(before)
+	}), nil
+}
+
+func (r *itemLotRepository) Add(ctx context.Context, lotSeq, quantity int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lot, ok := r.lots[lotSeq]
+	if !ok || lot.Remaining+quantity < 0 {
+		return apierror.ErrInsufficientLot
+	}
+
+	lot.Quantity += max(quantity, 0)
+	lot.Remaining += quantity
+	lot.ModDT = time.Now()
+	r.lots[lotSeq] = lot
+
+	return nil
+}
+
+func (r *itemLotRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for lotSeq, lot := range r.lots {
+		if _, ok := r.items[lot.ItemSeq]; !ok {
+			delete(r.lots, lotSeq)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// filter 유통기한이 빠른 순서로 정렬한다
+func (r *itemLotRepository) filter(fn func(lot dao.ItemLot) bool) dao.ItemLots {
+	lots := make(dao.ItemLots, 0)
+	for _, lot := range r.lots {
+		if fn(lot) {
+			lots = append(lots, lot)
+		}
+	}
+
+	sort.Slice(lots, func(i, j int) bool {
+		if !lots[i].ExpireDT.Equal(lots[j].ExpireDT) {
+			return lots[i].ExpireDT.Before(lots[j].ExpireDT)
+		}
+		return lots[i].LotSeq < lots[j].LotSeq
+	})
+
+	return lots
+}
@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hello-cafe/repository/dao"
+)
+
+type itemOptionRepository struct {
+	*data
+}
+
+func (r *itemOptionRepository) CreateGroup(ctx context.Context, group dao.ItemOptionGroup) (*dao.ItemOptionGroup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.groupExists(group) {
+		return nil, duplicated("failed to create item option group, name(%s) exists", group.Name)
+	}
+
+	group.OptionGroupSeq = r.nextSeq("item_option_group")
+	r.optionGroups[group.OptionGroupSeq] = group
+
+	return &group, nil
+}
+
+func (r *itemOptionRepository) GetGroup(ctx context.Context, optionGroupSeq int64) (*dao.ItemOptionGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	group, ok := r.optionGroups[optionGroupSeq]
+	if !ok {
+		return nil, notFound("failed to get item option group by option_group_seq(%d)", optionGroupSeq)
+	}
+
+	return &group, nil
+}
+
+func (r *itemOptionRepository) FindGroups(ctx context.Context, itemSeq int64) (dao.ItemOptionGroups, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	groups := make(dao.ItemOptionGroups, 0)
+	for _, group := range r.optionGroups {
+		if group.ItemSeq == itemSeq {
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].DisplayOrder != groups[j].DisplayOrder {
+			return groups[i].DisplayOrder < groups[j].DisplayOrder
+		}
+		return groups[i].OptionGroupSeq < groups[j].OptionGroupSeq
+	})
+
+	return groups, nil
+}
+
+func (r *itemOptionRepository) UpdateGroup(ctx context.Context, group dao.ItemOptionGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	g, ok := r.optionGroups[group.OptionGroupSeq]
+	if !ok {
+		return notFound("failed to update option_group_seq(%d)", group.OptionGroupSeq)
+	}
+
+	g.Name, g.MinSelect, g.MaxSelect, g.DisplayOrder = group.Name, group.MinSelect, group.MaxSelect, group.DisplayOrder
+	if r.groupExists(g) {
+		return duplicated("failed to update option_group_seq(%d), name(%s) exists", g.OptionGroupSeq, g.Name)
+	}
+
+	g.ModDT = time.Now()
+	r.optionGroups[g.OptionGroupSeq] = g
+
+	return nil
+}
+
+func (r *itemOptionRepository) DeleteGroup(ctx context.Context, optionGroupSeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for seq, option := range r.options {
+		if option.OptionGroupSeq == optionGroupSeq {
+			delete(r.options, seq)
+		}
+	}
+	delete(r.optionGroups, optionGroupSeq)
+
+	return nil
+}
+
+func (r *itemOptionRepository) Create(ctx context.Context, option dao.ItemOption) (*dao.ItemOption, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.optionExists(option) {
+		return nil, duplicated("failed to create item option, name(%s), barcode or sku exists", option.Name)
+	}
+
+	option.OptionSeq = r.nextSeq("item_option")
+	r.options[option.OptionSeq] = option
+
+	return &option, nil
+}
+
+func (r *itemOptionRepository) Get(ctx context.Context, optionSeq int64) (*dao.ItemOption, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	option, ok := r.options[optionSeq]
+	if !ok {
+		return nil, notFound("failed to get item option by option_seq(%d)", optionSeq)
+	}
+
+	return &option, nil
+}
+
+func (r *itemOptionRepository) Find(ctx context.Context, itemSeq int64) (dao.ItemOptions, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	options := make(dao.ItemOptions, 0)
+	for _, option := range r.options {
+		if option.ItemSeq == itemSeq {
+			options = append(options, option)
+		}
+	}
+
+	sort.Slice(options, func(i, j int) bool {
+		if options[i].DisplayOrder != options[j].DisplayOrder {
+			return options[i].DisplayOrder < options[j].DisplayOrder
+		}
+		return options[i].OptionSeq < options[j].OptionSeq
+	})
+
+	return options, nil
+}
+
+func (r *itemOptionRepository) GetByBarcode(ctx context.Context, storeSeq int64, barcode string) (*dao.ItemOption, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, option := range r.options {
+		if option.StoreSeq == storeSeq && option.Barcode != nil && *option.Barcode == barcode {
+			return &option, nil
+		}
+	}
+
+	return nil, notFound("failed to get item option by barcode(%s)", barcode)
+}
+
+func (r *itemOptionRepository) Update(ctx context.Context, option dao.ItemOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.options[option.OptionSeq]
+	if !ok {
+		return notFound("failed to update option_seq(%d)", option.OptionSeq)
+	}
+
+	o.Name, o.PriceDelta, o.CostDelta = option.Name, option.PriceDelta, option.CostDelta
+	o.Barcode, o.SKU, o.DisplayOrder = option.Barcode, option.SKU, option.DisplayOrder
+	if r.optionExists(o) {
+		return duplicated("failed to update option_seq(%d), name(%s), barcode or sku exists", o.OptionSeq, o.Name)
+	}
+
+	o.ModDT = time.Now()
+	r.options[o.OptionSeq] = o
+
+	return nil
+}
+
+func (r *itemOptionRepository) Delete(ctx context.Context, optionSeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.options, optionSeq)
+
+	return nil
+}
+
+func (r *itemOptionRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for seq, option := range r.options {
+		if _, ok := r.items[option.ItemSeq]; !ok {
+			delete(r.options, seq)
+			purged++
+		}
+	}
+
+	for seq, group := range r.optionGroups {
+		if _, ok := r.items[group.ItemSeq]; !ok {
+			delete(r.optionGroups, seq)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// groupExists item_seq, name 고유 키
+func (r *itemOptionRepository) groupExists(group dao.ItemOptionGroup) bool {
+	for _, g := range r.optionGroups {
+		if g.OptionGroupSeq != group.OptionGroupSeq && g.ItemSeq == group.ItemSeq && g.Name == group.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// optionExists option_group_seq, name 과 store_seq, barcode 와 store_seq, sku 고유 키
+func (r *itemOptionRepository) optionExists(option dao.ItemOption) bool {
+	for _, o := range r.options {
+		if o.OptionSeq == option.OptionSeq {
+			continue
+		}
+
+		switch {
+		case o.OptionGroupSeq == option.OptionGroupSeq && o.Name == option.Name,
+			o.StoreSeq == option.StoreSeq && sameKey(o.Barcode, option.Barcode),
+			o.StoreSeq == option.StoreSeq && sameKey(o.SKU, option.SKU):
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameKey NULL 은 고유 키 비교에서 제외된다
+func sameKey(a, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
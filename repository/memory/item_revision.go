@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hello-cafe/repository/dao"
+)
+
+type itemRevisionRepository struct {
+	*data
+}
+
+func (r *itemRevisionRepository) Create(ctx context.Context, revision dao.ItemRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revision.RevisionSeq = r.nextSeq("item_revision")
+	if revision.Changes == nil {
+		revision.Changes = make(dao.ItemChanges)
+	}
+	r.itemRevisions[revision.RevisionSeq] = revision
+
+	return nil
+}
+
+func (r *itemRevisionRepository) Find(ctx context.Context, itemSeq, lastRevisionSeq int64, limit int) (dao.ItemRevisions, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.filter(func(revision dao.ItemRevision) bool {
+		return revision.ItemSeq == itemSeq && (lastRevisionSeq <= 0 || revision.RevisionSeq < lastRevisionSeq)
+	})
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].RevisionSeq > revisions[j].RevisionSeq
+	})
+
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+	}
+
+	return revisions, nil
+}
+
+func (r *itemRevisionRepository) FindPriceChanges(ctx context.Context, itemSeq int64, from, to time.Time) (dao.ItemRevisions, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.filter(func(revision dao.ItemRevision) bool {
+		return revision.ItemSeq == itemSeq && revision.PriceChanged &&
+			!revision.RegDT.Before(from) && revision.RegDT.Before(to)
+	})
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].RevisionSeq < revisions[j].RevisionSeq
+	})
+
+	return revisions, nil
+}
+
+func (r *itemRevisionRepository) GetPriceAt(ctx context.Context, itemSeq int64, at time.Time) (*dao.ItemRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result *dao.ItemRevision
+	for _, revision := range r.itemRevisions {
+		if revision.ItemSeq != itemSeq || !revision.PriceChanged || !revision.RegDT.Before(at) {
+			continue
+		}
+
+		if result == nil || revision.RevisionSeq > result.RevisionSeq {
+			revision := revision
+			result = &revision
+		}
+	}
+
+	if result == nil {
+		return nil, notFound("failed to take item price")
+	}
+
+	return result, nil
+}
+
+func (r *itemRevisionRepository) filter(fn func(revision dao.ItemRevision) bool) dao.ItemRevisions {
+	revisions := make(dao.ItemRevisions, 0)
+	for _, revision := range r.itemRevisions {
+		if fn(revision) {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	return revisions
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hello-cafe/internal/apierror"
+	"hello-cafe/repository/dao"
+)
+
+type itemStockRepository struct {
+	*data
+}
+
+func (r *itemStockRepository) Create(ctx context.Context, stock dao.ItemStock) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.stocks[stock.ItemSeq]; ok {
+		return duplicated("failed to create item stock, item_seq(%d) exists", stock.ItemSeq)
+	}
+	r.stocks[stock.ItemSeq] = stock
+
+	return nil
+}
+
+func (r *itemStockRepository) Get(ctx context.Context, itemSeq int64) (*dao.ItemStock, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stock, ok := r.stocks[itemSeq]
+	if !ok {
+		return nil, notFound("failed to get item stock by item_seq(%d)", itemSeq)
+	}
+
+	return &stock, nil
+}
+
+func (r *itemStockRepository) FindByItems(ctx context.Context, itemSeqs []int64) (dao.ItemStocks, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stocks := make(dao.ItemStocks, 0, len(itemSeqs))
+	for _, itemSeq := range itemSeqs {
+		if stock, ok := r.stocks[itemSeq]; ok {
+			stocks = append(stocks, stock)
+		}
+	}
+
+	return stocks, nil
+}
+
+func (r *itemStockRepository) FindLow(ctx context.Context, storeSeq, defaultThreshold, lastItemSeq int64, limit int) (dao.ItemStocks, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stocks := make(dao.ItemStocks, 0)
+	for _, stock := range r.stocks {
+		item, ok := r.items[stock.ItemSeq]
+		if !ok || item.DeletedDT != nil || item.UnavailableDT != nil || stock.StoreSeq != storeSeq || stock.ItemSeq <= lastItemSeq {
+			continue
+		}
+
+		if stock.LowStock(defaultThreshold) {
+			stocks = append(stocks, stock)
+		}
+	}
+
+	sort.Slice(stocks, func(i, j int) bool {
+		return stocks[i].ItemSeq < stocks[j].ItemSeq
+	})
+
+	if len(stocks) > limit {
+		stocks = stocks[:limit]
+	}
+
+	return stocks, nil
+}
+
+func (r *itemStockRepository) Add(ctx context.Context, itemSeq, quantity int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stock, ok := r.stocks[itemSeq]
+	if !ok || stock.Quantity+quantity < 0 {
+		return apierror.ErrInsufficientStock
+	}
+
+	stock.Quantity += quantity
+	stock.ModDT = time.Now()
+	r.stocks[itemSeq] = stock
+
+	return nil
+}
+
+func (r *itemStockRepository) UpdateThreshold(ctx context.Context, itemSeq int64, threshold *int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stock, ok := r.stocks[itemSeq]
+	if !ok {
+		return notFound("failed to update low stock threshold by item_seq(%d)", itemSeq)
+	}
+
+	stock.LowStockThreshold = threshold
+	stock.ModDT = time.Now()
+	r.stocks[itemSeq] = stock
+
+	return nil
+}
+
+func (r *itemStockRepository) PurgeOrphans(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for itemSeq := range r.stocks {
+		if _, ok := r.items[itemSeq]; !ok {
+			delete(r.stocks, itemSeq)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
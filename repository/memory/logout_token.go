@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"hello-cafe/repository/dao"
+)
+
+type logoutTokenRepository struct {
+	*data
+}
+
+func (r *logoutTokenRepository) Create(ctx context.Context, adminSeq int64, tokenID string, expireDT time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.logoutTokens {
+		if t.TokenID == tokenID {
+			return duplicated("failed to create logout, token_id(%s) exists", tokenID)
+		}
+	}
+
+	t := dao.LogoutToken{
+		TokenSeq: r.nextSeq("logout_token"),
+		AdminSeq: adminSeq,
+		TokenID:  tokenID,
+		ExpireDT: expireDT,
+		RegDT:    time.Now(),
+	}
+	r.logoutTokens[t.TokenSeq] = t
+
+	return nil
+}
+
+func (r *logoutTokenRepository) GetLogoutToken(ctx context.Context, tokenID string) (*dao.LogoutToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.logoutTokens {
+		if t.TokenID == tokenID {
+			return &t, nil
+		}
+	}
+
+	return nil, notFound("failed to get logout token by token_id(%s)", tokenID)
+}
+
+func (r *logoutTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for seq, t := range r.logoutTokens {
+		if t.ExpireDT.Before(now) {
+			delete(r.logoutTokens, seq)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
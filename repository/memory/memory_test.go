@@ -0,0 +1,100 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/memory"
+	"hello-cafe/repository/repositorytest"
+)
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		return memory.NewRepository()
+	})
+}
+
+// TestRepository_WithTx_concurrent transaction 밖에서 동시에 변경한 내용은 rollback 되지 않아야 한다
+func TestRepository_WithTx_concurrent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		txErr   error
+		wantTx  bool
+		wantOut bool
+	}{
+		{
+			name:    "commit",
+			txErr:   nil,
+			wantTx:  true,
+			wantOut: true,
+		},
+		{
+			name:    "rollback",
+			txErr:   errors.New("rollback"),
+			wantTx:  false,
+			wantOut: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+
+			done := make(chan error, 1)
+			err := repo.WithTx(ctx, func(tx repository.Repository) error {
+				if err := tx.Item().Create(ctx, newItem("tx")); err != nil {
+					return err
+				}
+
+				go func() {
+					done <- repo.Item().Create(ctx, newItem("outside"))
+				}()
+
+				// transaction 밖의 변경이 먼저 실행될 수 있는 시간을 준다
+				time.Sleep(10 * time.Millisecond)
+
+				return tt.txErr
+			})
+			require.True(t, errors.Is(err, tt.txErr), "WithTx() error = %v", err)
+			require.NoError(t, <-done)
+
+			for barcode, want := range map[string]bool{"tx": tt.wantTx, "outside": tt.wantOut} {
+				_, err := repo.Item().GetByBarcode(ctx, 1, barcode)
+				if want {
+					require.NoError(t, err, barcode)
+				} else {
+					require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "%s: %v", barcode, err)
+				}
+			}
+		})
+	}
+}
+
+func newItem(barcode string) request.CreateItem {
+	category := int64(1)
+	size := request.ItemSize(0)
+	price := int64(4500)
+	cost := int64(1500)
+	name := barcode
+	description := "설명"
+	expireDT := time.Now().Add(24 * time.Hour)
+
+	return request.CreateItem{
+		StoreSeq:    1,
+		Category:    &category,
+		Barcode:     &barcode,
+		Price:       &price,
+		Cost:        &cost,
+		Name:        &name,
+		Description: &description,
+		ExpireDT:    &expireDT,
+		Size:        &size,
+	}
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"hello-cafe/repository/dao"
+)
+
+type refreshTokenRepository struct {
+	*data
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, adminSeq int64, familyID, tokenHash string, expireDT time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return duplicated("failed to create refresh token, hash exists")
+		}
+	}
+
+	t := dao.RefreshToken{
+		TokenSeq:  r.nextSeq("refresh_token"),
+		AdminSeq:  adminSeq,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpireDT:  expireDT,
+		RegDT:     time.Now(),
+	}
+	r.refreshTokens[t.TokenSeq] = t
+
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*dao.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+
+	return nil, notFound("failed to get refresh token by hash")
+}
+
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, tokenSeq int64, usedDT time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.refreshTokens[tokenSeq]
+	if !ok || t.UsedDT != nil {
+		return false, nil
+	}
+
+	t.UsedDT = &usedDT
+	r.refreshTokens[tokenSeq] = t
+
+	return true, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedDT time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for seq, t := range r.refreshTokens {
+		if t.FamilyID == familyID && t.RevokedDT == nil {
+			t.RevokedDT = &revokedDT
+			r.refreshTokens[seq] = t
+		}
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.refreshTokens {
+		if t.FamilyID == familyID && t.RevokedDT != nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *refreshTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for seq, t := range r.refreshTokens {
+		if t.ExpireDT.Before(now) {
+			delete(r.refreshTokens, seq)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
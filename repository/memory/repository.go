@@ -0,0 +1,238 @@
+// Package memory repository.Repository 를 메모리에 구현한 것으로, DB 없이 service 를 테스트할 때 사용한다.
+// 고유 키 중복은 gorm.ErrDuplicatedKey, 조회 결과 없음은 gorm.ErrRecordNotFound 로 SQL 구현과 같은 오류를 반환한다.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type memberKey struct {
+	storeSeq int64
+	adminSeq int64
+}
+
+// data 모든 repository 가 공유하는 테이블
+type data struct {
+	mu sync.RWMutex
+
+	admins        map[int64]dao.Admin
+	stores        map[int64]dao.Store
+	members       map[memberKey]dao.StoreMember
+	categories    map[int64]dao.Category
+	items         map[int64]dao.Item
+	optionGroups  map[int64]dao.ItemOptionGroup
+	options       map[int64]dao.ItemOption
+	itemRevisions map[int64]dao.ItemRevision
+	stocks        map[int64]dao.ItemStock
+	lots          map[int64]dao.ItemLot
+	movements     map[int64]dao.StockMovement
+	logoutTokens  map[int64]dao.LogoutToken
+	refreshTokens map[int64]dao.RefreshToken
+	auditLogs     map[int64]dao.AuditLog
+
+	// sequences 테이블별 auto increment
+	sequences map[string]int64
+}
+
+func (d *data) nextSeq(table string) int64 {
+	d.sequences[table]++
+	return d.sequences[table]
+}
+
+// clone transaction 에서 변경할 테이블 복사본을 만든다. 호출하는 쪽에서 lock 을 잡고 있어야 한다.
+func (d *data) clone() *data {
+	return &data{
+		admins:        copyMap(d.admins),
+		stores:        copyMap(d.stores),
+		members:       copyMap(d.members),
+		categories:    copyMap(d.categories),
+		items:         copyMap(d.items),
+		optionGroups:  copyMap(d.optionGroups),
+		options:       copyMap(d.options),
+		itemRevisions: copyMap(d.itemRevisions),
+		stocks:        copyMap(d.stocks),
+		lots:          copyMap(d.lots),
+		movements:     copyMap(d.movements),
+		logoutTokens:  copyMap(d.logoutTokens),
+		refreshTokens: copyMap(d.refreshTokens),
+		auditLogs:     copyMap(d.auditLogs),
+		sequences:     copyMap(d.sequences),
+	}
+}
+
+// commit transaction 에서 변경한 테이블로 교체한다. 호출하는 쪽에서 lock 을 잡고 있어야 한다.
+func (d *data) commit(tx *data) {
+	d.admins = tx.admins
+	d.stores = tx.stores
+	d.members = tx.members
+	d.categories = tx.categories
+	d.items = tx.items
+	d.optionGroups = tx.optionGroups
+	d.options = tx.options
+	d.itemRevisions = tx.itemRevisions
+	d.stocks = tx.stocks
+	d.lots = tx.lots
+	d.movements = tx.movements
+	d.logoutTokens = tx.logoutTokens
+	d.refreshTokens = tx.refreshTokens
+	d.auditLogs = tx.auditLogs
+	d.sequences = tx.sequences
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	result := make(map[K]V, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
+
+type memoryRepository struct {
+	data *data
+	inTx bool
+
+	admin       repository.AdminRepository
+	store       repository.StoreRepository
+	storeMember repository.StoreMemberRepository
+	category    repository.CategoryRepository
+	item        repository.ItemRepository
+	itemOption  repository.ItemOptionRepository
+	itemRev     repository.ItemRevisionRepository
+	itemStock   repository.ItemStockRepository
+	itemLot     repository.ItemLotRepository
+	movement    repository.StockMovementRepository
+	logout      repository.LogoutTokenRepository
+	refresh     repository.RefreshTokenRepository
+	audit       repository.AuditRepository
+}
+
+func NewRepository() repository.Repository {
+	d := &data{
+		admins:        make(map[int64]dao.Admin),
+		stores:        make(map[int64]dao.Store),
+		members:       make(map[memberKey]dao.StoreMember),
+		categories:    make(map[int64]dao.Category),
+		items:         make(map[int64]dao.Item),
+		optionGroups:  make(map[int64]dao.ItemOptionGroup),
+		options:       make(map[int64]dao.ItemOption),
+		itemRevisions: make(map[int64]dao.ItemRevision),
+		stocks:        make(map[int64]dao.ItemStock),
+		lots:          make(map[int64]dao.ItemLot),
+		movements:     make(map[int64]dao.StockMovement),
+		logoutTokens:  make(map[int64]dao.LogoutToken),
+		refreshTokens: make(map[int64]dao.RefreshToken),
+		auditLogs:     make(map[int64]dao.AuditLog),
+		sequences:     make(map[string]int64),
+	}
+
+	return newRepository(d)
+}
+
+func newRepository(d *data) *memoryRepository {
+	return &memoryRepository{
+		data:        d,
+		admin:       &adminRepository{data: d},
+		store:       &storeRepository{data: d},
+		storeMember: &storeMemberRepository{data: d},
+		category:    &categoryRepository{data: d},
+		item:        &itemRepository{data: d},
+		itemOption:  &itemOptionRepository{data: d},
+		itemRev:     &itemRevisionRepository{data: d},
+		itemStock:   &itemStockRepository{data: d},
+		itemLot:     &itemLotRepository{data: d},
+		movement:    &stockMovementRepository{data: d},
+		logout:      &logoutTokenRepository{data: d},
+		refresh:     &refreshTokenRepository{data: d},
+		audit:       &auditRepository{data: d},
+	}
+}
+
+// WithTx fn 은 테이블 복사본에서 실행하고, 성공하면 복사본으로 교체한다.
+// transaction 이 끝날 때까지 write lock 을 잡고 있으므로 다른 변경과 조회는 commit 또는 rollback 이후에 실행된다.
+func (r *memoryRepository) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	view := r.data.clone()
+
+	tx := newRepository(view)
+	tx.inTx = true
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	r.data.commit(view)
+
+	return nil
+}
+
+func (r *memoryRepository) Admin() repository.AdminRepository {
+	return r.admin
+}
+
+func (r *memoryRepository) Store() repository.StoreRepository {
+	return r.store
+}
+
+func (r *memoryRepository) StoreMember() repository.StoreMemberRepository {
+	return r.storeMember
+}
+
+func (r *memoryRepository) Category() repository.CategoryRepository {
+	return r.category
+}
+
+func (r *memoryRepository) Item() repository.ItemRepository {
+	return r.item
+}
+
+func (r *memoryRepository) ItemOption() repository.ItemOptionRepository {
+	return r.itemOption
+}
+
+func (r *memoryRepository) ItemRevision() repository.ItemRevisionRepository {
+	return r.itemRev
+}
+
+func (r *memoryRepository) ItemStock() repository.ItemStockRepository {
+	return r.itemStock
+}
+
+func (r *memoryRepository) ItemLot() repository.ItemLotRepository {
+	return r.itemLot
+}
+
+func (r *memoryRepository) StockMovement() repository.StockMovementRepository {
+	return r.movement
+}
+
+func (r *memoryRepository) Logout() repository.LogoutTokenRepository {
+	return r.logout
+}
+
+func (r *memoryRepository) Refresh() repository.RefreshTokenRepository {
+	return r.refresh
+}
+
+func (r *memoryRepository) Audit() repository.AuditRepository {
+	return r.audit
+}
+
+func notFound(format string, args ...interface{}) error {
+	return errors.Wrapf(gorm.ErrRecordNotFound, format, args...)
+}
+
+func duplicated(format string, args ...interface{}) error {
+	return errors.Wrapf(gorm.ErrDuplicatedKey, format, args...)
+}
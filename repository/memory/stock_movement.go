@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"hello-cafe/repository/dao"
+)
+
+type stockMovementRepository struct {
+	*data
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement dao.StockMovement) (*dao.StockMovement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movement.MovementSeq = r.nextSeq("stock_movement")
+	r.movements[movement.MovementSeq] = movement
+
+	return &movement, nil
+}
+
+func (r *stockMovementRepository) Find(ctx context.Context, itemSeq, lastMovementSeq int64, limit int) (dao.StockMovements, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movements := make(dao.StockMovements, 0)
+	for _, movement := range r.movements {
+		if movement.ItemSeq == itemSeq && (lastMovementSeq <= 0 || movement.MovementSeq < lastMovementSeq) {
+			movements = append(movements, movement)
+		}
+	}
+
+	sort.Slice(movements, func(i, j int) bool {
+		return movements[i].MovementSeq > movements[j].MovementSeq
+	})
+
+	if len(movements) > limit {
+		movements = movements[:limit]
+	}
+
+	return movements, nil
+}
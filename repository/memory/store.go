@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"hello-cafe/repository/dao"
+)
+
+type storeRepository struct {
+	*data
+}
+
+func (r *storeRepository) Create(ctx context.Context, name string) (*dao.Store, error) {
+	store, err := dao.NewStore(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create new store")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	store.StoreSeq = r.nextSeq("store")
+	r.stores[store.StoreSeq] = *store
+
+	return store, nil
+}
+
+func (r *storeRepository) Get(ctx context.Context, storeSeq int64) (*dao.Store, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	store, ok := r.stores[storeSeq]
+	if !ok {
+		return nil, notFound("failed to get store by store_seq(%d)", storeSeq)
+	}
+
+	return &store, nil
+}
+
+func (r *storeRepository) FindByAdmin(ctx context.Context, adminSeq int64) ([]dao.StoreInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stores := make([]dao.StoreInfo, 0)
+	for key, member := range r.members {
+		if key.adminSeq != adminSeq {
+			continue
+		}
+
+		store, ok := r.stores[key.storeSeq]
+		if !ok {
+			continue
+		}
+
+		stores = append(stores, dao.StoreInfo{
+			StoreSeq: store.StoreSeq,
+			Name:     store.Name,
+			Role:     member.Role,
+			RegDT:    member.RegDT,
+		})
+	}
+
+	sort.Slice(stores, func(i, j int) bool {
+		if !stores[i].RegDT.Equal(stores[j].RegDT) {
+			return stores[i].RegDT.Before(stores[j].RegDT)
+		}
+		return stores[i].StoreSeq < stores[j].StoreSeq
+	})
+
+	return stores, nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/model"
+	"hello-cafe/repository/dao"
+)
+
+type storeMemberRepository struct {
+	*data
+}
+
+func (r *storeMemberRepository) Create(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error {
+	if err := role.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{storeSeq: storeSeq, adminSeq: adminSeq}
+	if _, ok := r.members[key]; ok {
+		return duplicated("failed to create store member, admin_seq(%d) is in store_seq(%d)", adminSeq, storeSeq)
+	}
+
+	r.members[key] = dao.StoreMember{
+		StoreSeq: storeSeq,
+		AdminSeq: adminSeq,
+		Role:     role,
+		RegDT:    time.Now(),
+	}
+
+	return nil
+}
+
+func (r *storeMemberRepository) Get(ctx context.Context, storeSeq, adminSeq int64) (*dao.StoreMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[memberKey{storeSeq: storeSeq, adminSeq: adminSeq}]
+	if !ok {
+		return nil, notFound("failed to get store member by store_seq(%d) and admin_seq(%d)", storeSeq, adminSeq)
+	}
+
+	return &member, nil
+}
+
+func (r *storeMemberRepository) Find(ctx context.Context, storeSeq int64) ([]dao.MemberInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := make([]dao.MemberInfo, 0)
+	for key, member := range r.members {
+		if key.storeSeq != storeSeq {
+			continue
+		}
+
+		admin, ok := r.admins[key.adminSeq]
+		if !ok {
+			continue
+		}
+
+		members = append(members, dao.MemberInfo{
+			StoreSeq: member.StoreSeq,
+			AdminSeq: member.AdminSeq,
+			Phone:    admin.Phone,
+			Name:     admin.Name,
+			Role:     member.Role,
+			RegDT:    member.RegDT,
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].AdminSeq < members[j].AdminSeq
+	})
+
+	return members, nil
+}
+
+func (r *storeMemberRepository) UpdateRole(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{storeSeq: storeSeq, adminSeq: adminSeq}
+	if member, ok := r.members[key]; ok {
+		member.Role = role
+		r.members[key] = member
+	}
+
+	return nil
+}
+
+func (r *storeMemberRepository) Delete(ctx context.Context, storeSeq, adminSeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members, memberKey{storeSeq: storeSeq, adminSeq: adminSeq})
+
+	return nil
+}
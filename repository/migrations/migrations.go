@@ -0,0 +1,27 @@
+// Package migrations 스키마 변경 이력을 DB 종류별로 버전 순서대로 관리한다.
+// 파일 이름은 {version}_{name}.up.sql / {version}_{name}.down.sql 형식을 따르며,
+// 같은 버전은 모든 DB 종류에 동일한 스키마를 만들어야 한다.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql
+var files embed.FS
+
+// Dialects migration 파일이 있는 DB 종류
+var Dialects = []string{"mysql", "sqlite"}
+
+// FS dialect 에 해당하는 migration 파일
+func FS(dialect string) (fs.FS, error) {
+	for _, d := range Dialects {
+		if d == dialect {
+			return fs.Sub(files, dialect)
+		}
+	}
+
+	return nil, fmt.Errorf("migrations for dialect(%s) do not exist", dialect)
+}
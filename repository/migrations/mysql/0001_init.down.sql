@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `item`;
+DROP TABLE IF EXISTS `refresh_token`;
+DROP TABLE IF EXISTS `logout_token`;
+DROP TABLE IF EXISTS `store_member`;
+DROP TABLE IF EXISTS `store`;
+DROP TABLE IF EXISTS `admin`;
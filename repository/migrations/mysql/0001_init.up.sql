@@ -0,0 +1,73 @@
+-- 초기 스키마
+CREATE TABLE `admin` (
+     `admin_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK ',
+     `phone` varchar(20) CHARACTER SET utf8mb4 NOT NULL COMMENT '핸드폰번호',
+     `password` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '비밀번호',
+     `name` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름',
+     `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+     `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+     PRIMARY KEY (`admin_seq`),
+     UNIQUE KEY `phone` (`phone`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `store` (
+    `store_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `name` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '매장 이름',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`store_seq`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `store_member` (
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `admin_seq` bigint(20) NOT NULL COMMENT 'admin sequence',
+    `role` varchar(20) CHARACTER SET utf8mb4 NOT NULL COMMENT '매장 내 권한(owner, manager, staff)',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '소속일',
+    PRIMARY KEY (`store_seq`, `admin_seq`),
+    KEY `admin_seq` (`admin_seq`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `logout_token` (
+    `token_seq` bigint(20) NOT NULL AUTO_INCREMENT,
+    `admin_seq` bigint(20) NOT NULL COMMENT 'admin sequence',
+    `token_id` varchar(64) CHARACTER SET utf8mb4 NOT NULL COMMENT 'token id(jti)',
+    `expire_dt` datetime NOT NULL COMMENT '토큰 만료일',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    PRIMARY KEY (`token_seq`),
+    UNIQUE KEY `token_id` (`token_id`) USING BTREE,
+    KEY `expire_dt` (`expire_dt`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `refresh_token` (
+    `token_seq` bigint(20) NOT NULL AUTO_INCREMENT,
+    `admin_seq` bigint(20) NOT NULL COMMENT 'admin sequence',
+    `family_id` varchar(64) CHARACTER SET utf8mb4 NOT NULL COMMENT '최초 로그인 시 발급된 토큰 계열',
+    `token_hash` char(64) CHARACTER SET utf8mb4 NOT NULL COMMENT 'token sha256',
+    `expire_dt` datetime NOT NULL COMMENT '토큰 만료일',
+    `used_dt` datetime DEFAULT NULL COMMENT '사용일',
+    `revoked_dt` datetime DEFAULT NULL COMMENT '폐기일',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    PRIMARY KEY (`token_seq`),
+    UNIQUE KEY `token_hash` (`token_hash`) USING BTREE,
+    KEY `family_id` (`family_id`) USING BTREE,
+    KEY `expire_dt` (`expire_dt`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `item` (
+    `item_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `category` tinyint(4) NOT NULL COMMENT '카테고리(0:음료, 1:음식)',
+    `barcode` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '바코드',
+    `price` bigint(20) NOT NULL COMMENT '가격',
+    `cost` bigint(20) NOT NULL COMMENT '원가',
+    `name` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름',
+    `consonant` varchar(100) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름 초성',
+    `description` text CHARACTER SET utf8mb4 COMMENT '설명',
+    `expire_dt` datetime NOT NULL COMMENT '유통기한',
+    `size` tinyint(4) NOT NULL COMMENT '사이즈(0:small, 1:large)',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`item_seq`),
+    UNIQUE KEY `store_seq_barcode` (`store_seq`, `barcode`) USING BTREE,
+    KEY `name` (`name`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
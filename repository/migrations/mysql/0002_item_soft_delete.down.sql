@@ -0,0 +1,4 @@
+DELETE FROM `item` WHERE `deleted_dt` IS NOT NULL;
+ALTER TABLE `item`
+    DROP KEY `deleted_dt`,
+    DROP COLUMN `deleted_dt`;
@@ -0,0 +1,5 @@
+-- 삭제된 상품은 휴지통에 보관했다가 보관 기간이 지나면 영구 삭제한다
+-- 휴지통의 상품도 바코드를 점유하므로 store_seq_barcode 는 그대로 유지한다
+ALTER TABLE `item`
+    ADD COLUMN `deleted_dt` datetime DEFAULT NULL COMMENT '삭제일(휴지통)' AFTER `mod_dt`,
+    ADD KEY `deleted_dt` (`deleted_dt`) USING BTREE;
@@ -0,0 +1,3 @@
+-- 상품 수정 시 낙관적 잠금에 사용하며, 변경될 때마다 1 씩 증가한다
+ALTER TABLE `item`
+    ADD COLUMN `version` bigint(20) NOT NULL DEFAULT 1 COMMENT '버전' AFTER `size`;
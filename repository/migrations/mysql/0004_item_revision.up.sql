@@ -0,0 +1,17 @@
+-- 상품이 등록, 수정, 삭제, 복구될 때마다 변경 전후 값을 남기며, 한 번 기록된 이력은 수정하지 않는다
+CREATE TABLE `item_revision` (
+    `revision_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `admin_seq` bigint(20) NOT NULL COMMENT '변경한 관리자',
+    `action` varchar(20) CHARACTER SET utf8mb4 NOT NULL COMMENT '변경 종류(create, update, delete, restore)',
+    `version` bigint(20) NOT NULL COMMENT '변경 후 상품 버전',
+    `changes` text CHARACTER SET utf8mb4 NOT NULL COMMENT '변경 전후 값(json)',
+    `price` bigint(20) NOT NULL COMMENT '변경 후 가격',
+    `cost` bigint(20) NOT NULL COMMENT '변경 후 원가',
+    `price_changed` tinyint(1) NOT NULL DEFAULT 0 COMMENT '가격 또는 원가 변경 여부',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '변경일',
+    PRIMARY KEY (`revision_seq`),
+    KEY `item_seq` (`item_seq`, `revision_seq`) USING BTREE,
+    KEY `item_seq_price_changed` (`item_seq`, `price_changed`, `reg_dt`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,18 @@
+-- 관리자와 상품 변경 요청의 감사 기록, 실패한 요청도 남긴다
+CREATE TABLE `audit_log` (
+    `audit_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `store_seq` bigint(20) NOT NULL DEFAULT 0 COMMENT '요청 당시 활성 매장, 인증 전 요청은 0',
+    `admin_seq` bigint(20) NOT NULL DEFAULT 0 COMMENT '요청한 관리자, 알 수 없으면 0',
+    `action` varchar(50) CHARACTER SET utf8mb4 NOT NULL COMMENT '요청 종류(admin.sign_in, item.update 등)',
+    `target` varchar(100) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '대상(item_seq=1, phone 등)',
+    `outcome` varchar(20) CHARACTER SET utf8mb4 NOT NULL COMMENT '결과(success, failure)',
+    `status` int(11) NOT NULL COMMENT 'http status',
+    `ip` varchar(45) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT 'client ip',
+    `user_agent` varchar(255) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT 'user agent',
+    `request_id` varchar(64) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT 'request id',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '요청일',
+    PRIMARY KEY (`audit_seq`),
+    KEY `store_seq` (`store_seq`, `audit_seq`) USING BTREE,
+    KEY `admin_seq` (`admin_seq`, `audit_seq`) USING BTREE,
+    KEY `reg_dt` (`reg_dt`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,10 @@
+-- 음식 카테고리의 상품은 1, 나머지 카테고리의 상품은 모두 음료(0)로 되돌린다
+UPDATE `item` SET
+    `category` = CASE (SELECT c.`name` FROM `category` AS c WHERE c.`category_seq` = `item`.`category`) WHEN '음식' THEN 1 ELSE 0 END,
+    `mod_dt` = `mod_dt`;
+
+ALTER TABLE `item`
+    DROP KEY `store_seq_category`,
+    MODIFY `category` tinyint(4) NOT NULL COMMENT '카테고리(0:음료, 1:음식)';
+
+DROP TABLE `category`;
@@ -0,0 +1,30 @@
+-- 매장별 상품 카테고리, parent_seq 가 0 이면 최상위이며 같은 상위 카테고리 안에서 display_order 순서로 보여준다
+CREATE TABLE `category` (
+    `category_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `parent_seq` bigint(20) NOT NULL DEFAULT 0 COMMENT '상위 카테고리, 최상위는 0',
+    `name` varchar(50) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름',
+    `display_order` int(11) NOT NULL DEFAULT 0 COMMENT '노출 순서',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`category_seq`),
+    UNIQUE KEY `store_seq_parent_seq_name` (`store_seq`, `parent_seq`, `name`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- 고정되어 있던 음료(0), 음식(1)을 매장마다 카테고리로 만들고, 상품의 category 는 category_seq 를 가리키도록 바꾼다
+INSERT INTO `category` (`store_seq`, `parent_seq`, `name`, `display_order`, `mod_dt`) SELECT `store_seq`, 0, '음료', 1, CURRENT_TIMESTAMP FROM `store`;
+INSERT INTO `category` (`store_seq`, `parent_seq`, `name`, `display_order`, `mod_dt`) SELECT `store_seq`, 0, '음식', 2, CURRENT_TIMESTAMP FROM `store`;
+
+ALTER TABLE `item`
+    MODIFY `category` bigint(20) NOT NULL COMMENT 'category sequence';
+
+UPDATE `item` SET
+    `category` = COALESCE((
+        SELECT c.`category_seq` FROM `category` AS c
+        WHERE c.`store_seq` = `item`.`store_seq` AND c.`parent_seq` = 0
+          AND c.`name` = CASE `item`.`category` WHEN 1 THEN '음식' ELSE '음료' END
+    ), 0),
+    `mod_dt` = `mod_dt`;
+
+ALTER TABLE `item`
+    ADD KEY `store_seq_category` (`store_seq`, `category`) USING BTREE;
@@ -0,0 +1,2 @@
+DROP TABLE `item_option`;
+DROP TABLE `item_option_group`;
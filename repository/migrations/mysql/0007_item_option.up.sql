@@ -0,0 +1,35 @@
+-- 상품의 옵션 그룹(사이즈, 온도, 추가 등), min_select 가 1 이상이면 필수이고 max_select 가 0 이면 개수 제한이 없다
+CREATE TABLE `item_option_group` (
+    `option_group_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `name` varchar(50) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름',
+    `min_select` int(11) NOT NULL DEFAULT 0 COMMENT '최소 선택 개수, 1 이상이면 필수',
+    `max_select` int(11) NOT NULL DEFAULT 1 COMMENT '최대 선택 개수, 0 이면 제한 없음',
+    `display_order` int(11) NOT NULL DEFAULT 0 COMMENT '노출 순서',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`option_group_seq`),
+    UNIQUE KEY `item_seq_name` (`item_seq`, `name`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- 옵션 그룹에서 고를 수 있는 옵션, 선택하면 상품 가격과 원가에 price_delta, cost_delta 를 더한다
+CREATE TABLE `item_option` (
+    `option_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `option_group_seq` bigint(20) NOT NULL COMMENT 'option group sequence',
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `name` varchar(50) CHARACTER SET utf8mb4 NOT NULL COMMENT '이름',
+    `price_delta` bigint(20) NOT NULL DEFAULT 0 COMMENT '추가 가격',
+    `cost_delta` bigint(20) NOT NULL DEFAULT 0 COMMENT '추가 원가',
+    `barcode` varchar(100) CHARACTER SET utf8mb4 DEFAULT NULL COMMENT '바코드',
+    `sku` varchar(50) CHARACTER SET utf8mb4 DEFAULT NULL COMMENT 'SKU',
+    `display_order` int(11) NOT NULL DEFAULT 0 COMMENT '노출 순서',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`option_seq`),
+    UNIQUE KEY `option_group_seq_name` (`option_group_seq`, `name`) USING BTREE,
+    UNIQUE KEY `store_seq_barcode` (`store_seq`, `barcode`) USING BTREE,
+    UNIQUE KEY `store_seq_sku` (`store_seq`, `sku`) USING BTREE,
+    KEY `item_seq` (`item_seq`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
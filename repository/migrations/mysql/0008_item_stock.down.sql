@@ -0,0 +1,2 @@
+DROP TABLE `stock_movement`;
+DROP TABLE `item_stock`;
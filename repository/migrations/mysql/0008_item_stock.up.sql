@@ -0,0 +1,30 @@
+-- 상품별 현재 재고, low_stock_threshold 가 NULL 이면 설정의 기본값을 사용한다
+CREATE TABLE `item_stock` (
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `quantity` bigint(20) NOT NULL DEFAULT 0 COMMENT '현재 재고',
+    `low_stock_threshold` bigint(20) DEFAULT NULL COMMENT '재고 부족 기준, NULL 이면 기본값',
+    `mod_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`item_seq`),
+    KEY `store_seq` (`store_seq`, `item_seq`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- 재고 변동 원장, 재고가 바뀔 때마다 같은 transaction 안에서 추가하며 수정, 삭제하지 않는다
+CREATE TABLE `stock_movement` (
+    `movement_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `admin_seq` bigint(20) NOT NULL COMMENT '변동을 기록한 관리자',
+    `type` varchar(20) CHARACTER SET utf8mb4 NOT NULL COMMENT '변동 종류(receive, sell, waste, adjust, transfer)',
+    `quantity` bigint(20) NOT NULL COMMENT '변동 수량, 감소는 음수',
+    `balance` bigint(20) NOT NULL COMMENT '변동 후 재고',
+    `reason` varchar(200) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '사유',
+    `transfer_store_seq` bigint(20) DEFAULT NULL COMMENT '이동한 상대 매장',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    PRIMARY KEY (`movement_seq`),
+    KEY `item_seq` (`item_seq`, `movement_seq`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- 기존 상품의 재고는 0 으로 시작한다
+INSERT INTO `item_stock` (`item_seq`, `store_seq`, `quantity`, `mod_dt`)
+SELECT `item_seq`, `store_seq`, 0, CURRENT_TIMESTAMP FROM `item`;
@@ -0,0 +1,20 @@
+-- 입고한 lot(batch)별 수량과 유통기한, 재고를 줄일 때 유통기한이 빠른 lot 부터 차감한다(FEFO)
+CREATE TABLE `item_lot` (
+    `lot_seq` bigint(20) NOT NULL AUTO_INCREMENT COMMENT 'PK',
+    `item_seq` bigint(20) NOT NULL COMMENT 'item sequence',
+    `store_seq` bigint(20) NOT NULL COMMENT 'store sequence',
+    `lot_number` varchar(50) CHARACTER SET utf8mb4 NOT NULL COMMENT 'lot 번호',
+    `quantity` bigint(20) NOT NULL DEFAULT 0 COMMENT '입고 수량',
+    `remaining` bigint(20) NOT NULL DEFAULT 0 COMMENT '남은 수량',
+    `received_dt` datetime NOT NULL COMMENT '입고일',
+    `expire_dt` datetime NOT NULL COMMENT '유통기한',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '등록일',
+    `mod_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '수정일',
+    PRIMARY KEY (`lot_seq`),
+    UNIQUE KEY `item_seq_lot_number` (`item_seq`, `lot_number`) USING BTREE,
+    KEY `store_seq_expire_dt` (`store_seq`, `expire_dt`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- lot 의 재고가 바뀐 변동은 lot 마다 원장에 남긴다
+ALTER TABLE `stock_movement`
+    ADD COLUMN `lot_seq` bigint(20) DEFAULT NULL COMMENT 'lot sequence' AFTER `transfer_store_seq`;
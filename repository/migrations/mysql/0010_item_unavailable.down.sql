@@ -0,0 +1,3 @@
+ALTER TABLE `item`
+    DROP KEY `expire_dt`,
+    DROP COLUMN `unavailable_dt`;
@@ -0,0 +1,68 @@
+-- 초기 스키마
+CREATE TABLE `admin` (
+    `admin_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `phone` varchar(20) NOT NULL,
+    `password` varchar(100) NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `admin_phone` ON `admin` (`phone`);
+
+CREATE TABLE `store` (
+    `store_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `name` varchar(100) NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+
+CREATE TABLE `store_member` (
+    `store_seq` bigint NOT NULL,
+    `admin_seq` bigint NOT NULL,
+    `role` varchar(20) NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`store_seq`, `admin_seq`)
+);
+CREATE INDEX `store_member_admin_seq` ON `store_member` (`admin_seq`);
+
+CREATE TABLE `logout_token` (
+    `token_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `admin_seq` bigint NOT NULL,
+    `token_id` varchar(64) NOT NULL,
+    `expire_dt` datetime NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `logout_token_token_id` ON `logout_token` (`token_id`);
+CREATE INDEX `logout_token_expire_dt` ON `logout_token` (`expire_dt`);
+
+CREATE TABLE `refresh_token` (
+    `token_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `admin_seq` bigint NOT NULL,
+    `family_id` varchar(64) NOT NULL,
+    `token_hash` char(64) NOT NULL,
+    `expire_dt` datetime NOT NULL,
+    `used_dt` datetime DEFAULT NULL,
+    `revoked_dt` datetime DEFAULT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `refresh_token_token_hash` ON `refresh_token` (`token_hash`);
+CREATE INDEX `refresh_token_family_id` ON `refresh_token` (`family_id`);
+CREATE INDEX `refresh_token_expire_dt` ON `refresh_token` (`expire_dt`);
+
+CREATE TABLE `item` (
+    `item_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `store_seq` bigint NOT NULL,
+    `category` tinyint NOT NULL,
+    `barcode` varchar(100) NOT NULL,
+    `price` bigint NOT NULL,
+    `cost` bigint NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `consonant` varchar(100) NOT NULL,
+    `description` text,
+    `expire_dt` datetime NOT NULL,
+    `size` tinyint NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `item_store_seq_barcode` ON `item` (`store_seq`, `barcode`);
+CREATE INDEX `item_name` ON `item` (`name`);
@@ -0,0 +1,3 @@
+DELETE FROM `item` WHERE `deleted_dt` IS NOT NULL;
+DROP INDEX `item_deleted_dt`;
+ALTER TABLE `item` DROP COLUMN `deleted_dt`;
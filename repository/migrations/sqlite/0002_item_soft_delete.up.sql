@@ -0,0 +1,4 @@
+-- 삭제된 상품은 휴지통에 보관했다가 보관 기간이 지나면 영구 삭제한다
+-- 휴지통의 상품도 바코드를 점유하므로 item_store_seq_barcode 는 그대로 유지한다
+ALTER TABLE `item` ADD COLUMN `deleted_dt` datetime DEFAULT NULL;
+CREATE INDEX `item_deleted_dt` ON `item` (`deleted_dt`);
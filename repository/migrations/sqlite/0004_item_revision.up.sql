@@ -0,0 +1,16 @@
+-- 상품이 등록, 수정, 삭제, 복구될 때마다 변경 전후 값을 남기며, 한 번 기록된 이력은 수정하지 않는다
+CREATE TABLE `item_revision` (
+    `revision_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `item_seq` bigint NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `admin_seq` bigint NOT NULL,
+    `action` varchar(20) NOT NULL,
+    `version` bigint NOT NULL,
+    `changes` text NOT NULL,
+    `price` bigint NOT NULL,
+    `cost` bigint NOT NULL,
+    `price_changed` tinyint NOT NULL DEFAULT 0,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX `item_revision_item_seq` ON `item_revision` (`item_seq`, `revision_seq`);
+CREATE INDEX `item_revision_item_seq_price_changed` ON `item_revision` (`item_seq`, `price_changed`, `reg_dt`);
@@ -0,0 +1,17 @@
+-- 관리자와 상품 변경 요청의 감사 기록, 실패한 요청도 남긴다
+CREATE TABLE `audit_log` (
+    `audit_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `store_seq` bigint NOT NULL DEFAULT 0,
+    `admin_seq` bigint NOT NULL DEFAULT 0,
+    `action` varchar(50) NOT NULL,
+    `target` varchar(100) NOT NULL DEFAULT '',
+    `outcome` varchar(20) NOT NULL,
+    `status` int NOT NULL,
+    `ip` varchar(45) NOT NULL DEFAULT '',
+    `user_agent` varchar(255) NOT NULL DEFAULT '',
+    `request_id` varchar(64) NOT NULL DEFAULT '',
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX `audit_log_store_seq` ON `audit_log` (`store_seq`, `audit_seq`);
+CREATE INDEX `audit_log_admin_seq` ON `audit_log` (`admin_seq`, `audit_seq`);
+CREATE INDEX `audit_log_reg_dt` ON `audit_log` (`reg_dt`);
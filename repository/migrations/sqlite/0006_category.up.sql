@@ -0,0 +1,24 @@
+-- 매장별 상품 카테고리, parent_seq 가 0 이면 최상위이며 같은 상위 카테고리 안에서 display_order 순서로 보여준다
+CREATE TABLE `category` (
+    `category_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `store_seq` bigint NOT NULL,
+    `parent_seq` bigint NOT NULL DEFAULT 0,
+    `name` varchar(50) NOT NULL,
+    `display_order` int NOT NULL DEFAULT 0,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `category_store_seq_parent_seq_name` ON `category` (`store_seq`, `parent_seq`, `name`);
+
+-- 고정되어 있던 음료(0), 음식(1)을 매장마다 카테고리로 만들고, 상품의 category 는 category_seq 를 가리키도록 바꾼다
+INSERT INTO `category` (`store_seq`, `parent_seq`, `name`, `display_order`, `mod_dt`) SELECT `store_seq`, 0, '음료', 1, CURRENT_TIMESTAMP FROM `store`;
+INSERT INTO `category` (`store_seq`, `parent_seq`, `name`, `display_order`, `mod_dt`) SELECT `store_seq`, 0, '음식', 2, CURRENT_TIMESTAMP FROM `store`;
+
+UPDATE `item` SET
+    `category` = COALESCE((
+        SELECT c.`category_seq` FROM `category` AS c
+        WHERE c.`store_seq` = `item`.`store_seq` AND c.`parent_seq` = 0
+          AND c.`name` = CASE `item`.`category` WHEN 1 THEN '음식' ELSE '음료' END
+    ), 0);
+
+CREATE INDEX `item_store_seq_category` ON `item` (`store_seq`, `category`);
@@ -0,0 +1,33 @@
+-- 상품의 옵션 그룹(사이즈, 온도, 추가 등), min_select 가 1 이상이면 필수이고 max_select 가 0 이면 개수 제한이 없다
+CREATE TABLE `item_option_group` (
+    `option_group_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `item_seq` bigint NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `name` varchar(50) NOT NULL,
+    `min_select` int NOT NULL DEFAULT 0,
+    `max_select` int NOT NULL DEFAULT 1,
+    `display_order` int NOT NULL DEFAULT 0,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `item_option_group_item_seq_name` ON `item_option_group` (`item_seq`, `name`);
+
+-- 옵션 그룹에서 고를 수 있는 옵션, 선택하면 상품 가격과 원가에 price_delta, cost_delta 를 더한다
+CREATE TABLE `item_option` (
+    `option_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `option_group_seq` bigint NOT NULL,
+    `item_seq` bigint NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `name` varchar(50) NOT NULL,
+    `price_delta` bigint NOT NULL DEFAULT 0,
+    `cost_delta` bigint NOT NULL DEFAULT 0,
+    `barcode` varchar(100) DEFAULT NULL,
+    `sku` varchar(50) DEFAULT NULL,
+    `display_order` int NOT NULL DEFAULT 0,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime DEFAULT NULL
+);
+CREATE UNIQUE INDEX `item_option_option_group_seq_name` ON `item_option` (`option_group_seq`, `name`);
+CREATE UNIQUE INDEX `item_option_store_seq_barcode` ON `item_option` (`store_seq`, `barcode`);
+CREATE UNIQUE INDEX `item_option_store_seq_sku` ON `item_option` (`store_seq`, `sku`);
+CREATE INDEX `item_option_item_seq` ON `item_option` (`item_seq`);
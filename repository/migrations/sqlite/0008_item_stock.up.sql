@@ -0,0 +1,28 @@
+-- 상품별 현재 재고, low_stock_threshold 가 NULL 이면 설정의 기본값을 사용한다
+CREATE TABLE `item_stock` (
+    `item_seq` bigint PRIMARY KEY NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `quantity` bigint NOT NULL DEFAULT 0,
+    `low_stock_threshold` bigint DEFAULT NULL,
+    `mod_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX `item_stock_store_seq` ON `item_stock` (`store_seq`, `item_seq`);
+
+-- 재고 변동 원장, 재고가 바뀔 때마다 같은 transaction 안에서 추가하며 수정, 삭제하지 않는다
+CREATE TABLE `stock_movement` (
+    `movement_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `item_seq` bigint NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `admin_seq` bigint NOT NULL,
+    `type` varchar(20) NOT NULL,
+    `quantity` bigint NOT NULL,
+    `balance` bigint NOT NULL,
+    `reason` varchar(200) NOT NULL DEFAULT '',
+    `transfer_store_seq` bigint DEFAULT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX `stock_movement_item_seq` ON `stock_movement` (`item_seq`, `movement_seq`);
+
+-- 기존 상품의 재고는 0 으로 시작한다
+INSERT INTO `item_stock` (`item_seq`, `store_seq`, `quantity`, `mod_dt`)
+SELECT `item_seq`, `store_seq`, 0, CURRENT_TIMESTAMP FROM `item`;
@@ -0,0 +1,2 @@
+ALTER TABLE `stock_movement` DROP COLUMN `lot_seq`;
+DROP TABLE `item_lot`;
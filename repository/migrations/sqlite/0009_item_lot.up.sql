@@ -0,0 +1,18 @@
+-- 입고한 lot(batch)별 수량과 유통기한, 재고를 줄일 때 유통기한이 빠른 lot 부터 차감한다(FEFO)
+CREATE TABLE `item_lot` (
+    `lot_seq` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `item_seq` bigint NOT NULL,
+    `store_seq` bigint NOT NULL,
+    `lot_number` varchar(50) NOT NULL,
+    `quantity` bigint NOT NULL DEFAULT 0,
+    `remaining` bigint NOT NULL DEFAULT 0,
+    `received_dt` datetime NOT NULL,
+    `expire_dt` datetime NOT NULL,
+    `reg_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `mod_dt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX `item_lot_item_seq_lot_number` ON `item_lot` (`item_seq`, `lot_number`);
+CREATE INDEX `item_lot_store_seq_expire_dt` ON `item_lot` (`store_seq`, `expire_dt`);
+
+-- lot 의 재고가 바뀐 변동은 lot 마다 원장에 남긴다
+ALTER TABLE `stock_movement` ADD COLUMN `lot_seq` bigint DEFAULT NULL;
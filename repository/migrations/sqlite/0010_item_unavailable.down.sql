@@ -0,0 +1,2 @@
+DROP INDEX `item_expire_dt`;
+ALTER TABLE `item` DROP COLUMN `unavailable_dt`;
@@ -0,0 +1,4 @@
+-- 유통기한이 지난 상품은 스케줄러가 판매 중지하여 리스트와 검색에서 숨긴다
+-- 유통기한을 지나지 않은 날짜로 수정하면 다시 판매한다
+ALTER TABLE `item` ADD COLUMN `unavailable_dt` datetime DEFAULT NULL;
+CREATE INDEX `item_expire_dt` ON `item` (`expire_dt`);
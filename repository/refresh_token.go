@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, adminSeq int64, familyID, tokenHash string, expireDT time.Time) error
+	GetByHash(ctx context.Context, tokenHash string) (*dao.RefreshToken, error)
+	MarkUsed(ctx context.Context, tokenSeq int64, usedDT time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedDT time.Time) error
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type refreshTokenRepository struct {
+	conn *gorm.DB
+}
+
+func NewRefreshTokenRepository(conn *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{conn: conn}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, adminSeq int64, familyID, tokenHash string, expireDT time.Time) error {
+	t := &dao.RefreshToken{
+		AdminSeq:  adminSeq,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpireDT:  expireDT,
+		RegDT:     time.Now(),
+	}
+
+	if err := r.conn.WithContext(ctx).Create(&t).Error; err != nil {
+		return errors.Wrap(err, "failed to create refresh token")
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*dao.RefreshToken, error) {
+	t := new(dao.RefreshToken)
+
+	if err := r.conn.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&t).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to get refresh token by hash")
+	}
+
+	return t, nil
+}
+
+// MarkUsed 아직 사용되지 않은 토큰만 사용 처리하며, 이미 사용된 경우 false 를 반환한다
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, tokenSeq int64, usedDT time.Time) (bool, error) {
+	tx := r.conn.WithContext(ctx).
+		Model(&dao.RefreshToken{}).
+		Where("token_seq = ?", tokenSeq).
+		Where("used_dt IS NULL").
+		Update("used_dt", usedDT)
+	if tx.Error != nil {
+		return false, errors.Wrap(tx.Error, "failed to mark refresh token as used")
+	}
+
+	return tx.RowsAffected > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedDT time.Time) error {
+	if err := r.conn.WithContext(ctx).
+		Model(&dao.RefreshToken{}).
+		Where("family_id = ?", familyID).
+		Where("revoked_dt IS NULL").
+		Update("revoked_dt", revokedDT).Error; err != nil {
+		return errors.Wrapf(err, "failed to revoke refresh token family(%s)", familyID)
+	}
+
+	return nil
+}
+
+// IsFamilyRevoked 계열의 토큰 중 하나라도 폐기되었으면 계열 전체가 폐기된 것으로 본다
+func (r *refreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	var count int64
+	if err := r.conn.WithContext(ctx).
+		Model(&dao.RefreshToken{}).
+		Where("family_id = ?", familyID).
+		Where("revoked_dt IS NOT NULL").
+		Count(&count).Error; err != nil {
+		return false, errors.Wrapf(err, "failed to check refresh token family(%s)", familyID)
+	}
+
+	return count > 0, nil
+}
+
+func (r *refreshTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tx := r.conn.WithContext(ctx).
+		Where("expire_dt < ?", now).
+		Delete(&dao.RefreshToken{})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "failed to delete expired refresh tokens")
+	}
+
+	return tx.RowsAffected, nil
+}
@@ -1,40 +1,104 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"hello-cafe/internal/valid"
 )
 
 type Repository interface {
 	Admin() AdminRepository
+	Store() StoreRepository
+	StoreMember() StoreMemberRepository
+	Category() CategoryRepository
 	Item() ItemRepository
+	ItemOption() ItemOptionRepository
+	ItemRevision() ItemRevisionRepository
+	ItemStock() ItemStockRepository
+	ItemLot() ItemLotRepository
+	StockMovement() StockMovementRepository
 	Logout() LogoutTokenRepository
+	Refresh() RefreshTokenRepository
+	Audit() AuditRepository
+
+	// WithTx fn 안에서 넘겨받은 repo 로 실행한 작업을 하나의 transaction 으로 묶는다.
+	// fn 이 오류를 반환하거나 panic 이 발생하면 rollback 하며, 이미 transaction 안이라면 그 transaction 을 그대로 사용한다.
+	WithTx(ctx context.Context, fn func(repo Repository) error) error
 }
 
 type repository struct {
-	admin  AdminRepository
-	item   ItemRepository
-	logout LogoutTokenRepository
+	conn *gorm.DB
+
+	admin       AdminRepository
+	store       StoreRepository
+	storeMember StoreMemberRepository
+	category    CategoryRepository
+	item        ItemRepository
+	itemOption  ItemOptionRepository
+	itemRev     ItemRevisionRepository
+	itemStock   ItemStockRepository
+	itemLot     ItemLotRepository
+	movement    StockMovementRepository
+	logout      LogoutTokenRepository
+	refresh     RefreshTokenRepository
+	audit       AuditRepository
 }
 
 func (r *repository) Validate() error {
 	switch {
 	case valid.IsNil(r.admin):
 		return errors.New("admin repository is nil")
+	case valid.IsNil(r.store):
+		return errors.New("store repository is nil")
+	case valid.IsNil(r.storeMember):
+		return errors.New("store member repository is nil")
+	case valid.IsNil(r.category):
+		return errors.New("category repository is nil")
 	case valid.IsNil(r.item):
 		return errors.New("item repository is nil")
+	case valid.IsNil(r.itemOption):
+		return errors.New("item option repository is nil")
+	case valid.IsNil(r.itemRev):
+		return errors.New("item revision repository is nil")
+	case valid.IsNil(r.itemStock):
+		return errors.New("item stock repository is nil")
+	case valid.IsNil(r.itemLot):
+		return errors.New("item lot repository is nil")
+	case valid.IsNil(r.movement):
+		return errors.New("stock movement repository is nil")
 	case valid.IsNil(r.logout):
 		return errors.New("logout token repository is nil")
+	case valid.IsNil(r.refresh):
+		return errors.New("refresh token repository is nil")
+	case valid.IsNil(r.audit):
+		return errors.New("audit repository is nil")
 	}
 
 	return nil
 }
 
-func NewRepository() (Repository, error) {
+func NewRepository(conn *gorm.DB) (Repository, error) {
+	if conn == nil {
+		return nil, errors.New("db connection is nil")
+	}
+
 	r := &repository{
-		admin:  NewAdminRepository(),
-		item:   NewItemRepository(),
-		logout: NewLogoutTokenRepository(),
+		conn:        conn,
+		admin:       NewAdminRepository(conn),
+		store:       NewStoreRepository(conn),
+		storeMember: NewStoreMemberRepository(conn),
+		category:    NewCategoryRepository(conn),
+		item:        NewItemRepository(conn),
+		itemOption:  NewItemOptionRepository(conn),
+		itemRev:     NewItemRevisionRepository(conn),
+		itemStock:   NewItemStockRepository(conn),
+		itemLot:     NewItemLotRepository(conn),
+		movement:    NewStockMovementRepository(conn),
+		logout:      NewLogoutTokenRepository(conn),
+		refresh:     NewRefreshTokenRepository(conn),
+		audit:       NewAuditRepository(conn),
 	}
 
 	if err := r.Validate(); err != nil {
@@ -44,14 +108,65 @@ func NewRepository() (Repository, error) {
 	return r, nil
 }
 
+func (r *repository) WithTx(ctx context.Context, fn func(repo Repository) error) error {
+	return r.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo, err := NewRepository(tx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		return fn(txRepo)
+	})
+}
+
 func (r *repository) Admin() AdminRepository {
 	return r.admin
 }
 
+func (r *repository) Store() StoreRepository {
+	return r.store
+}
+
+func (r *repository) StoreMember() StoreMemberRepository {
+	return r.storeMember
+}
+
+func (r *repository) Category() CategoryRepository {
+	return r.category
+}
+
 func (r *repository) Item() ItemRepository {
 	return r.item
 }
 
+func (r *repository) ItemOption() ItemOptionRepository {
+	return r.itemOption
+}
+
+func (r *repository) ItemRevision() ItemRevisionRepository {
+	return r.itemRev
+}
+
+func (r *repository) ItemStock() ItemStockRepository {
+	return r.itemStock
+}
+
+func (r *repository) ItemLot() ItemLotRepository {
+	return r.itemLot
+}
+
+func (r *repository) StockMovement() StockMovementRepository {
+	return r.movement
+}
+
 func (r *repository) Logout() LogoutTokenRepository {
 	return r.logout
 }
+
+func (r *repository) Refresh() RefreshTokenRepository {
+	return r.refresh
+}
+
+func (r *repository) Audit() AuditRepository {
+	return r.audit
+}
@@ -0,0 +1,19 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/db/dbtest"
+	"hello-cafe/repository"
+	"hello-cafe/repository/repositorytest"
+)
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		repo, err := repository.NewRepository(dbtest.Connect(t))
+		require.NoError(t, err)
+
+		return repo
+	})
+}
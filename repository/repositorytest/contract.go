@@ -0,0 +1,1209 @@
+// Package repositorytest repository.Repository 구현체가 지켜야 하는 동작을 검증한다.
+// SQL 구현과 memory 구현이 같은 테스트를 통과하도록 하여 두 구현이 어긋나지 않게 한다.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// Run 테스트마다 newRepository 로 비어있는 저장소를 만들어 계약을 검증한다
+func Run(t *testing.T, newRepository func(t *testing.T) repository.Repository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.Repository)
+	}{
+		{name: "관리자", fn: testAdmin},
+		{name: "매장", fn: testStore},
+		{name: "매장 소속", fn: testStoreMember},
+		{name: "카테고리", fn: testCategory},
+		{name: "상품 등록", fn: testItemCreate},
+		{name: "상품 조회", fn: testItemFind},
+		{name: "상품 조건 조회", fn: testItemFindFilter},
+		{name: "상품 검색 대상", fn: testItemFindAll},
+		{name: "상품 번호로 조회", fn: testItemFindBySeqs},
+		{name: "전체 상품 순회", fn: testItemScan},
+		{name: "상품 수정", fn: testItemUpdate},
+		{name: "상품 삭제", fn: testItemDelete},
+		{name: "상품 유통기한 경과", fn: testItemExpire},
+		{name: "상품 이력", fn: testItemRevision},
+		{name: "상품 옵션", fn: testItemOption},
+		{name: "상품 재고", fn: testItemStock},
+		{name: "재고 변동 원장", fn: testStockMovement},
+		{name: "상품 lot", fn: testItemLot},
+		{name: "로그아웃 토큰", fn: testLogoutToken},
+		{name: "refresh token", fn: testRefreshToken},
+		{name: "감사 기록", fn: testAudit},
+		{name: "transaction", fn: testWithTx},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepository(t))
+		})
+	}
+}
+
+func testAdmin(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	created, err := repo.Admin().Create(ctx, "010-1234-1234", "12341234", "홍길동")
+	require.NoError(t, err)
+	require.Greater(t, created.AdminSeq, int64(0))
+
+	got, err := repo.Admin().Get(ctx, created.AdminSeq)
+	require.NoError(t, err)
+	require.Equal(t, "010-1234-1234", got.Phone)
+	require.Equal(t, "홍길동", got.Name)
+
+	got, err = repo.Admin().GetAdminByPhone(ctx, "010-1234-1234")
+	require.NoError(t, err)
+	require.Equal(t, created.AdminSeq, got.AdminSeq)
+
+	_, err = repo.Admin().Create(ctx, "010-1234-1234", "12341234", "임꺽정")
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated phone: %v", err)
+
+	_, err = repo.Admin().Get(ctx, created.AdminSeq+100)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist admin: %v", err)
+
+	_, err = repo.Admin().GetAdminByPhone(ctx, "010-0000-0000")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist phone: %v", err)
+}
+
+func testStore(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	admin, err := repo.Admin().Create(ctx, "010-1234-1234", "12341234", "홍길동")
+	require.NoError(t, err)
+
+	first, err := repo.Store().Create(ctx, "첫 매장")
+	require.NoError(t, err)
+	second, err := repo.Store().Create(ctx, "두번째 매장")
+	require.NoError(t, err)
+
+	got, err := repo.Store().Get(ctx, first.StoreSeq)
+	require.NoError(t, err)
+	require.Equal(t, "첫 매장", got.Name)
+
+	_, err = repo.Store().Get(ctx, second.StoreSeq+100)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist store: %v", err)
+
+	require.NoError(t, repo.StoreMember().Create(ctx, first.StoreSeq, admin.AdminSeq, model.RoleOwner))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, repo.StoreMember().Create(ctx, second.StoreSeq, admin.AdminSeq, model.RoleStaff))
+
+	stores, err := repo.Store().FindByAdmin(ctx, admin.AdminSeq)
+	require.NoError(t, err)
+	require.Len(t, stores, 2)
+	require.Equal(t, first.StoreSeq, stores[0].StoreSeq)
+	require.Equal(t, model.RoleOwner, stores[0].Role)
+	require.Equal(t, second.StoreSeq, stores[1].StoreSeq)
+	require.Equal(t, model.RoleStaff, stores[1].Role)
+
+	stores, err = repo.Store().FindByAdmin(ctx, admin.AdminSeq+100)
+	require.NoError(t, err)
+	require.Empty(t, stores)
+}
+
+func testStoreMember(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	owner, err := repo.Admin().Create(ctx, "010-1234-1234", "12341234", "홍길동")
+	require.NoError(t, err)
+	staff, err := repo.Admin().Create(ctx, "010-1234-5678", "12341234", "임꺽정")
+	require.NoError(t, err)
+	store, err := repo.Store().Create(ctx, "매장")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.StoreMember().Create(ctx, store.StoreSeq, owner.AdminSeq, model.RoleOwner))
+	require.NoError(t, repo.StoreMember().Create(ctx, store.StoreSeq, staff.AdminSeq, model.RoleStaff))
+
+	err = repo.StoreMember().Create(ctx, store.StoreSeq, staff.AdminSeq, model.RoleManager)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated member: %v", err)
+
+	require.Error(t, repo.StoreMember().Create(ctx, store.StoreSeq, staff.AdminSeq, model.Role("invalid")))
+
+	members, err := repo.StoreMember().Find(ctx, store.StoreSeq)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	require.Equal(t, owner.AdminSeq, members[0].AdminSeq)
+	require.Equal(t, "010-1234-1234", members[0].Phone)
+	require.Equal(t, staff.AdminSeq, members[1].AdminSeq)
+	require.Equal(t, "임꺽정", members[1].Name)
+
+	require.NoError(t, repo.StoreMember().UpdateRole(ctx, store.StoreSeq, staff.AdminSeq, model.RoleManager))
+	member, err := repo.StoreMember().Get(ctx, store.StoreSeq, staff.AdminSeq)
+	require.NoError(t, err)
+	require.Equal(t, model.RoleManager, member.Role)
+
+	require.NoError(t, repo.StoreMember().Delete(ctx, store.StoreSeq, staff.AdminSeq))
+	_, err = repo.StoreMember().Get(ctx, store.StoreSeq, staff.AdminSeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted member: %v", err)
+}
+
+func testItemCreate(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "8801234", "아메리카노")))
+
+	err := repo.Item().Create(ctx, newItem(1, "8801234", "카페라떼"))
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated barcode: %v", err)
+
+	// 바코드는 매장 안에서만 고유하다
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "8801234", "카페라떼")))
+
+	got, err := repo.Item().GetByBarcode(ctx, 1, "8801234")
+	require.NoError(t, err)
+	require.Equal(t, "아메리카노", got.Name)
+	require.Equal(t, "ㅇㅁㄹㅋㄴ", got.Consonant)
+	require.Equal(t, int64(1), got.StoreSeq)
+
+	item, err := repo.Item().Get(ctx, got.ItemSeq)
+	require.NoError(t, err)
+	require.Equal(t, got.Barcode, item.Barcode)
+
+	_, err = repo.Item().GetByBarcode(ctx, 3, "8801234")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "other store barcode: %v", err)
+
+	_, err = repo.Item().Get(ctx, got.ItemSeq+100)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist item: %v", err)
+}
+
+func testItemFind(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	for _, barcode := range []string{"1", "2", "3", "4", "5"} {
+		require.NoError(t, repo.Item().Create(ctx, newItem(1, barcode, "상품"+barcode)))
+	}
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "6", "다른 매장 상품")))
+
+	after := func(item dao.Item) *repository.ItemCursor {
+		return &repository.ItemCursor{Value: item.ItemSeq, ItemSeq: item.ItemSeq}
+	}
+
+	first, err := repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"5", "4"}, barcodes(first))
+
+	next, err := repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: 1, After: after(first[len(first)-1]), Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2"}, barcodes(next))
+
+	last, err := repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: 1, After: after(next[len(next)-1]), Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, barcodes(last))
+
+	// limit 이 없으면 10 개
+	all, err := repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: 1})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+
+	_, err = repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: -1, Limit: 10})
+	require.Error(t, err)
+}
+
+func testItemFindFilter(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+	base := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 바코드, 이름, 카테고리, 가격, 원가, 유통기한(일)
+	for _, v := range []struct {
+		barcode, name string
+		category      int64
+		price, cost   int64
+		expireDays    int
+	}{
+		{barcode: "1", name: "라떼", category: 1, price: 5000, cost: 2000, expireDays: 3},
+		{barcode: "2", name: "아메리카노", category: 1, price: 4500, cost: 1000, expireDays: 1},
+		{barcode: "3", name: "케이크", category: 2, price: 7000, cost: 3000, expireDays: 2},
+		{barcode: "4", name: "쿠키", category: 2, price: 4500, cost: 1500, expireDays: 5},
+		{barcode: "5", name: "녹차", category: 1, price: 4500, cost: 2500, expireDays: 4},
+	} {
+		item := newItem(1, v.barcode, v.name)
+		category, price, cost, expireDT := v.category, v.price, v.cost, base.AddDate(0, 0, v.expireDays)
+		item.Category, item.Price, item.Cost, item.ExpireDT = &category, &price, &cost, &expireDT
+		require.NoError(t, repo.Item().Create(ctx, item))
+	}
+
+	// 정렬 값이 같은 상품이 있어도 cursor 로 빠짐없이 이어서 조회한다
+	findAll := func(filter repository.ItemFilter) []string {
+		filter.StoreSeq, filter.Limit = 1, 2
+		result := make([]string, 0)
+		for {
+			items, err := repo.Item().Find(ctx, filter)
+			require.NoError(t, err)
+			result = append(result, barcodes(items)...)
+			if len(items) < filter.Limit {
+				return result
+			}
+
+			last := items[len(items)-1]
+			filter.After = &repository.ItemCursor{Value: repository.ItemSortValue(filter.Sort, last), ItemSeq: last.ItemSeq}
+		}
+	}
+
+	minPrice, maxPrice := int64(4500), int64(5000)
+	maxCost := int64(2000)
+
+	tests := []struct {
+		name   string
+		filter repository.ItemFilter
+		want   []string
+	}{
+		{name: "가격 오름차순", filter: repository.ItemFilter{Sort: request.ItemSortPrice, Asc: true}, want: []string{"2", "4", "5", "1", "3"}},
+		{name: "가격 내림차순", filter: repository.ItemFilter{Sort: request.ItemSortPrice}, want: []string{"3", "1", "5", "4", "2"}},
+		{name: "마진 내림차순", filter: repository.ItemFilter{Sort: request.ItemSortMargin}, want: []string{"3", "2", "4", "1", "5"}},
+		{name: "이름 오름차순", filter: repository.ItemFilter{Sort: request.ItemSortName, Asc: true}, want: []string{"5", "1", "2", "3", "4"}},
+		{name: "유통기한 오름차순", filter: repository.ItemFilter{Sort: request.ItemSortExpireDT, Asc: true}, want: []string{"2", "3", "1", "5", "4"}},
+		{name: "등록일 오름차순", filter: repository.ItemFilter{Sort: request.ItemSortRegDT, Asc: true}, want: []string{"1", "2", "3", "4", "5"}},
+		{name: "카테고리", filter: repository.ItemFilter{Categories: []int64{2}}, want: []string{"4", "3"}},
+		{name: "여러 카테고리", filter: repository.ItemFilter{Categories: []int64{1, 2}, Sort: request.ItemSortRegDT, Asc: true}, want: []string{"1", "2", "3", "4", "5"}},
+		{name: "가격, 원가 범위", filter: repository.ItemFilter{MinPrice: &minPrice, MaxPrice: &maxPrice, MaxCost: &maxCost}, want: []string{"4", "2", "1"}},
+		{
+			name:   "유통기한 기간",
+			filter: repository.ItemFilter{ExpireFrom: base.AddDate(0, 0, 2), ExpireTo: base.AddDate(0, 0, 4), Sort: request.ItemSortExpireDT, Asc: true},
+			want:   []string{"3", "1"},
+		},
+		{name: "등록일 기간", filter: repository.ItemFilter{RegTo: time.Now().Add(-time.Hour)}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, findAll(tt.filter))
+
+			filter := tt.filter
+			filter.StoreSeq = 1
+			count, err := repo.Item().Count(ctx, filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tt.want)), count)
+		})
+	}
+}
+
+func testItemFindAll(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "2", "카페라떼")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "3", "Cold Brew")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "4", "아이스 아메리카노")))
+
+	// 다른 매장 상품을 제외하고 최신 순
+	got, err := repo.Item().FindAll(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2", "1"}, barcodes(got))
+	require.Equal(t, "ㅋㅍㄹㄸ", got[1].Consonant)
+
+	got, err = repo.Item().FindAll(ctx, 3)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = repo.Item().FindAll(ctx, -1)
+	require.Error(t, err)
+}
+
+func testCategory(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	newCategory := func(storeSeq, parentSeq int64, name string, displayOrder int) *dao.Category {
+		category, err := dao.NewCategory(storeSeq, parentSeq, name, displayOrder)
+		require.NoError(t, err)
+
+		created, err := repo.Category().Create(ctx, *category)
+		require.NoError(t, err)
+		require.NotZero(t, created.CategorySeq)
+
+		return created
+	}
+
+	dessert := newCategory(1, 0, "디저트", 2)
+	beverage := newCategory(1, 0, "음료", 1)
+	coffee := newCategory(1, beverage.CategorySeq, "커피", 1)
+	newCategory(2, 0, "음료", 1)
+
+	// 같은 상위 카테고리에서 이름은 고유하다
+	category, err := dao.NewCategory(1, 0, "음료", 3)
+	require.NoError(t, err)
+	_, err = repo.Category().Create(ctx, *category)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated name: %v", err)
+
+	got, err := repo.Category().Find(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, []int64{beverage.CategorySeq, dessert.CategorySeq, coffee.CategorySeq},
+		[]int64{got[0].CategorySeq, got[1].CategorySeq, got[2].CategorySeq})
+
+	coffee.ParentSeq, coffee.Name, coffee.DisplayOrder = 0, "커피", 0
+	require.NoError(t, repo.Category().Update(ctx, *coffee))
+
+	updated, err := repo.Category().Get(ctx, coffee.CategorySeq)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), updated.ParentSeq)
+	require.Equal(t, 0, updated.DisplayOrder)
+
+	dessert.Name = "음료"
+	err = repo.Category().Update(ctx, *dessert)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated name: %v", err)
+
+	err = repo.Category().Update(ctx, dao.Category{CategorySeq: coffee.CategorySeq + 100, Name: "없음"})
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist category: %v", err)
+
+	// 휴지통의 상품도 센다
+	item := newItem(1, "1", "아메리카노")
+	item.Category = &coffee.CategorySeq
+	require.NoError(t, repo.Item().Create(ctx, item))
+	created, err := repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+	require.NoError(t, repo.Item().Delete(ctx, created.ItemSeq, created.Version))
+
+	count, err := repo.Item().CountByCategory(ctx, coffee.CategorySeq)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, repo.Category().Delete(ctx, dessert.CategorySeq))
+	_, err = repo.Category().Get(ctx, dessert.CategorySeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted category: %v", err)
+}
+
+func testItemFindBySeqs(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "2", "카페라떼")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "3", "Cold Brew")))
+
+	all, err := repo.Item().FindAll(ctx, 1)
+	require.NoError(t, err)
+	other, err := repo.Item().GetByBarcode(ctx, 2, "3")
+	require.NoError(t, err)
+
+	// 다른 매장과 없는 상품은 제외된다
+	got, err := repo.Item().FindBySeqs(ctx, 1, []int64{all[0].ItemSeq, all[1].ItemSeq, other.ItemSeq, 100})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"1", "2"}, barcodes(got))
+
+	// 휴지통 상품은 제외된다
+	require.NoError(t, repo.Item().Delete(ctx, all[0].ItemSeq, all[0].Version))
+	got, err = repo.Item().FindBySeqs(ctx, 1, []int64{all[0].ItemSeq, all[1].ItemSeq})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, barcodes(got))
+
+	got, err = repo.Item().FindBySeqs(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func testItemScan(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "2", "카페라떼")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "3", "Cold Brew")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(2, "4", "바닐라 라떼")))
+
+	deleted, err := repo.Item().GetByBarcode(ctx, 2, "4")
+	require.NoError(t, err)
+	require.NoError(t, repo.Item().Delete(ctx, deleted.ItemSeq, deleted.Version))
+
+	// 매장과 관계없이 item_seq 순서로 나누어 읽는다
+	got, err := repo.Item().Scan(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, barcodes(got))
+
+	got, err = repo.Item().Scan(ctx, got[1].ItemSeq, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, barcodes(got))
+
+	got, err = repo.Item().Scan(ctx, got[0].ItemSeq, 2)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func testItemUpdate(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "2", "카페라떼")))
+
+	item, err := repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), item.Version)
+
+	name := "카페모카"
+	price := int64(5000)
+	version := item.Version
+	require.NoError(t, repo.Item().Update(ctx, request.UpdateItem{ItemSeq: item.ItemSeq, Name: &name, Price: &price, Version: &version}))
+
+	got, err := repo.Item().Get(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Equal(t, "카페모카", got.Name)
+	require.Equal(t, "ㅋㅍㅁㅋ", got.Consonant)
+	require.Equal(t, int64(5000), got.Price)
+	require.Equal(t, item.Cost, got.Cost)
+	require.Equal(t, int64(2), got.Version)
+
+	// 이전 버전으로는 수정할 수 없다
+	err = repo.Item().Update(ctx, request.UpdateItem{ItemSeq: item.ItemSeq, Name: &name, Version: &version})
+	require.True(t, errors.Is(err, apierror.ErrStaleItem), "stale version: %v", err)
+
+	err = repo.Item().Update(ctx, request.UpdateItem{ItemSeq: item.ItemSeq, Name: &name})
+	require.True(t, errors.Is(err, apierror.ErrNilItemVersion), "nil version: %v", err)
+
+	barcode := "2"
+	version = got.Version
+	err = repo.Item().Update(ctx, request.UpdateItem{ItemSeq: item.ItemSeq, Barcode: &barcode, Version: &version})
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated barcode: %v", err)
+
+	err = repo.Item().Update(ctx, request.UpdateItem{ItemSeq: item.ItemSeq + 100, Name: &name, Version: &version})
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not exist item: %v", err)
+}
+
+func testItemExpire(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	expired := func(barcode, name string) request.CreateItem {
+		item := newItem(1, barcode, name)
+		expireDT := time.Now().Add(-time.Hour)
+		item.ExpireDT = &expireDT
+		return item
+	}
+	require.NoError(t, repo.Item().Create(ctx, expired("1", "우유")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "2", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, expired("3", "샌드위치")))
+	require.NoError(t, repo.Item().Create(ctx, expired("4", "케이크")))
+
+	// 휴지통의 상품은 제외한다
+	cake, err := repo.Item().GetByBarcode(ctx, 1, "4")
+	require.NoError(t, err)
+	require.NoError(t, repo.Item().Delete(ctx, cake.ItemSeq, cake.Version))
+
+	now := time.Now()
+	got, err := repo.Item().FindExpired(ctx, now, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, barcodes(got))
+
+	got, err = repo.Item().FindExpired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, barcodes(got))
+
+	milk := got[0]
+	require.NoError(t, repo.Item().MarkUnavailable(ctx, milk.ItemSeq, now))
+
+	// 판매 중지도 변경이므로 버전을 올린다
+	hidden, err := repo.Item().Get(ctx, milk.ItemSeq)
+	require.NoError(t, err)
+	require.NotNil(t, hidden.UnavailableDT)
+	require.Equal(t, milk.Version+1, hidden.Version)
+	require.False(t, hidden.ModDT.Before(now))
+
+	// 이미 판매 중지된 상품, 유통기한이 지나지 않은 상품은 판매 중지하지 않는다
+	err = repo.Item().MarkUnavailable(ctx, milk.ItemSeq, now)
+	require.True(t, errors.Is(err, apierror.ErrStaleItem), "already unavailable: %v", err)
+
+	americano, err := repo.Item().GetByBarcode(ctx, 1, "2")
+	require.NoError(t, err)
+	err = repo.Item().MarkUnavailable(ctx, americano.ItemSeq, now)
+	require.True(t, errors.Is(err, apierror.ErrStaleItem), "not expired: %v", err)
+
+	got, err = repo.Item().FindExpired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, barcodes(got))
+
+	// 판매 중지된 상품은 Availability 로 제외하거나 골라서 조회하며, 지정하지 않으면 모두 조회한다
+	availabilityTests := []struct {
+		availability repository.ItemAvailability
+		want         []string
+	}{
+		{availability: repository.ItemAvailabilityAll, want: []string{"3", "2", "1"}},
+		{availability: repository.ItemAvailabilityAvailable, want: []string{"3", "2"}},
+		{availability: repository.ItemAvailabilityUnavailable, want: []string{"1"}},
+	}
+	for _, tt := range availabilityTests {
+		filter := repository.ItemFilter{StoreSeq: 1, Availability: tt.availability, Limit: 10}
+		items, err := repo.Item().Find(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, barcodes(items), "availability(%d)", tt.availability)
+
+		count, err := repo.Item().Count(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(tt.want)), count, "availability(%d)", tt.availability)
+	}
+
+	// 검색 대상에서 제외하고, 번호로는 조회할 수 있다
+	items, err := repo.Item().FindAll(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2"}, barcodes(items))
+
+	items, err = repo.Item().Scan(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "3"}, barcodes(items))
+
+	unavailable, err := repo.Item().Get(ctx, milk.ItemSeq)
+	require.NoError(t, err)
+	require.NotNil(t, unavailable.UnavailableDT)
+
+	// 유통기한을 늘리면 다시 판매한다
+	expireDT := now.Add(24 * time.Hour)
+	require.NoError(t, repo.Item().Update(ctx, request.UpdateItem{ItemSeq: milk.ItemSeq, ExpireDT: &expireDT, Version: &unavailable.Version}))
+
+	items, err = repo.Item().FindAll(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2", "1"}, barcodes(items))
+}
+
+func testItemDelete(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "2", "카페라떼")))
+
+	item, err := repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+
+	err = repo.Item().Delete(ctx, item.ItemSeq, item.Version+1)
+	require.True(t, errors.Is(err, apierror.ErrStaleItem), "stale version: %v", err)
+
+	require.NoError(t, repo.Item().Delete(ctx, item.ItemSeq, item.Version))
+
+	// 삭제된 상품은 기본 조회에서 제외된다
+	_, err = repo.Item().Get(ctx, item.ItemSeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted item: %v", err)
+
+	_, err = repo.Item().GetByBarcode(ctx, 1, "1")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted item barcode: %v", err)
+
+	items, err := repo.Item().Find(ctx, repository.ItemFilter{StoreSeq: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2"}, barcodes(items))
+
+	items, err = repo.Item().FindAll(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2"}, barcodes(items))
+
+	err = repo.Item().Delete(ctx, item.ItemSeq, item.Version+1)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "delete again: %v", err)
+
+	// 휴지통
+	deleted, err := repo.Item().GetDeleted(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.NotNil(t, deleted.DeletedDT)
+
+	deleted, err = repo.Item().GetDeletedByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+	require.Equal(t, item.ItemSeq, deleted.ItemSeq)
+
+	items, err = repo.Item().FindDeleted(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, barcodes(items))
+
+	_, err = repo.Item().GetDeleted(ctx, item.ItemSeq+1)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "not deleted item: %v", err)
+
+	// 휴지통의 상품도 바코드를 점유한다
+	err = repo.Item().Create(ctx, newItem(1, "1", "아메리카노"))
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "barcode in trash: %v", err)
+
+	// 복구
+	require.NoError(t, repo.Item().Restore(ctx, item.ItemSeq))
+
+	restored, err := repo.Item().Get(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedDT)
+	require.Equal(t, item.Version+2, restored.Version)
+
+	err = repo.Item().Restore(ctx, item.ItemSeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "restore again: %v", err)
+
+	// 영구 삭제는 보관 기간이 지난 상품만
+	require.NoError(t, repo.Item().Delete(ctx, item.ItemSeq, restored.Version))
+
+	purged, err := repo.Item().Purge(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), purged)
+
+	purged, err = repo.Item().Purge(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = repo.Item().GetDeleted(ctx, item.ItemSeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "purged item: %v", err)
+
+	// 영구 삭제된 상품의 바코드는 다시 사용할 수 있다
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+}
+
+func testItemOption(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "아메리카노")))
+	item, err := repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+
+	newGroup := func(name string, minSelect, maxSelect, displayOrder int) *dao.ItemOptionGroup {
+		group, err := dao.NewItemOptionGroup(*item, name, minSelect, maxSelect, displayOrder)
+		require.NoError(t, err)
+
+		created, err := repo.ItemOption().CreateGroup(ctx, *group)
+		require.NoError(t, err)
+		require.NotZero(t, created.OptionGroupSeq)
+
+		return created
+	}
+
+	extra := newGroup("추가", 0, 0, 2)
+	size := newGroup("사이즈", 1, 1, 1)
+
+	// 상품 안에서 옵션 그룹 이름은 고유하다
+	group, err := dao.NewItemOptionGroup(*item, "사이즈", 0, 1, 3)
+	require.NoError(t, err)
+	_, err = repo.ItemOption().CreateGroup(ctx, *group)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated group name: %v", err)
+
+	groups, err := repo.ItemOption().FindGroups(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, []int64{size.OptionGroupSeq, extra.OptionGroupSeq}, []int64{groups[0].OptionGroupSeq, groups[1].OptionGroupSeq})
+
+	size.MaxSelect, size.DisplayOrder = 2, 3
+	require.NoError(t, repo.ItemOption().UpdateGroup(ctx, *size))
+	updated, err := repo.ItemOption().GetGroup(ctx, size.OptionGroupSeq)
+	require.NoError(t, err)
+	require.Equal(t, 2, updated.MaxSelect)
+
+	newOption := func(group dao.ItemOptionGroup, name string, priceDelta int64, barcode, sku *string, displayOrder int) (*dao.ItemOption, error) {
+		option, err := dao.NewItemOption(group, name, priceDelta, 0, barcode, sku, displayOrder)
+		require.NoError(t, err)
+
+		return repo.ItemOption().Create(ctx, *option)
+	}
+
+	barcode, sku := "8801-1", "AME-TALL"
+	tall, err := newOption(*size, "Tall", 0, &barcode, &sku, 1)
+	require.NoError(t, err)
+	grande, err := newOption(*size, "Grande", 500, nil, nil, 2)
+	require.NoError(t, err)
+	shot, err := newOption(*extra, "샷 추가", 500, nil, nil, 0)
+	require.NoError(t, err)
+
+	// 바코드, SKU 가 없는 옵션은 여러 개일 수 있지만 입력한 값은 매장 안에서 고유하다
+	_, err = newOption(*extra, "Tall", 0, &barcode, nil, 0)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated barcode: %v", err)
+	_, err = newOption(*extra, "시럽", 0, nil, &sku, 0)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated sku: %v", err)
+	_, err = newOption(*size, "Tall", 0, nil, nil, 0)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated option name: %v", err)
+
+	options, err := repo.ItemOption().Find(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Equal(t, []int64{shot.OptionSeq, tall.OptionSeq, grande.OptionSeq},
+		[]int64{options[0].OptionSeq, options[1].OptionSeq, options[2].OptionSeq})
+
+	got, err := repo.ItemOption().GetByBarcode(ctx, 1, barcode)
+	require.NoError(t, err)
+	require.Equal(t, tall.OptionSeq, got.OptionSeq)
+
+	// 바코드를 지우면 NULL 로 저장된다
+	tall.Barcode, tall.PriceDelta = nil, 100
+	require.NoError(t, repo.ItemOption().Update(ctx, *tall))
+	got, err = repo.ItemOption().Get(ctx, tall.OptionSeq)
+	require.NoError(t, err)
+	require.Nil(t, got.Barcode)
+	require.Equal(t, int64(100), got.PriceDelta)
+	_, err = repo.ItemOption().GetByBarcode(ctx, 1, barcode)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "removed barcode: %v", err)
+
+	require.NoError(t, repo.ItemOption().Delete(ctx, grande.OptionSeq))
+	require.NoError(t, repo.ItemOption().DeleteGroup(ctx, extra.OptionGroupSeq))
+	options, err = repo.ItemOption().Find(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Len(t, options, 1)
+
+	// 영구 삭제된 상품의 옵션만 정리한다
+	purged, err := repo.ItemOption().PurgeOrphans(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), purged)
+
+	require.NoError(t, repo.Item().Delete(ctx, item.ItemSeq, item.Version))
+	_, err = repo.Item().Purge(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	purged, err = repo.ItemOption().PurgeOrphans(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+
+	groups, err = repo.ItemOption().FindGroups(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Empty(t, groups)
+}
+
+func testItemRevision(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	revisions := []dao.ItemRevision{
+		{ItemSeq: 1, Action: dao.ItemActionCreate, Version: 1, Price: 4500, Cost: 1500, PriceChanged: true, RegDT: base},
+		{ItemSeq: 1, Action: dao.ItemActionUpdate, Version: 2, Price: 4500, Cost: 1500, RegDT: base.Add(time.Minute)},
+		{ItemSeq: 1, Action: dao.ItemActionUpdate, Version: 3, Price: 5000, Cost: 1500, PriceChanged: true, RegDT: base.Add(2 * time.Minute)},
+		{ItemSeq: 2, Action: dao.ItemActionCreate, Version: 1, Price: 3000, Cost: 1000, PriceChanged: true, RegDT: base},
+	}
+	revisions[2].Changes = dao.ItemChanges{"price": {Before: []byte("4500"), After: []byte("5000")}}
+	for _, revision := range revisions {
+		require.NoError(t, repo.ItemRevision().Create(ctx, revision))
+	}
+
+	got, err := repo.ItemRevision().Find(ctx, 1, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, int64(3), got[0].Version)
+	require.Equal(t, dao.ItemActionUpdate, got[0].Action)
+	require.Equal(t, "4500", string(got[0].Changes["price"].Before))
+	require.Equal(t, "5000", string(got[0].Changes["price"].After))
+
+	got, err = repo.ItemRevision().Find(ctx, 1, got[1].RevisionSeq, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, int64(1), got[0].Version)
+
+	// 가격이 바뀐 이력만 기간으로 조회한다
+	got, err = repo.ItemRevision().FindPriceChanges(ctx, 1, base, base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, int64(4500), got[0].Price)
+	require.Equal(t, int64(5000), got[1].Price)
+
+	got, err = repo.ItemRevision().FindPriceChanges(ctx, 1, base.Add(time.Second), base.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	price, err := repo.ItemRevision().GetPriceAt(ctx, 1, base.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int64(4500), price.Price)
+
+	_, err = repo.ItemRevision().GetPriceAt(ctx, 1, base)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "no price before create: %v", err)
+}
+
+func testItemStock(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	stocks := make([]dao.ItemStock, 0, 3)
+	for _, barcode := range []string{"1", "2", "3"} {
+		require.NoError(t, repo.Item().Create(ctx, newItem(1, barcode, "상품"+barcode)))
+		item, err := repo.Item().GetByBarcode(ctx, 1, barcode)
+		require.NoError(t, err)
+
+		stock := dao.NewItemStock(*item)
+		require.NoError(t, repo.ItemStock().Create(ctx, stock))
+		stocks = append(stocks, stock)
+	}
+
+	err := repo.ItemStock().Create(ctx, stocks[0])
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated item_seq: %v", err)
+
+	_, err = repo.ItemStock().Get(ctx, 100)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "unknown item_seq: %v", err)
+
+	// 재고는 음수가 될 수 없다
+	require.NoError(t, repo.ItemStock().Add(ctx, stocks[0].ItemSeq, 10))
+	require.NoError(t, repo.ItemStock().Add(ctx, stocks[0].ItemSeq, -4))
+	require.ErrorIs(t, repo.ItemStock().Add(ctx, stocks[0].ItemSeq, -7), apierror.ErrInsufficientStock)
+	require.NoError(t, repo.ItemStock().Add(ctx, stocks[1].ItemSeq, 20))
+
+	got, err := repo.ItemStock().Get(ctx, stocks[0].ItemSeq)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), got.Quantity)
+	require.Nil(t, got.LowStockThreshold)
+
+	threshold := int64(10)
+	require.NoError(t, repo.ItemStock().UpdateThreshold(ctx, stocks[0].ItemSeq, &threshold))
+	err = repo.ItemStock().UpdateThreshold(ctx, 100, &threshold)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "unknown item_seq: %v", err)
+
+	found, err := repo.ItemStock().FindByItems(ctx, []int64{stocks[0].ItemSeq, stocks[1].ItemSeq, 100})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	// 상품의 기준(10)이나 기본 기준(5) 이하인 상품, 세 번째 상품은 재고가 0 이다
+	low, err := repo.ItemStock().FindLow(ctx, 1, 5, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, low, 2)
+	require.Equal(t, stocks[0].ItemSeq, low[0].ItemSeq)
+	require.Equal(t, int64(10), *low[0].LowStockThreshold)
+	require.Equal(t, stocks[2].ItemSeq, low[1].ItemSeq)
+
+	low, err = repo.ItemStock().FindLow(ctx, 1, 5, low[0].ItemSeq, 10)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+
+	// 판매 중지된 상품은 제외하고, 유통기한을 바꿔 다시 판매하면 포함한다
+	require.NoError(t, repo.Item().MarkUnavailable(ctx, stocks[2].ItemSeq, time.Now().Add(48*time.Hour)))
+
+	low, err = repo.ItemStock().FindLow(ctx, 1, 5, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	require.Equal(t, stocks[0].ItemSeq, low[0].ItemSeq)
+
+	unavailable, err := repo.Item().Get(ctx, stocks[2].ItemSeq)
+	require.NoError(t, err)
+	expireDT := time.Now().Add(72 * time.Hour)
+	require.NoError(t, repo.Item().Update(ctx, request.UpdateItem{ItemSeq: unavailable.ItemSeq, ExpireDT: &expireDT, Version: &unavailable.Version}))
+
+	low, err = repo.ItemStock().FindLow(ctx, 1, 5, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, low, 2)
+
+	require.NoError(t, repo.ItemStock().UpdateThreshold(ctx, stocks[0].ItemSeq, nil))
+
+	// 휴지통의 상품은 제외하고, 영구 삭제되면 재고도 삭제한다
+	item, err := repo.Item().Get(ctx, stocks[2].ItemSeq)
+	require.NoError(t, err)
+	require.NoError(t, repo.Item().Delete(ctx, item.ItemSeq, item.Version))
+
+	low, err = repo.ItemStock().FindLow(ctx, 1, 5, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, low)
+
+	purged, err := repo.Item().Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	purged, err = repo.ItemStock().PurgeOrphans(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = repo.ItemStock().Get(ctx, stocks[2].ItemSeq)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "purged stock: %v", err)
+}
+
+func testStockMovement(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	storeSeq := int64(2)
+	movements := []dao.StockMovement{
+		{ItemSeq: 1, StoreSeq: 1, AdminSeq: 1, Type: dao.StockMovementReceive, Quantity: 10, Balance: 10},
+		{ItemSeq: 1, StoreSeq: 1, AdminSeq: 1, Type: dao.StockMovementSell, Quantity: -2, Balance: 8},
+		{ItemSeq: 1, StoreSeq: 1, AdminSeq: 2, Type: dao.StockMovementTransfer, Quantity: -3, Balance: 5, TransferStoreSeq: &storeSeq},
+		{ItemSeq: 2, StoreSeq: 1, AdminSeq: 1, Type: dao.StockMovementReceive, Quantity: 1, Balance: 1},
+	}
+	lotSeq := int64(7)
+	movements[1].LotSeq = &lotSeq
+	for _, movement := range movements {
+		movement.RegDT = time.Now().Truncate(time.Second)
+		created, err := repo.StockMovement().Create(ctx, movement)
+		require.NoError(t, err)
+		require.NotZero(t, created.MovementSeq)
+	}
+
+	got, err := repo.StockMovement().Find(ctx, 1, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, dao.StockMovementTransfer, got[0].Type)
+	require.Equal(t, int64(-3), got[0].Quantity)
+	require.Equal(t, storeSeq, *got[0].TransferStoreSeq)
+	require.Nil(t, got[1].TransferStoreSeq)
+	require.Equal(t, lotSeq, *got[1].LotSeq)
+
+	got, err = repo.StockMovement().Find(ctx, 1, got[1].MovementSeq, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, int64(10), got[0].Balance)
+}
+
+func testItemLot(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Item().Create(ctx, newItem(1, "1", "샌드위치")))
+	item, err := repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	newLot := func(lotNumber string, expireDT time.Time, quantity int64) *dao.ItemLot {
+		lot, err := dao.NewItemLot(*item, lotNumber, now, expireDT)
+		require.NoError(t, err)
+
+		created, err := repo.ItemLot().Create(ctx, *lot)
+		require.NoError(t, err)
+		require.NoError(t, repo.ItemLot().Add(ctx, created.LotSeq, quantity))
+
+		return created
+	}
+
+	late := newLot("L-2", now.Add(72*time.Hour), 5)
+	early := newLot("L-1", now.Add(24*time.Hour), 3)
+	newLot("L-0", now.Add(time.Hour), 0)
+
+	_, err = repo.ItemLot().Create(ctx, *early)
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated lot_number: %v", err)
+
+	// 남은 수량은 음수가 될 수 없고, 차감은 입고 수량을 바꾸지 않는다
+	require.ErrorIs(t, repo.ItemLot().Add(ctx, early.LotSeq, -4), apierror.ErrInsufficientLot)
+	require.NoError(t, repo.ItemLot().Add(ctx, early.LotSeq, -1))
+
+	got, err := repo.ItemLot().Get(ctx, early.LotSeq)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), got.Quantity)
+	require.Equal(t, int64(2), got.Remaining)
+	require.True(t, now.Add(24*time.Hour).Equal(got.ExpireDT))
+
+	got, err = repo.ItemLot().GetByNumber(ctx, item.ItemSeq, "L-2")
+	require.NoError(t, err)
+	require.Equal(t, late.LotSeq, got.LotSeq)
+
+	_, err = repo.ItemLot().GetByNumber(ctx, item.ItemSeq, "L-3")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "unknown lot_number: %v", err)
+
+	// 남은 수량이 있는 lot 만 유통기한 순서로
+	lots, err := repo.ItemLot().FindRemaining(ctx, item.ItemSeq)
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	require.Equal(t, early.LotSeq, lots[0].LotSeq)
+	require.Equal(t, late.LotSeq, lots[1].LotSeq)
+
+	lots, err = repo.ItemLot().FindExpiring(ctx, 1, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, lots, 1)
+	require.Equal(t, early.LotSeq, lots[0].LotSeq)
+
+	lots, err = repo.ItemLot().FindExpiring(ctx, 2, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, lots)
+
+	// 휴지통의 상품은 제외하고, 영구 삭제되면 lot 도 삭제한다
+	require.NoError(t, repo.Item().Delete(ctx, item.ItemSeq, item.Version))
+
+	lots, err = repo.ItemLot().FindExpiring(ctx, 1, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, lots)
+
+	_, err = repo.Item().Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	purged, err := repo.ItemLot().PurgeOrphans(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+}
+
+func testLogoutToken(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	now := time.Now()
+	require.NoError(t, repo.Logout().Create(ctx, 1, "expired", now.Add(-time.Minute)))
+	require.NoError(t, repo.Logout().Create(ctx, 1, "valid", now.Add(time.Hour)))
+
+	err := repo.Logout().Create(ctx, 1, "valid", now.Add(time.Hour))
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated token_id: %v", err)
+
+	got, err := repo.Logout().GetLogoutToken(ctx, "valid")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got.AdminSeq)
+
+	deleted, err := repo.Logout().DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = repo.Logout().GetLogoutToken(ctx, "expired")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted token: %v", err)
+}
+
+func testRefreshToken(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	now := time.Now()
+	require.NoError(t, repo.Refresh().Create(ctx, 1, "family", "hash-1", now.Add(time.Hour)))
+	require.NoError(t, repo.Refresh().Create(ctx, 1, "family", "hash-2", now.Add(time.Hour)))
+	require.NoError(t, repo.Refresh().Create(ctx, 1, "other", "hash-3", now.Add(-time.Minute)))
+
+	err := repo.Refresh().Create(ctx, 1, "family", "hash-1", now.Add(time.Hour))
+	require.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "duplicated hash: %v", err)
+
+	token, err := repo.Refresh().GetByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.Nil(t, token.UsedDT)
+
+	// 한 번만 사용 처리된다
+	marked, err := repo.Refresh().MarkUsed(ctx, token.TokenSeq, now)
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	marked, err = repo.Refresh().MarkUsed(ctx, token.TokenSeq, now)
+	require.NoError(t, err)
+	require.False(t, marked)
+
+	revoked, err := repo.Refresh().IsFamilyRevoked(ctx, "family")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	require.NoError(t, repo.Refresh().RevokeFamily(ctx, "family", now))
+	for _, hash := range []string{"hash-1", "hash-2"} {
+		token, err := repo.Refresh().GetByHash(ctx, hash)
+		require.NoError(t, err)
+		require.NotNil(t, token.RevokedDT, hash)
+	}
+
+	other, err := repo.Refresh().GetByHash(ctx, "hash-3")
+	require.NoError(t, err)
+	require.Nil(t, other.RevokedDT)
+
+	revoked, err = repo.Refresh().IsFamilyRevoked(ctx, "family")
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = repo.Refresh().IsFamilyRevoked(ctx, "other")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	deleted, err := repo.Refresh().DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = repo.Refresh().GetByHash(ctx, "hash-3")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "deleted token: %v", err)
+}
+
+func testAudit(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	store, err := repo.Store().Create(ctx, "매장")
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreMember().Create(ctx, store.StoreSeq, 1, model.RoleOwner))
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	logs := []dao.AuditLog{
+		{AdminSeq: 1, Action: "admin.sign_in", Outcome: "success", Status: 200, RegDT: base},
+		{AdminSeq: 2, Action: "admin.sign_in", Outcome: "failure", Status: 401, RegDT: base},
+		{StoreSeq: store.StoreSeq, AdminSeq: 1, Action: "item.create", Target: "barcode=1", Outcome: "success", Status: 200, RegDT: base.Add(time.Minute)},
+		{StoreSeq: store.StoreSeq + 1, AdminSeq: 1, Action: "item.create", Outcome: "success", Status: 200, RegDT: base.Add(time.Minute)},
+		{StoreSeq: store.StoreSeq, AdminSeq: 1, Action: "item.update", Outcome: "failure", Status: 412, IP: "127.0.0.1", UserAgent: "test", RequestID: "request", RegDT: base.Add(2 * time.Minute)},
+	}
+	for _, log := range logs {
+		require.NoError(t, repo.Audit().Create(ctx, log))
+	}
+
+	actions := func(logs dao.AuditLogs) []string {
+		result := make([]string, 0, len(logs))
+		for _, log := range logs {
+			result = append(result, log.Action)
+		}
+		return result
+	}
+
+	// 다른 매장의 기록과 소속되지 않은 관리자의 인증 기록은 제외된다
+	got, err := repo.Audit().Find(ctx, repository.AuditFilter{StoreSeq: store.StoreSeq})
+	require.NoError(t, err)
+	require.Equal(t, []string{"item.update", "item.create", "admin.sign_in"}, actions(got))
+	require.Equal(t, "127.0.0.1", got[0].IP)
+	require.Equal(t, "test", got[0].UserAgent)
+	require.Equal(t, "request", got[0].RequestID)
+
+	got, err = repo.Audit().Find(ctx, repository.AuditFilter{StoreSeq: store.StoreSeq, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"item.update", "item.create"}, actions(got))
+
+	got, err = repo.Audit().Find(ctx, repository.AuditFilter{StoreSeq: store.StoreSeq, LastAuditSeq: got[1].AuditSeq})
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin.sign_in"}, actions(got))
+
+	got, err = repo.Audit().Find(ctx, repository.AuditFilter{Action: "admin.sign_in"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	got, err = repo.Audit().Find(ctx, repository.AuditFilter{AdminSeq: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin.sign_in"}, actions(got))
+
+	got, err = repo.Audit().Find(ctx, repository.AuditFilter{StoreSeq: store.StoreSeq, From: base.Add(time.Minute), To: base.Add(2 * time.Minute)})
+	require.NoError(t, err)
+	require.Equal(t, []string{"item.create"}, actions(got))
+}
+
+func testWithTx(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	// 성공하면 commit
+	err := repo.WithTx(ctx, func(tx repository.Repository) error {
+		return tx.Item().Create(ctx, newItem(1, "1", "아메리카노"))
+	})
+	require.NoError(t, err)
+
+	_, err = repo.Item().GetByBarcode(ctx, 1, "1")
+	require.NoError(t, err)
+
+	// 오류가 발생하면 앞선 작업도 rollback
+	errRollback := errors.New("rollback")
+	err = repo.WithTx(ctx, func(tx repository.Repository) error {
+		if err := tx.Item().Create(ctx, newItem(1, "2", "카페라떼")); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	require.True(t, errors.Is(err, errRollback), "rollback error: %v", err)
+
+	_, err = repo.Item().GetByBarcode(ctx, 1, "2")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "rolled back item: %v", err)
+
+	// panic 도 rollback 후 다시 panic
+	require.Panics(t, func() {
+		_ = repo.WithTx(ctx, func(tx repository.Repository) error {
+			if err := tx.Item().Create(ctx, newItem(1, "3", "카페모카")); err != nil {
+				return err
+			}
+			panic("rollback")
+		})
+	})
+
+	_, err = repo.Item().GetByBarcode(ctx, 1, "3")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "panicked item: %v", err)
+
+	// 중첩된 WithTx 는 바깥 transaction 에 포함된다
+	err = repo.WithTx(ctx, func(tx repository.Repository) error {
+		if err := tx.WithTx(ctx, func(nested repository.Repository) error {
+			return nested.Item().Create(ctx, newItem(1, "4", "녹차"))
+		}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	require.True(t, errors.Is(err, errRollback), "nested rollback error: %v", err)
+
+	_, err = repo.Item().GetByBarcode(ctx, 1, "4")
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound), "nested item: %v", err)
+}
+
+func newItem(storeSeq int64, barcode, name string) request.CreateItem {
+	category := int64(1)
+	size := request.ItemSize(0)
+	price := int64(4500)
+	cost := int64(1500)
+	description := "설명"
+	expireDT := time.Now().Add(24 * time.Hour)
+
+	return request.CreateItem{
+		StoreSeq:    storeSeq,
+		Category:    &category,
+		Barcode:     &barcode,
+		Price:       &price,
+		Cost:        &cost,
+		Name:        &name,
+		Description: &description,
+		ExpireDT:    &expireDT,
+		Size:        &size,
+	}
+}
+
+func barcodes(items []dao.Item) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Barcode)
+	}
+
+	return result
+}
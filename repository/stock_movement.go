@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+// StockMovementRepository 재고 변동 원장은 추가만 하며 수정, 삭제하지 않는다
+type StockMovementRepository interface {
+	Create(ctx context.Context, movement dao.StockMovement) (*dao.StockMovement, error)
+	Find(ctx context.Context, itemSeq, lastMovementSeq int64, limit int) (dao.StockMovements, error)
+}
+
+type stockMovementRepository struct {
+	conn *gorm.DB
+}
+
+func NewStockMovementRepository(conn *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{conn: conn}
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement dao.StockMovement) (*dao.StockMovement, error) {
+	movement.MovementSeq = 0
+	if err := r.conn.WithContext(ctx).Create(&movement).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create stock movement")
+	}
+
+	return &movement, nil
+}
+
+// Find 최근 변동부터 조회한다
+func (r *stockMovementRepository) Find(ctx context.Context, itemSeq, lastMovementSeq int64, limit int) (dao.StockMovements, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	tx := r.conn.WithContext(ctx).
+		Where("item_seq = ?", itemSeq).
+		Limit(limit).
+		Order("movement_seq DESC")
+
+	if lastMovementSeq > 0 {
+		tx = tx.Where("movement_seq < ?", lastMovementSeq)
+	}
+
+	movements := make(dao.StockMovements, 0)
+	if err := tx.Find(&movements).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find stock movements")
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/repository/dao"
+)
+
+type StoreRepository interface {
+	Create(ctx context.Context, name string) (*dao.Store, error)
+	Get(ctx context.Context, storeSeq int64) (*dao.Store, error)
+	FindByAdmin(ctx context.Context, adminSeq int64) ([]dao.StoreInfo, error)
+}
+
+type storeRepository struct {
+	conn *gorm.DB
+}
+
+func NewStoreRepository(conn *gorm.DB) StoreRepository {
+	return &storeRepository{conn: conn}
+}
+
+func (r *storeRepository) Create(ctx context.Context, name string) (*dao.Store, error) {
+	store, err := dao.NewStore(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create new store")
+	}
+
+	if err := r.conn.WithContext(ctx).Create(&store).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create store")
+	}
+
+	return store, nil
+}
+
+func (r *storeRepository) Get(ctx context.Context, storeSeq int64) (*dao.Store, error) {
+	store := new(dao.Store)
+	if err := r.conn.WithContext(ctx).Take(&store, storeSeq).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get store by store_seq(%d)", storeSeq)
+	}
+
+	return store, nil
+}
+
+// FindByAdmin 관리자가 소속된 매장을 먼저 소속된 순서로 조회한다
+func (r *storeRepository) FindByAdmin(ctx context.Context, adminSeq int64) ([]dao.StoreInfo, error) {
+	stores := make([]dao.StoreInfo, 0)
+	if err := r.conn.WithContext(ctx).
+		Table("store_member AS sm").
+		Select("s.store_seq, s.name, sm.role, sm.reg_dt").
+		Joins("JOIN store AS s ON s.store_seq = sm.store_seq").
+		Where("sm.admin_seq = ?", adminSeq).
+		Order("sm.reg_dt ASC, s.store_seq ASC").
+		Scan(&stores).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find stores by admin_seq(%d)", adminSeq)
+	}
+
+	return stores, nil
+}
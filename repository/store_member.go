@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/model"
+	"hello-cafe/repository/dao"
+)
+
+type StoreMemberRepository interface {
+	Create(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error
+	Get(ctx context.Context, storeSeq, adminSeq int64) (*dao.StoreMember, error)
+	Find(ctx context.Context, storeSeq int64) ([]dao.MemberInfo, error)
+	UpdateRole(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error
+	Delete(ctx context.Context, storeSeq, adminSeq int64) error
+}
+
+type storeMemberRepository struct {
+	conn *gorm.DB
+}
+
+func NewStoreMemberRepository(conn *gorm.DB) StoreMemberRepository {
+	return &storeMemberRepository{conn: conn}
+}
+
+func (r *storeMemberRepository) Create(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error {
+	if err := role.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	member := &dao.StoreMember{
+		StoreSeq: storeSeq,
+		AdminSeq: adminSeq,
+		Role:     role,
+		RegDT:    time.Now(),
+	}
+
+	if err := r.conn.WithContext(ctx).Create(&member).Error; err != nil {
+		return errors.Wrap(err, "failed to create store member")
+	}
+
+	return nil
+}
+
+func (r *storeMemberRepository) Get(ctx context.Context, storeSeq, adminSeq int64) (*dao.StoreMember, error) {
+	member := new(dao.StoreMember)
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("admin_seq = ?", adminSeq).
+		Take(&member).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get store member by store_seq(%d) and admin_seq(%d)", storeSeq, adminSeq)
+	}
+
+	return member, nil
+}
+
+func (r *storeMemberRepository) Find(ctx context.Context, storeSeq int64) ([]dao.MemberInfo, error) {
+	members := make([]dao.MemberInfo, 0)
+	if err := r.conn.WithContext(ctx).
+		Table("store_member AS sm").
+		Select("sm.store_seq, sm.admin_seq, a.phone, a.name, sm.role, sm.reg_dt").
+		Joins("JOIN admin AS a ON a.admin_seq = sm.admin_seq").
+		Where("sm.store_seq = ?", storeSeq).
+		Order("sm.admin_seq ASC").
+		Scan(&members).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find members by store_seq(%d)", storeSeq)
+	}
+
+	return members, nil
+}
+
+func (r *storeMemberRepository) UpdateRole(ctx context.Context, storeSeq, adminSeq int64, role model.Role) error {
+	if err := r.conn.WithContext(ctx).
+		Model(&dao.StoreMember{}).
+		Where("store_seq = ?", storeSeq).
+		Where("admin_seq = ?", adminSeq).
+		Update("role", role).Error; err != nil {
+		return errors.Wrapf(err, "failed to update role of admin_seq(%d) in store_seq(%d)", adminSeq, storeSeq)
+	}
+
+	return nil
+}
+
+func (r *storeMemberRepository) Delete(ctx context.Context, storeSeq, adminSeq int64) error {
+	if err := r.conn.WithContext(ctx).
+		Where("store_seq = ?", storeSeq).
+		Where("admin_seq = ?", adminSeq).
+		Delete(&dao.StoreMember{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete admin_seq(%d) from store_seq(%d)", adminSeq, storeSeq)
+	}
+
+	return nil
+}
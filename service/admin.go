@@ -1,63 +1,98 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 	"hello-cafe/internal/apierror"
 	"hello-cafe/internal/internaljwt"
+	"hello-cafe/internal/strcheck"
 	"hello-cafe/internal/valid"
+	"hello-cafe/model"
 	"hello-cafe/repository"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AdminService interface {
-	SignIn(phone string, password string) (accessToken string, err error)
-	SignUp(phone string, password string, name string) error
-	SignOut(phone string, token string) error
+	// SignIn 전화번호로 찾은 관리자의 로그인이 실패하면 SignInError 로 관리자를 알려준다
+	SignIn(ctx context.Context, phone string, password string) (*model.Token, error)
+	// SignUp 가입한 관리자의 adminSeq 를 반환한다
+	SignUp(ctx context.Context, phone string, password string, name string) (int64, error)
+	SignOut(ctx context.Context, phone string, token string, refreshToken string) error
+	RefreshToken(ctx context.Context, refreshToken string) (*model.Token, error)
+}
+
+// SignInError 전화번호로 관리자를 찾은 뒤 실패한 로그인, 감사 기록에 남길 관리자를 담는다
+type SignInError struct {
+	AdminSeq int64
+	err      error
+}
+
+func (e *SignInError) Error() string {
+	return e.err.Error()
+}
+
+func (e *SignInError) Unwrap() error {
+	return e.err
 }
 
 type adminService struct {
-	repo repository.Repository
+	repo         repository.Repository
+	tokenService TokenService
 }
 
-func NewAdminService(repo repository.Repository) (AdminService, error) {
-	if valid.IsNil(repo) {
+func NewAdminService(repo repository.Repository, tokenService TokenService) (AdminService, error) {
+	switch {
+	case valid.IsNil(repo):
 		return nil, errors.New("repository is nil")
+	case valid.IsNil(tokenService):
+		return nil, errors.New("token service is nil")
 	}
 
-	return &adminService{repo: repo}, nil
+	return &adminService{repo: repo, tokenService: tokenService}, nil
 }
 
-func (s *adminService) SignIn(phone string, password string) (accessToken string, err error) {
+func (s *adminService) SignIn(ctx context.Context, phone string, password string) (*model.Token, error) {
 	switch {
 	case phone == "":
-		return "", apierror.ErrNilPhone
+		return nil, apierror.ErrNilPhone
 	case password == "":
-		return "", apierror.ErrNilPassword
+		return nil, apierror.ErrNilPassword
 	}
 
-	admin, err := s.repo.Admin().GetAdminByPhone(phone)
+	admin, err := s.repo.Admin().GetAdminByPhone(ctx, phone)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return "", errors.Wrap(err, "failed to get admin")
+		return nil, errors.Wrap(err, "failed to get admin")
 	}
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return "", apierror.ErrIDNotExist
+		return nil, apierror.ErrIDNotExist
 	}
 
 	if !s.checkPasswordHash(admin.Password, password) {
-		return "", apierror.ErrIncorrectPassword
+		return nil, &SignInError{AdminSeq: admin.AdminSeq, err: apierror.ErrIncorrectPassword}
 	}
 
 	// 토큰 발행
-	if accessToken, err = internaljwt.CreateJWT(phone); err != nil {
-		return "", errors.Wrap(err, "failed to create jwt token")
+	token, err := s.tokenService.Issue(ctx, *admin)
+	if err != nil {
+		return nil, &SignInError{AdminSeq: admin.AdminSeq, err: errors.Wrap(err, "failed to issue token")}
+	}
+
+	return token, nil
+}
+
+func (s *adminService) RefreshToken(ctx context.Context, refreshToken string) (*model.Token, error) {
+	token, err := s.tokenService.Refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return
+	return token, nil
 }
 
 func (s *adminService) checkPasswordHash(hashVal, userPw string) bool {
@@ -69,37 +104,83 @@ func (s *adminService) checkPasswordHash(hashVal, userPw string) bool {
 	}
 }
 
-func (s *adminService) SignUp(phone string, password string, name string) error {
+func hashPassword(password string) (string, error) {
+	if !strcheck.ValidatePassword(password) {
+		return "", apierror.ErrInvalidPassword
+	}
+
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.New("failed to encrypt password")
+	}
+
+	return string(bytes), nil
+}
+
+func (s *adminService) SignUp(ctx context.Context, phone string, password string, name string) (int64, error) {
 	switch {
 	case phone == "":
-		return apierror.ErrNilPhone
+		return 0, apierror.ErrNilPhone
 	case password == "":
-		return apierror.ErrNilPassword
+		return 0, apierror.ErrNilPassword
 	}
 
-	admin, err := s.repo.Admin().GetAdminByPhone(phone)
+	admin, err := s.repo.Admin().GetAdminByPhone(ctx, phone)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return errors.Wrap(err, "failed to get admin")
+		return 0, errors.Wrap(err, "failed to get admin")
 	}
 
 	if !valid.IsNil(admin) && admin.AdminSeq > 0 {
-		return apierror.ErrDuplicatedAdmin
+		return 0, apierror.ErrDuplicatedAdmin
 	}
 
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	encryptedPwd, err := hashPassword(password)
 	if err != nil {
-		return errors.New("failed to encrypt password")
+		return 0, errors.WithStack(err)
 	}
 
-	encryptedPwd := string(bytes)
-	if err := s.repo.Admin().Create(phone, encryptedPwd, name); err != nil {
-		return errors.Wrap(err, "failed to sign up admin")
+	var adminSeq int64
+	err = s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		created, err := repo.Admin().Create(ctx, phone, encryptedPwd, name)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apierror.ErrDuplicatedAdmin
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "failed to sign up admin")
+		}
+
+		// 가입한 관리자는 자신의 첫 매장의 owner 가 된다
+		storeName := name
+		if storeName == "" {
+			storeName = phone
+		}
+
+		store, err := repo.Store().Create(ctx, storeName)
+		if err != nil {
+			return errors.Wrap(err, "failed to create store")
+		}
+
+		if err := repo.StoreMember().Create(ctx, store.StoreSeq, created.AdminSeq, model.RoleOwner); err != nil {
+			return errors.Wrap(err, "failed to join store")
+		}
+
+		if err := createDefaultCategories(ctx, repo, store.StoreSeq); err != nil {
+			return err
+		}
+
+		adminSeq = created.AdminSeq
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return adminSeq, nil
 }
 
-func (s *adminService) SignOut(phone string, token string) error {
+func (s *adminService) SignOut(ctx context.Context, phone string, token string, refreshToken string) error {
 	switch {
 	case phone == "":
 		return apierror.ErrNilPhone
@@ -107,22 +188,31 @@ func (s *adminService) SignOut(phone string, token string) error {
 		return apierror.ErrNilAccessToken
 	}
 
-	admin, err := s.repo.Admin().GetAdminByPhone(phone)
+	claims, err := internaljwt.ParseJWT(token)
+	if err != nil {
+		return apierror.ErrInvalidAccessToken
+	}
+
+	admin, err := s.repo.Admin().GetAdminByPhone(ctx, phone)
 	if err != nil {
 		return errors.Wrap(err, "failed to get admin")
 	}
 
-	logoutToken, err := s.repo.Logout().GetLogoutToken(admin.AdminSeq, token)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return errors.Wrap(err, "failed to get logout token")
+	if claims.AdminSeq != admin.AdminSeq {
+		return apierror.ErrInvalidAccessToken
+	}
+
+	revoked, err := s.tokenService.IsRevoked(ctx, claims.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check logout token")
 	}
 
-	if !valid.IsNil(logoutToken) {
-		return apierror.ErrAlreadyLogout.SetInternal(fmt.Errorf("token_seq(%d) is already expired", logoutToken.TokenSeq))
+	if revoked {
+		return apierror.ErrAlreadyLogout.SetInternal(fmt.Errorf("token_id(%s) is already expired", claims.Id))
 	}
 
-	if err := s.repo.Logout().Create(admin.AdminSeq, token); err != nil {
-		return errors.Wrap(err, "failed to create logout token")
+	if err := s.tokenService.Revoke(ctx, admin.AdminSeq, claims.Id, time.Unix(claims.ExpiresAt, 0), refreshToken); err != nil {
+		return errors.Wrap(err, "failed to revoke token")
 	}
 
 	return nil
@@ -1,30 +1,30 @@
 package service
 
 import (
-	"os"
+	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
-	"hello-cafe/internal/api"
-	"hello-cafe/internal/db"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/model"
 	"hello-cafe/repository"
 )
 
 func Test_adminService_SignIn(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
+	tokenService, err := NewTokenService(repo, internaljwt.Config{})
 	require.NoError(t, err)
 
-	cfg, err := api.Config()
+	signUpService, err := NewAdminService(repo, tokenService)
 	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
+	adminSeq, err := signUpService.SignUp(context.Background(), "010-1234-1111", "12341234", "홍길동")
 	require.NoError(t, err)
 
 	type fields struct {
-		repo repository.Repository
+		repo         repository.Repository
+		tokenService TokenService
 	}
 	type args struct {
 		phone    string
@@ -35,23 +35,27 @@ func Test_adminService_SignIn(t *testing.T) {
 		fields          fields
 		args            args
 		wantAccessToken string
+		wantAdminSeq    int64
 		wantErr         bool
 	}{
 		{
 			name: "로그인 성공",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone:    "010-1234-1111",
 				password: "12341234",
 			},
-			wantErr: false,
+			wantAdminSeq: adminSeq,
+			wantErr:      false,
 		},
 		{
 			name: "로그인 실패(핸드폰번호)",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone:    "010-1234-1111123",
@@ -62,44 +66,54 @@ func Test_adminService_SignIn(t *testing.T) {
 		{
 			name: "로그인 실패(비밀번호)",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone:    "010-1234-1111",
 				password: "12341234123",
 			},
-			wantErr: true,
+			wantAdminSeq: adminSeq,
+			wantErr:      true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &adminService{
-				repo: tt.fields.repo,
+				repo:         tt.fields.repo,
+				tokenService: tt.fields.tokenService,
 			}
-			_, err := s.SignIn(tt.args.phone, tt.args.password)
+			token, err := s.SignIn(context.Background(), tt.args.phone, tt.args.password)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SignIn() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+
+			// 실패한 로그인은 SignInError 로 전화번호로 찾은 관리자를 알려준다
+			var gotAdminSeq int64
+			var signInErr *SignInError
+			switch {
+			case err == nil:
+				gotAdminSeq = token.AdminSeq
+			case errors.As(err, &signInErr):
+				gotAdminSeq = signInErr.AdminSeq
+			}
+			if gotAdminSeq != tt.wantAdminSeq {
+				t.Errorf("SignIn() adminSeq = %v, want %v", gotAdminSeq, tt.wantAdminSeq)
+			}
 		})
 	}
 }
 
 func Test_adminService_SignOut(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
+	tokenService, err := NewTokenService(repo, internaljwt.Config{})
 	require.NoError(t, err)
 
 	type fields struct {
-		repo repository.Repository
+		repo         repository.Repository
+		tokenService TokenService
 	}
 	type args struct {
 		phone string
@@ -114,7 +128,8 @@ func Test_adminService_SignOut(t *testing.T) {
 		{
 			name: "로그아웃 실패",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone: "010-1234-1234",
@@ -126,9 +141,10 @@ func Test_adminService_SignOut(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &adminService{
-				repo: tt.fields.repo,
+				repo:         tt.fields.repo,
+				tokenService: tt.fields.tokenService,
 			}
-			if err := s.SignOut(tt.args.phone, tt.args.token); (err != nil) != tt.wantErr {
+			if err := s.SignOut(context.Background(), tt.args.phone, tt.args.token, ""); (err != nil) != tt.wantErr {
 				t.Errorf("SignOut() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -136,20 +152,14 @@ func Test_adminService_SignOut(t *testing.T) {
 }
 
 func Test_adminService_SignUp(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
-
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
-	err = db.Connect(cfg.DB)
+	tokenService, err := NewTokenService(repo, internaljwt.Config{})
 	require.NoError(t, err)
 
 	type fields struct {
-		repo repository.Repository
+		repo         repository.Repository
+		tokenService TokenService
 	}
 	type args struct {
 		phone    string
@@ -165,7 +175,8 @@ func Test_adminService_SignUp(t *testing.T) {
 		{
 			name: "회원 가입 실패(핸드폰 번호 미입력)",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone:    "",
@@ -177,7 +188,8 @@ func Test_adminService_SignUp(t *testing.T) {
 		{
 			name: "회원 가입 실패(비밀 번호 미입력)",
 			fields: fields{
-				repo: repo,
+				repo:         repo,
+				tokenService: tokenService,
 			},
 			args: args{
 				phone:    "010-1234-1234",
@@ -186,14 +198,103 @@ func Test_adminService_SignUp(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "회원 가입 성공",
+			fields: fields{
+				repo:         repo,
+				tokenService: tokenService,
+			},
+			args: args{
+				phone:    "010-1234-1234",
+				password: "12341234",
+				name:     "홍길동",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &adminService{
-				repo: tt.fields.repo,
+				repo:         tt.fields.repo,
+				tokenService: tt.fields.tokenService,
 			}
-			if err := s.SignUp(tt.args.phone, tt.args.password, tt.args.name); (err != nil) != tt.wantErr {
+			adminSeq, err := s.SignUp(context.Background(), tt.args.phone, tt.args.password, tt.args.name)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("SignUp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && adminSeq <= 0 {
+				t.Errorf("SignUp() adminSeq = %v, want created admin", adminSeq)
+			}
+		})
+	}
+}
+
+func Test_adminService_SignOut_refreshToken(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	tokenService, err := NewTokenService(repo, internaljwt.Config{})
+	require.NoError(t, err)
+
+	s, err := NewAdminService(repo, tokenService)
+	require.NoError(t, err)
+	_, err = s.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+	require.NoError(t, err)
+	_, err = s.SignUp(ctx, "010-1234-2222", "12341234", "임꺽정")
+	require.NoError(t, err)
+
+	other, err := s.SignIn(ctx, "010-1234-2222", "12341234")
+	require.NoError(t, err)
+
+	type args struct {
+		refreshToken func(token *model.Token) string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantRevoked bool
+	}{
+		{
+			name: "다른 관리자의 refresh token 은 rollback",
+			args: args{
+				refreshToken: func(*model.Token) string { return other.RefreshToken },
+			},
+			wantErr:     true,
+			wantRevoked: false,
+		},
+		{
+			name: "로그아웃 성공",
+			args: args{
+				refreshToken: func(token *model.Token) string { return token.RefreshToken },
+			},
+			wantErr:     false,
+			wantRevoked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := s.SignIn(ctx, "010-1234-1111", "12341234")
+			require.NoError(t, err)
+
+			refreshToken := tt.args.refreshToken(token)
+			if err := s.SignOut(ctx, "010-1234-1111", token.AccessToken, refreshToken); (err != nil) != tt.wantErr {
+				t.Errorf("SignOut() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			claims, err := internaljwt.ParseJWT(token.AccessToken)
+			require.NoError(t, err)
+
+			revoked, err := tokenService.IsRevoked(ctx, claims.Id)
+			require.NoError(t, err)
+			if revoked != tt.wantRevoked {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.wantRevoked)
+			}
+
+			_, err = tokenService.Refresh(ctx, refreshToken)
+			if (err != nil) != tt.wantRevoked {
+				t.Errorf("Refresh() error = %v, wantRevoked %v", err, tt.wantRevoked)
 			}
 		})
 	}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+const defaultAuditLimit = 20
+
+type AuditService interface {
+	Record(ctx context.Context, log model.AuditLog) error
+	Find(ctx context.Context, storeSeq int64, req request.FindAudit) (*model.AuditLogPage, error)
+}
+
+type auditService struct {
+	repo repository.Repository
+}
+
+func NewAuditService(repo repository.Repository) (AuditService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &auditService{repo: repo}, nil
+}
+
+func (s *auditService) Record(ctx context.Context, log model.AuditLog) error {
+	if log.RegDT.IsZero() {
+		log.RegDT = time.Now()
+	}
+
+	if err := s.repo.Audit().Create(ctx, dao.AuditLog{
+		StoreSeq:  log.StoreSeq,
+		AdminSeq:  log.AdminSeq,
+		Action:    string(log.Action),
+		Target:    log.Target,
+		Outcome:   string(log.Outcome),
+		Status:    log.Status,
+		IP:        log.IP,
+		UserAgent: log.UserAgent,
+		RequestID: log.RequestID,
+		RegDT:     log.RegDT,
+	}); err != nil {
+		return errors.Wrap(err, "failed to record audit log")
+	}
+
+	return nil
+}
+
+// Find 매장의 감사 기록을 최근 순서로 조회한다
+func (s *auditService) Find(ctx context.Context, storeSeq int64, req request.FindAudit) (*model.AuditLogPage, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lastAuditSeq, _ := req.LastAuditSeq()
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+
+	filter := repository.AuditFilter{
+		StoreSeq:     storeSeq,
+		AdminSeq:     req.AdminSeq,
+		Action:       req.Action,
+		LastAuditSeq: lastAuditSeq,
+		// 다음 페이지가 있는지 확인하기 위해 하나 더 조회한다
+		Limit: limit + 1,
+	}
+
+	if !valid.IsNil(req.From) {
+		filter.From = *req.From
+	}
+
+	if !valid.IsNil(req.To) {
+		filter.To = *req.To
+	}
+
+	logs, err := s.repo.Audit().Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find audit logs")
+	}
+
+	page := &model.AuditLogPage{Logs: make(model.AuditLogs, 0, len(logs))}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		page.NextCursor = strconv.FormatInt(logs[len(logs)-1].AuditSeq, 10)
+	}
+
+	for _, log := range logs {
+		page.Logs = append(page.Logs, model.AuditLog{
+			AuditSeq:  log.AuditSeq,
+			StoreSeq:  log.StoreSeq,
+			AdminSeq:  log.AdminSeq,
+			Action:    model.AuditAction(log.Action),
+			Target:    log.Target,
+			Outcome:   model.AuditOutcome(log.Outcome),
+			Status:    log.Status,
+			IP:        log.IP,
+			UserAgent: log.UserAgent,
+			RequestID: log.RequestID,
+			RegDT:     log.RegDT,
+		})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+func Test_auditService_Find(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	s, err := NewAuditService(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		if err := s.Record(ctx, model.AuditLog{
+			StoreSeq: 1,
+			AdminSeq: int64(i%2 + 1),
+			Action:   model.AuditActionItemUpdate,
+			Outcome:  model.AuditOutcomeSuccess,
+			RegDT:    base.Add(time.Duration(i) * time.Minute),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// cursor 로 다음 페이지를 조회한다
+	var pages [][]int64
+	cursor := ""
+	for {
+		page, err := s.Find(ctx, 1, request.FindAudit{Cursor: cursor, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seqs := make([]int64, 0, len(page.Logs))
+		for _, log := range page.Logs {
+			seqs = append(seqs, log.AuditSeq)
+		}
+		pages = append(pages, seqs)
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(pages) != 3 || pages[0][0] != 5 || pages[2][0] != 1 || len(pages[2]) != 1 {
+		t.Errorf("Find() pages = %v", pages)
+	}
+
+	page, err := s.Find(ctx, 1, request.FindAudit{AdminSeq: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.Logs) != 2 {
+		t.Errorf("Find() admin_seq filter = %v", page.Logs)
+	}
+
+	tests := []struct {
+		name    string
+		req     request.FindAudit
+		wantErr error
+	}{
+		{
+			name:    "잘못된 cursor",
+			req:     request.FindAudit{Cursor: "abc"},
+			wantErr: apierror.ErrInvalidCursor,
+		},
+		{
+			name:    "최대 개수 초과",
+			req:     request.FindAudit{Limit: 1000},
+			wantErr: apierror.ErrInvalidLimit,
+		},
+		{
+			name:    "잘못된 기간",
+			req:     request.FindAudit{From: &base, To: &base},
+			wantErr: apierror.ErrInvalidPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Find(ctx, 1, tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
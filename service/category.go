@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// defaultCategories 새 매장에 노출 순서대로 만드는 최상위 카테고리
+var defaultCategories = []string{"음료", "음식"}
+
+type CategoryService interface {
+	Create(ctx context.Context, storeSeq int64, req request.CreateCategory) (*model.Category, error)
+	Find(ctx context.Context, storeSeq int64) (model.Categories, error)
+	Update(ctx context.Context, storeSeq int64, req request.UpdateCategory) (*model.Category, error)
+	Delete(ctx context.Context, storeSeq, categorySeq int64) error
+}
+
+type categoryService struct {
+	repo repository.Repository
+}
+
+func NewCategoryService(repo repository.Repository) (CategoryService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &categoryService{repo: repo}, nil
+}
+
+func (s *categoryService) Create(ctx context.Context, storeSeq int64, req request.CreateCategory) (*model.Category, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	category, err := dao.NewCategory(storeSeq, req.ParentSeq, *req.Name, req.DisplayOrder)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := s.repo.Store().Get(ctx, storeSeq); err != nil {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	var created *dao.Category
+	err = s.repo.WithTx(ctx, func(repo repository.Repository) (err error) {
+		if req.ParentSeq > 0 {
+			if _, err := getStoreCategory(ctx, repo, storeSeq, req.ParentSeq); err != nil {
+				return apierror.ErrInvalidParent.SetInternal(err)
+			}
+		}
+
+		if created, err = repo.Category().Create(ctx, *category); err != nil {
+			return duplicatedCategoryError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := getCategoryFromDAO(*created)
+	return &result, nil
+}
+
+// Find 최상위 카테고리부터 노출 순서대로 하위 카테고리를 포함해 조회한다
+func (s *categoryService) Find(ctx context.Context, storeSeq int64) (model.Categories, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	categories, err := s.repo.Category().Find(ctx, storeSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find categories")
+	}
+
+	return categoryTree(categories, 0), nil
+}
+
+// Update 다른 카테고리로 옮길 때 자기 자신이나 하위 카테고리 아래로는 옮길 수 없다
+func (s *categoryService) Update(ctx context.Context, storeSeq int64, req request.UpdateCategory) (*model.Category, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var updated *dao.Category
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		category, err := getStoreCategory(ctx, repo, storeSeq, req.CategorySeq)
+		if err != nil {
+			return apierror.ErrNotExistCategory.SetInternal(err)
+		}
+
+		if !valid.IsNil(req.Name) {
+			if category.Name, err = dao.CategoryName(*req.Name); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(req.DisplayOrder) {
+			category.DisplayOrder = *req.DisplayOrder
+		}
+
+		if !valid.IsNil(req.ParentSeq) && *req.ParentSeq != category.ParentSeq {
+			categories, err := repo.Category().Find(ctx, storeSeq)
+			if err != nil {
+				return errors.Wrap(err, "failed to find categories")
+			}
+
+			if !canMoveCategory(categories, category.CategorySeq, *req.ParentSeq) {
+				return apierror.ErrInvalidParent
+			}
+			category.ParentSeq = *req.ParentSeq
+		}
+
+		if err := repo.Category().Update(ctx, *category); err != nil {
+			return duplicatedCategoryError(err)
+		}
+
+		updated = category
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := getCategoryFromDAO(*updated)
+	return &result, nil
+}
+
+// Delete 하위 카테고리나 상품(휴지통 포함)이 있으면 삭제할 수 없다
+func (s *categoryService) Delete(ctx context.Context, storeSeq, categorySeq int64) error {
+	switch {
+	case storeSeq <= 0:
+		return apierror.ErrInvalidStore
+	case categorySeq <= 0:
+		return apierror.ErrInvalidCategory
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		if _, err := getStoreCategory(ctx, repo, storeSeq, categorySeq); err != nil {
+			return apierror.ErrNotExistCategory.SetInternal(err)
+		}
+
+		categories, err := repo.Category().Find(ctx, storeSeq)
+		if err != nil {
+			return errors.Wrap(err, "failed to find categories")
+		}
+
+		for _, category := range categories {
+			if category.ParentSeq == categorySeq {
+				return apierror.ErrCategoryHasChildren
+			}
+		}
+
+		count, err := repo.Item().CountByCategory(ctx, categorySeq)
+		if err != nil {
+			return errors.Wrap(err, "failed to count items")
+		}
+
+		if count > 0 {
+			return apierror.ErrCategoryInUse
+		}
+
+		if err := repo.Category().Delete(ctx, categorySeq); err != nil {
+			return errors.Wrap(err, "failed to delete category")
+		}
+
+		return nil
+	})
+}
+
+// createDefaultCategories 매장을 만드는 transaction 안에서 호출해 기본 카테고리를 함께 만든다
+func createDefaultCategories(ctx context.Context, repo repository.Repository, storeSeq int64) error {
+	for i, name := range defaultCategories {
+		category, err := dao.NewCategory(storeSeq, 0, name, i+1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if _, err := repo.Category().Create(ctx, *category); err != nil {
+			return errors.Wrap(err, "failed to create default category")
+		}
+	}
+
+	return nil
+}
+
+// getStoreCategory 다른 매장의 카테고리는 없는 카테고리와 같이 gorm.ErrRecordNotFound 를 반환한다
+func getStoreCategory(ctx context.Context, repo repository.Repository, storeSeq, categorySeq int64) (*dao.Category, error) {
+	category, err := repo.Category().Get(ctx, categorySeq)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if category.StoreSeq != storeSeq {
+		return nil, errors.Wrapf(gorm.ErrRecordNotFound, "category_seq(%d) is not in store_seq(%d)", categorySeq, storeSeq)
+	}
+
+	return category, nil
+}
+
+// checkItemCategory 상품의 카테고리가 매장에 있는지 확인한다
+func checkItemCategory(ctx context.Context, repo repository.Repository, storeSeq, categorySeq int64) error {
+	_, err := getStoreCategory(ctx, repo, storeSeq, categorySeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierror.ErrUnknownCategory
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to get category")
+	}
+
+	return nil
+}
+
+// canMoveCategory parentSeq 가 매장의 카테고리이고, categorySeq 자신이나 그 하위 카테고리가 아닌지 확인한다
+func canMoveCategory(categories dao.Categories, categorySeq, parentSeq int64) bool {
+	if parentSeq == 0 {
+		return true
+	}
+
+	parents := make(map[int64]int64, len(categories))
+	for _, category := range categories {
+		parents[category.CategorySeq] = category.ParentSeq
+	}
+
+	if _, ok := parents[parentSeq]; !ok {
+		return false
+	}
+
+	for seq := parentSeq; seq != 0; seq = parents[seq] {
+		if seq == categorySeq {
+			return false
+		}
+	}
+
+	return true
+}
+
+// descendantCategories categorySeq 와 그 하위 카테고리 전체
+func descendantCategories(categories dao.Categories, categorySeq int64) []int64 {
+	result := []int64{categorySeq}
+	for i := 0; i < len(result); i++ {
+		for _, category := range categories {
+			if category.ParentSeq == result[i] {
+				result = append(result, category.CategorySeq)
+			}
+		}
+	}
+
+	return result
+}
+
+// categoryTree categories 는 노출 순서대로 정렬되어 있어야 한다
+func categoryTree(categories dao.Categories, parentSeq int64) model.Categories {
+	result := make(model.Categories, 0)
+	for _, category := range categories {
+		if category.ParentSeq != parentSeq {
+			continue
+		}
+
+		c := getCategoryFromDAO(category)
+		c.Children = categoryTree(categories, category.CategorySeq)
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func getCategoryFromDAO(category dao.Category) model.Category {
+	return model.Category{
+		CategorySeq:  category.CategorySeq,
+		ParentSeq:    category.ParentSeq,
+		Name:         category.Name,
+		DisplayOrder: category.DisplayOrder,
+		RegDT:        category.RegDT,
+		Children:     make(model.Categories, 0),
+	}
+}
+
+func duplicatedCategoryError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apierror.ErrDuplicatedCategory
+	}
+
+	return errors.Wrap(err, "failed to save category")
+}
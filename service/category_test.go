@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model/request"
+)
+
+func Test_categoryService(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := repo.Store().Create(ctx, "다른 카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewCategoryService(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(storeSeq, parentSeq int64, name string, displayOrder int) int64 {
+		t.Helper()
+		category, err := s.Create(ctx, storeSeq, request.CreateCategory{ParentSeq: parentSeq, Name: &name, DisplayOrder: displayOrder})
+		if err != nil {
+			t.Fatalf("Create(%s) error = %v", name, err)
+		}
+		return category.CategorySeq
+	}
+
+	food := create(store.StoreSeq, 0, "음식", 2)
+	beverage := create(store.StoreSeq, 0, "음료", 1)
+	coffee := create(store.StoreSeq, beverage, "커피", 1)
+	espresso := create(store.StoreSeq, coffee, "에스프레소", 1)
+	otherCategory := create(other.StoreSeq, 0, "음료", 1)
+
+	name := "커피"
+	if _, err := s.Create(ctx, store.StoreSeq, request.CreateCategory{ParentSeq: beverage, Name: &name}); !errors.Is(err, apierror.ErrDuplicatedCategory) {
+		t.Errorf("Create() 같은 부모의 같은 이름 error = %v, want %v", err, apierror.ErrDuplicatedCategory)
+	}
+
+	if _, err := s.Create(ctx, store.StoreSeq, request.CreateCategory{ParentSeq: otherCategory, Name: &name}); !errors.Is(err, apierror.ErrInvalidParent) {
+		t.Errorf("Create() 다른 매장의 부모 error = %v, want %v", err, apierror.ErrInvalidParent)
+	}
+
+	categories, err := s.Find(ctx, store.StoreSeq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 || categories[0].CategorySeq != beverage || categories[1].CategorySeq != food ||
+		len(categories[0].Children) != 1 || len(categories[0].Children[0].Children) != 1 {
+		t.Fatalf("Find() categories = %+v", categories)
+	}
+
+	tests := []struct {
+		name    string
+		req     request.UpdateCategory
+		wantErr error
+	}{
+		{
+			name:    "자기 자신을 부모로 지정",
+			req:     request.UpdateCategory{CategorySeq: coffee, ParentSeq: &coffee},
+			wantErr: apierror.ErrInvalidParent,
+		},
+		{
+			name:    "하위 카테고리를 부모로 지정",
+			req:     request.UpdateCategory{CategorySeq: beverage, ParentSeq: &espresso},
+			wantErr: apierror.ErrInvalidParent,
+		},
+		{
+			name:    "다른 매장의 카테고리",
+			req:     request.UpdateCategory{CategorySeq: otherCategory, Name: &name},
+			wantErr: apierror.ErrNotExistCategory,
+		},
+		{
+			name: "다른 부모로 이동",
+			req:  request.UpdateCategory{CategorySeq: coffee, ParentSeq: &food},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Update(ctx, store.StoreSeq, tt.req)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	items, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(otherCategory, "8801", "아메리카노")); !errors.Is(err, apierror.ErrUnknownCategory) {
+		t.Errorf("Create() 다른 매장의 카테고리 error = %v, want %v", err, apierror.ErrUnknownCategory)
+	}
+
+	if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(espresso, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 상위 카테고리로 조회하면 하위 카테고리의 상품도 포함된다
+	page, err := items.Find(ctx, store.StoreSeq, request.FindItems{Category: &food, Limit: 10})
+	if err != nil || len(page.Items) != 1 {
+		t.Errorf("Find() items = %v, error = %v", page, err)
+	}
+
+	if err := s.Delete(ctx, store.StoreSeq, coffee); !errors.Is(err, apierror.ErrCategoryHasChildren) {
+		t.Errorf("Delete() 하위 카테고리가 있는 카테고리 error = %v, want %v", err, apierror.ErrCategoryHasChildren)
+	}
+
+	if err := s.Delete(ctx, store.StoreSeq, espresso); !errors.Is(err, apierror.ErrCategoryInUse) {
+		t.Errorf("Delete() 상품이 있는 카테고리 error = %v, want %v", err, apierror.ErrCategoryInUse)
+	}
+
+	if err := s.Delete(ctx, store.StoreSeq, beverage); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"slices"
+	"time"
+
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"hello-cafe/internal/api"
 	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/search"
 	"hello-cafe/internal/valid"
 	"hello-cafe/model"
 	"hello-cafe/model/request"
@@ -12,135 +19,453 @@ import (
 )
 
 type ItemService interface {
-	Create(item request.CreateItem) error
-	Update(item request.UpdateItem) error
-	Delete(itemSeq int64) error
-	Find(adminSeq, lastItemSeq int64, limit int) (model.Items, error)
-	Get(itemSeq int64) (*model.Item, error)
-	Search(adminSeq int64, text string) (model.Items, error)
-	CheckDuplicated(barcode string) (bool, error)
+	Create(ctx context.Context, storeSeq, adminSeq int64, item request.CreateItem) error
+	Update(ctx context.Context, storeSeq, adminSeq int64, item request.UpdateItem) error
+	Delete(ctx context.Context, storeSeq, adminSeq, itemSeq, version int64) error
+	Find(ctx context.Context, storeSeq int64, req request.FindItems) (*model.ItemPage, error)
+	Get(ctx context.Context, storeSeq int64, req request.GetItem) (*model.Item, error)
+	Search(ctx context.Context, storeSeq int64, req request.SearchItems) (*model.ItemSearchPage, error)
+	CheckDuplicated(ctx context.Context, storeSeq int64, barcode string) (bool, error)
+	Import(ctx context.Context, storeSeq, adminSeq int64, req request.ImportItems) (*model.ItemImportResult, error)
+	Export(ctx context.Context, storeSeq int64, fn func(item model.Item) error) error
+
+	// 휴지통
+	FindTrash(ctx context.Context, storeSeq, lastItemSeq int64, limit int) (model.Items, error)
+	Restore(ctx context.Context, storeSeq, adminSeq, itemSeq int64) error
+	PurgeDeleted(ctx context.Context) (int64, error)
+	RunPurger(ctx context.Context, interval time.Duration)
+
+	// 유통기한
+	HideExpired(ctx context.Context, batchSize int) (model.Items, error)
+
+	// 검색 색인
+	RebuildIndex(ctx context.Context) error
+	RunIndexer(ctx context.Context, interval time.Duration)
+	IndexStats(storeSeq int64) model.SearchIndexStats
+
+	// 변경 이력
+	History(ctx context.Context, storeSeq int64, req request.FindItemHistory) (model.ItemRevisions, error)
+	Prices(ctx context.Context, storeSeq int64, req request.FindItemPrices) (model.ItemPrices, error)
 }
 
+// defaultItemLimit 상품 리스트 기본 조회 개수
+const defaultItemLimit = 10
+
 type itemService struct {
-	repo repository.Repository
+	repo  repository.Repository
+	cfg   api.ItemConfig
+	index *search.Index
 }
 
-func NewItemService(repo repository.Repository) (ItemService, error) {
+// AnyItemVersion If-Match: * 처럼 현재 버전과 상관없이 수정, 삭제할 때 version 으로 사용한다
+const AnyItemVersion int64 = -1
+
+func NewItemService(repo repository.Repository, cfg api.ItemConfig) (ItemService, error) {
 	if valid.IsNil(repo) {
 		return nil, errors.New("repository is nil")
 	}
 
-	return &itemService{repo: repo}, nil
+	return &itemService{repo: repo, cfg: cfg.WithDefaults(), index: search.NewIndex()}, nil
 }
 
-func (s *itemService) Create(item request.CreateItem) error {
+func (s *itemService) Create(ctx context.Context, storeSeq, adminSeq int64, item request.CreateItem) error {
+	if storeSeq <= 0 {
+		return apierror.ErrInvalidStore
+	}
+
+	item.StoreSeq = storeSeq
 	if err := item.Validate(); err != nil {
 		return errors.WithStack(err)
 	}
 
-	if _, err := s.repo.Admin().Get(item.AdminSeq); err != nil {
-		return apierror.ErrInvalidAdmin
+	if _, err := s.repo.Store().Get(ctx, item.StoreSeq); err != nil {
+		return apierror.ErrInvalidStore
 	}
 
-	isDuplicated, err := s.CheckDuplicated(*item.Barcode)
+	var created *dao.Item
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) (err error) {
+		created, err = createItem(ctx, repo, adminSeq, item)
+		return err
+	})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	if isDuplicated {
-		return apierror.ErrDuplicatedItem
+	s.indexItem(*created)
+	return nil
+}
+
+// createItem 검증된 상품을 바코드 중복 확인 후 등록하고 이력을 남긴다
+func createItem(ctx context.Context, repo repository.Repository, adminSeq int64, item request.CreateItem) (*dao.Item, error) {
+	if err := checkItemCategory(ctx, repo, item.StoreSeq, *item.Category); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := checkBarcode(ctx, repo, item.StoreSeq, *item.Barcode); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := repo.Item().Create(ctx, item); err != nil {
+		return nil, duplicatedItemError(err)
 	}
 
-	if err := s.repo.Item().Create(item); err != nil {
+	created, err := repo.Item().GetByBarcode(ctx, item.StoreSeq, *item.Barcode)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get created item")
+	}
+
+	if err := recordRevision(ctx, repo, adminSeq, dao.ItemActionCreate, nil, *created); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := repo.ItemStock().Create(ctx, dao.NewItemStock(*created)); err != nil {
+		return nil, errors.Wrap(err, "failed to create item stock")
+	}
+
+	return created, nil
+}
+
+// recordRevision 같은 transaction 안에서 변경 전후의 상품으로 이력을 남긴다
+func recordRevision(ctx context.Context, repo repository.Repository, adminSeq int64, action dao.ItemAction, before *dao.Item, after dao.Item) error {
+	revision, err := dao.NewItemRevision(adminSeq, action, before, after)
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
+	if err := repo.ItemRevision().Create(ctx, *revision); err != nil {
+		return errors.Wrap(err, "failed to record item revision")
+	}
+
 	return nil
 }
 
-func (s *itemService) CheckDuplicated(barcode string) (bool, error) {
-	item, err := s.repo.Item().GetByBarcode(barcode)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return false, errors.WithStack(err)
+// CheckDuplicated 바코드는 매장 안에서만 중복될 수 없다
+func (s *itemService) CheckDuplicated(ctx context.Context, storeSeq int64, barcode string) (bool, error) {
+	err := checkBarcode(ctx, s.repo, storeSeq, barcode)
+	if errors.Is(err, apierror.ErrDuplicatedItem) || errors.Is(err, apierror.ErrDeletedItemBarcode) {
+		return true, nil
 	}
 
-	if !valid.IsNil(item) && item.ItemSeq > 0 {
-		return true, nil
+	if err != nil {
+		return false, errors.WithStack(err)
 	}
 
 	return false, nil
 }
 
-func (s *itemService) Update(item request.UpdateItem) error {
-	if err := item.Validate(); err != nil {
+// checkBarcode 휴지통의 상품도 영구 삭제되기 전까지 바코드를 점유한다
+func checkBarcode(ctx context.Context, repo repository.Repository, storeSeq int64, barcode string) error {
+	item, err := repo.Item().GetByBarcode(ctx, storeSeq, barcode)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.WithStack(err)
 	}
 
-	_, err := s.repo.Item().Get(item.ItemSeq)
+	if !valid.IsNil(item) && item.ItemSeq > 0 {
+		return apierror.ErrDuplicatedItem
+	}
+
+	deleted, err := repo.Item().GetDeletedByBarcode(ctx, storeSeq, barcode)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.WithStack(err)
 	}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return apierror.ErrNotExistItem
+	if !valid.IsNil(deleted) && deleted.ItemSeq > 0 {
+		return apierror.ErrDeletedItemBarcode
 	}
 
-	if err := s.repo.Item().Update(item); err != nil {
+	// 옵션의 바코드와도 겹치지 않아야 한다
+	option, err := repo.ItemOption().GetByBarcode(ctx, storeSeq, barcode)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.WithStack(err)
 	}
 
+	if !valid.IsNil(option) && option.OptionSeq > 0 {
+		return apierror.ErrDuplicatedItem
+	}
+
 	return nil
 }
 
-func (s *itemService) Delete(itemSeq int64) error {
-	if itemSeq <= 0 {
-		return apierror.ErrInvalidItem
+// duplicatedItemError 중복 확인 이후 동시에 같은 바코드가 등록된 경우 unique key 오류로 확인된다
+func duplicatedItemError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apierror.ErrDuplicatedItem
 	}
 
-	_, err := s.repo.Item().Get(itemSeq)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	return errors.WithStack(err)
+}
+
+// Update 조회한 이후 다른 요청이 먼저 수정했다면 ErrStaleItem 을 반환한다
+func (s *itemService) Update(ctx context.Context, storeSeq, adminSeq int64, item request.UpdateItem) error {
+	anyVersion := !valid.IsNil(item.Version) && *item.Version == AnyItemVersion
+	if anyVersion {
+		item.Version = nil
+	}
+
+	if err := item.Validate(); err != nil {
 		return errors.WithStack(err)
 	}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return apierror.ErrNotExistItem
+	if valid.IsNil(item.Version) && !anyVersion {
+		return apierror.ErrNilItemVersion
+	}
+
+	var updated *dao.Item
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		owned, err := getOwnedItem(ctx, repo, storeSeq, item.ItemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if anyVersion {
+			item.Version = &owned.Version
+		}
+
+		if owned.Version != *item.Version {
+			return apierror.ErrStaleItem
+		}
+
+		if !valid.IsNil(item.Barcode) && *item.Barcode != owned.Barcode {
+			if err := checkBarcode(ctx, repo, storeSeq, *item.Barcode); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(item.Category) && *item.Category != owned.Category {
+			if err := checkItemCategory(ctx, repo, storeSeq, *item.Category); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if err := repo.Item().Update(ctx, item); err != nil {
+			return duplicatedItemError(err)
+		}
+
+		if updated, err = repo.Item().Get(ctx, item.ItemSeq); err != nil {
+			return errors.Wrap(err, "failed to get updated item")
+		}
+
+		return recordRevision(ctx, repo, adminSeq, dao.ItemActionUpdate, owned, *updated)
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.indexItem(*updated)
+	return nil
+}
+
+// Delete 상품을 휴지통으로 옮긴다
+func (s *itemService) Delete(ctx context.Context, storeSeq, adminSeq, itemSeq, version int64) error {
+	switch {
+	case itemSeq <= 0:
+		return apierror.ErrInvalidItem
+	case version <= 0 && version != AnyItemVersion:
+		return apierror.ErrNilItemVersion
 	}
 
-	if err := s.repo.Item().Delete(itemSeq); err != nil {
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		owned, err := getOwnedItem(ctx, repo, storeSeq, itemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if version == AnyItemVersion {
+			version = owned.Version
+		}
+
+		if owned.Version != version {
+			return apierror.ErrStaleItem
+		}
+
+		if err := repo.Item().Delete(ctx, itemSeq, version); err != nil {
+			return errors.WithStack(err)
+		}
+
+		deleted, err := repo.Item().GetDeleted(ctx, itemSeq)
+		if err != nil {
+			return errors.Wrap(err, "failed to get deleted item")
+		}
+
+		return recordRevision(ctx, repo, adminSeq, dao.ItemActionDelete, owned, *deleted)
+	})
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
+	s.index.Remove(itemSeq)
 	return nil
 }
 
-func (s *itemService) Find(adminSeq, lastItemSeq int64, limit int) (model.Items, error) {
-	if adminSeq <= 0 {
-		return nil, apierror.ErrInvalidAdmin
+func (s *itemService) Find(ctx context.Context, storeSeq int64, req request.FindItems) (*model.ItemPage, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	after, err := decodeItemCursor(storeSeq, req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := s.repo.Store().Get(ctx, storeSeq); err != nil {
+		return nil, apierror.ErrInvalidStore
 	}
 
-	if _, err := s.repo.Admin().Get(adminSeq); err != nil {
-		return nil, apierror.ErrInvalidAdmin
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultItemLimit
 	}
 
-	daoItems, err := s.repo.Item().Find(adminSeq, lastItemSeq, limit)
+	filter := repository.ItemFilter{
+		StoreSeq:     storeSeq,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		MinCost:      req.MinCost,
+		MaxCost:      req.MaxCost,
+		ExpireFrom:   timeOrZero(req.ExpireAfter),
+		ExpireTo:     timeOrZero(req.ExpireBefore),
+		RegFrom:      timeOrZero(req.RegFrom),
+		RegTo:        timeOrZero(req.RegTo),
+		ModFrom:      timeOrZero(req.ModFrom),
+		ModTo:        timeOrZero(req.ModTo),
+		Availability: itemAvailability(req),
+		Sort:         req.Sort,
+		Asc:          req.Order == request.SortOrderAsc,
+		After:        after,
+		// 다음 페이지가 있는지 확인하기 위해 하나 더 조회한다
+		Limit: limit + 1,
+	}
+
+	// 카테고리 조건은 하위 카테고리의 상품도 포함한다
+	if !valid.IsNil(req.Category) {
+		categories, err := s.repo.Category().Find(ctx, storeSeq)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find categories")
+		}
+
+		if !slices.ContainsFunc(categories, func(c dao.Category) bool { return c.CategorySeq == *req.Category }) {
+			return nil, apierror.ErrUnknownCategory
+		}
+		filter.Categories = descendantCategories(categories, *req.Category)
+	}
+
+	if !valid.IsNil(req.Size) {
+		size := int(*req.Size)
+		filter.Size = &size
+	}
+
+	daoItems, err := s.repo.Item().Find(ctx, filter)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.Wrap(err, "failed to find item list")
 	}
 
-	result := make(model.Items, 0)
-	for _, item := range daoItems {
-		result = append(result, s.getItemFromDAO(item))
+	page, err := s.newItemPage(ctx, storeSeq, req, daoItems, limit)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return result, nil
+	if req.WithTotal {
+		total, err := s.repo.Item().Count(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count items")
+		}
+		page.Pagination.Total = &total
+	}
+
+	return page, nil
+}
+
+// itemAvailability 판매 중지된 상품은 기본으로 제외하며, OnlyUnavailable 이 IncludeUnavailable 보다 우선한다
+func itemAvailability(req request.FindItems) repository.ItemAvailability {
+	switch {
+	case req.OnlyUnavailable:
+		return repository.ItemAvailabilityUnavailable
+	case req.IncludeUnavailable:
+		return repository.ItemAvailabilityAll
+	default:
+		return repository.ItemAvailabilityAvailable
+	}
+}
+
+// newItemPage items 가 limit 보다 많으면 다음 페이지가 있으며 마지막 상품으로 cursor 를 만든다. 상품마다 재고를 함께 내려준다
+func (s *itemService) newItemPage(ctx context.Context, storeSeq int64, req request.FindItems, items dao.Items, limit int) (*model.ItemPage, error) {
+	page := &model.ItemPage{}
+	if len(items) > limit {
+		items = items[:limit]
+
+		nextCursor, err := encodeItemCursor(storeSeq, req, items[len(items)-1])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		page.Pagination = model.Pagination{NextCursor: nextCursor, HasMore: true}
+	}
+
+	stocks, err := findItemStocks(ctx, s.repo, items)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	page.Items = make(model.Items, 0, len(items))
+	for _, item := range items {
+		result := getItemFromDAO(item)
+		stock := getItemStockFromDAO(stocks[item.ItemSeq], s.cfg.LowStockThreshold)
+		result.Stock = &stock
+		page.Items = append(page.Items, result)
+	}
+
+	return page, nil
 }
 
-func (s *itemService) Get(itemSeq int64) (*model.Item, error) {
-	if itemSeq <= 0 {
-		return nil, apierror.ErrInvalidItem
+// Get 옵션 그룹과 옵션에 따른 가격 범위를 포함하며, 옵션을 선택하면 그 가격도 계산한다
+func (s *itemService) Get(ctx context.Context, storeSeq int64, req request.GetItem) (*model.Item, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	item, err := s.repo.Item().Get(itemSeq)
+	item, err := getOwnedItem(ctx, s.repo, storeSeq, req.ItemSeq)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	options, err := findItemOptions(ctx, s.repo, *item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stocks, err := findItemStocks(ctx, s.repo, dao.Items{*item})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := getItemFromDAO(*item)
+	result.OptionGroups = options.groupModels()
+
+	stock := getItemStockFromDAO(stocks[item.ItemSeq], s.cfg.LowStockThreshold)
+	result.Stock = &stock
+
+	priceRange := options.priceRange()
+	result.PriceRange = &priceRange
+
+	if len(req.OptionSeqs) > 0 {
+		if result.Quote, err = options.quote(req.OptionSeqs); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return &result, nil
+}
+
+// getOwnedItem 매장에 등록된 상품만 조회한다
+func getOwnedItem(ctx context.Context, repo repository.Repository, storeSeq, itemSeq int64) (*dao.Item, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	item, err := repo.Item().Get(ctx, itemSeq)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.Wrap(err, "failed to get item")
 	}
@@ -149,49 +474,256 @@ func (s *itemService) Get(itemSeq int64) (*model.Item, error) {
 		return nil, apierror.ErrNotExistItem
 	}
 
-	result := s.getItemFromDAO(*item)
+	if item.StoreSeq != storeSeq {
+		return nil, apierror.ErrForbiddenItem
+	}
 
-	return &result, nil
+	return item, nil
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if valid.IsNil(t) {
+		return time.Time{}
+	}
+
+	return *t
 }
 
-func (s *itemService) getItemFromDAO(item dao.Item) model.Item {
+func getItemFromDAO(item dao.Item) model.Item {
 	return model.Item{
 		ItemSeq:     item.ItemSeq,
-		AdminSeq:    item.AdminSeq,
-		Category:    int(item.Category),
+		StoreSeq:    item.StoreSeq,
+		Category:    item.Category,
 		Barcode:     item.Barcode,
 		Price:       item.Price,
 		Cost:        item.Cost,
+		Margin:      item.Price - item.Cost,
 		Name:        item.Name,
+		Consonant:   item.Consonant,
 		Description: item.Description,
 		ExpireDT:    item.ExpireDT,
 		Size:        int(item.Size),
+		Version:     item.Version,
 		RegDT:       item.RegDT,
 		ModDT:       item.ModDT,
+		DeletedDT:   item.DeletedDT,
+		Unavailable: item.UnavailableDT,
+	}
+}
+
+func (s *itemService) FindTrash(ctx context.Context, storeSeq, lastItemSeq int64, limit int) (model.Items, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
 	}
+
+	daoItems, err := s.repo.Item().FindDeleted(ctx, storeSeq, lastItemSeq, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find deleted items")
+	}
+
+	result := make(model.Items, 0)
+	for _, item := range daoItems {
+		result = append(result, getItemFromDAO(item))
+	}
+
+	return result, nil
 }
 
-func (s *itemService) Search(adminSeq int64, text string) (model.Items, error) {
+// Restore 휴지통의 상품을 복구한다
+func (s *itemService) Restore(ctx context.Context, storeSeq, adminSeq, itemSeq int64) error {
 	switch {
-	case adminSeq <= 0:
-		return nil, apierror.ErrInvalidAdmin
-	case text == "":
-		return nil, apierror.ErrNilSearchText
+	case storeSeq <= 0:
+		return apierror.ErrInvalidStore
+	case itemSeq <= 0:
+		return apierror.ErrInvalidItem
 	}
 
-	if _, err := s.repo.Admin().Get(adminSeq); err != nil {
-		return nil, apierror.ErrInvalidAdmin
+	var restored *dao.Item
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, err := repo.Item().GetDeleted(ctx, itemSeq)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "failed to get deleted item")
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierror.ErrNotExistDeletedItem
+		}
+
+		if item.StoreSeq != storeSeq {
+			return apierror.ErrForbiddenItem
+		}
+
+		if err := repo.Item().Restore(ctx, itemSeq); err != nil {
+			return errors.Wrap(err, "failed to restore item")
+		}
+
+		if restored, err = repo.Item().Get(ctx, itemSeq); err != nil {
+			return errors.Wrap(err, "failed to get restored item")
+		}
+
+		return recordRevision(ctx, repo, adminSeq, dao.ItemActionRestore, item, *restored)
+	})
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
-	daoItems, err := s.repo.Item().Search(adminSeq, text)
+	s.indexItem(*restored)
+	return nil
+}
+
+// PurgeDeleted 보관 기간이 지난 휴지통의 상품을 영구 삭제한다
+func (s *itemService) PurgeDeleted(ctx context.Context) (int64, error) {
+	purged, err := s.repo.Item().Purge(ctx, time.Now().Add(-s.cfg.TrashRetention))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to search")
+		return 0, errors.Wrap(err, "failed to purge deleted items")
 	}
 
-	result := make(model.Items, 0)
-	for _, item := range daoItems {
-		result = append(result, s.getItemFromDAO(item))
+	if _, err := s.repo.ItemOption().PurgeOrphans(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to purge options of deleted items")
+	}
+
+	if _, err := s.repo.ItemStock().PurgeOrphans(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to purge stocks of deleted items")
+	}
+
+	if _, err := s.repo.ItemLot().PurgeOrphans(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to purge lots of deleted items")
+	}
+
+	return purged, nil
+}
+
+// RunPurger ctx 가 종료될 때까지 interval 마다 PurgeDeleted 를 실행한다
+func (s *itemService) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.PurgeDeleted(ctx)
+			if err != nil {
+				logrus.Errorf("failed to purge deleted items: %+v", err)
+				continue
+			}
+
+			logrus.Debugf("purged %d deleted items", purged)
+		}
+	}
+}
+
+// History 휴지통의 상품도 이력을 조회할 수 있다
+func (s *itemService) History(ctx context.Context, storeSeq int64, req request.FindItemHistory) (model.ItemRevisions, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := checkItemStore(ctx, s.repo, storeSeq, req.ItemSeq); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	revisions, err := s.repo.ItemRevision().Find(ctx, req.ItemSeq, req.LastRevisionSeq, req.Limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item revisions")
+	}
+
+	result := make(model.ItemRevisions, 0, len(revisions))
+	for _, revision := range revisions {
+		changes := make(map[string]model.ItemChange, len(revision.Changes))
+		for column, change := range revision.Changes {
+			changes[column] = model.ItemChange{Before: change.Before, After: change.After}
+		}
+
+		result = append(result, model.ItemRevision{
+			RevisionSeq: revision.RevisionSeq,
+			ItemSeq:     revision.ItemSeq,
+			AdminSeq:    revision.AdminSeq,
+			Action:      string(revision.Action),
+			Version:     revision.Version,
+			Changes:     changes,
+			RegDT:       revision.RegDT,
+		})
 	}
 
 	return result, nil
 }
+
+// Prices 기간 동안의 가격 변경 내역, from 시점에 적용되어 있던 가격을 첫 번째로 포함한다
+func (s *itemService) Prices(ctx context.Context, storeSeq int64, req request.FindItemPrices) (model.ItemPrices, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := checkItemStore(ctx, s.repo, storeSeq, req.ItemSeq); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var from time.Time
+	if !valid.IsNil(req.From) {
+		from = *req.From
+	}
+
+	to := time.Now().Add(time.Second)
+	if !valid.IsNil(req.To) {
+		to = *req.To
+	}
+
+	revisions := make(dao.ItemRevisions, 0)
+	if !from.IsZero() {
+		initial, err := s.repo.ItemRevision().GetPriceAt(ctx, req.ItemSeq, from)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(err, "failed to get item price")
+		}
+
+		if err == nil {
+			revisions = append(revisions, *initial)
+		}
+	}
+
+	changes, err := s.repo.ItemRevision().FindPriceChanges(ctx, req.ItemSeq, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item price changes")
+	}
+	revisions = append(revisions, changes...)
+
+	result := make(model.ItemPrices, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, model.ItemPrice{
+			RevisionSeq: revision.RevisionSeq,
+			Price:       revision.Price,
+			Cost:        revision.Cost,
+			AdminSeq:    revision.AdminSeq,
+			ChangedDT:   revision.RegDT,
+		})
+	}
+
+	return result, nil
+}
+
+// checkItemStore 매장의 상품인지 확인한다. 휴지통의 상품도 포함한다
+func checkItemStore(ctx context.Context, repo repository.Repository, storeSeq, itemSeq int64) error {
+	if storeSeq <= 0 {
+		return apierror.ErrInvalidStore
+	}
+
+	item, err := repo.Item().Get(ctx, itemSeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		item, err = repo.Item().GetDeleted(ctx, itemSeq)
+	}
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, "failed to get item")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierror.ErrNotExistItem
+	}
+
+	if item.StoreSeq != storeSeq {
+		return apierror.ErrForbiddenItem
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/cursor"
+	"hello-cafe/internal/search"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// itemCursor 매장과 조회, 정렬 조건, 마지막 상품의 정렬 값. 서명하여 내려주므로 client 가 바꿀 수 없다
+type itemCursor struct {
+	StoreSeq int64            `json:"st"`
+	Filter   string           `json:"f"`
+	Sort     request.ItemSort `json:"s"`
+	Order    string           `json:"o"`
+	Value    string           `json:"v"`
+	ItemSeq  int64            `json:"i"`
+}
+
+// itemCursorFilter cursor 를 만든 요청의 조회 조건, 시각은 UTC 로 맞춘다
+type itemCursorFilter struct {
+	Category     *int64                      `json:"c,omitempty"`
+	Size         *request.ItemSize           `json:"sz,omitempty"`
+	MinPrice     *int64                      `json:"pn,omitempty"`
+	MaxPrice     *int64                      `json:"px,omitempty"`
+	MinCost      *int64                      `json:"cn,omitempty"`
+	MaxCost      *int64                      `json:"cx,omitempty"`
+	Periods      [6]time.Time                `json:"p"`
+	Availability repository.ItemAvailability `json:"a"`
+}
+
+// itemFilterHash 조회 조건이 같은 요청은 같은 값을 반환한다
+func itemFilterHash(req request.FindItems) (string, error) {
+	filter := itemCursorFilter{
+		Category:     req.Category,
+		Size:         req.Size,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		MinCost:      req.MinCost,
+		MaxCost:      req.MaxCost,
+		Availability: itemAvailability(req),
+	}
+	for i, t := range []*time.Time{req.ExpireAfter, req.ExpireBefore, req.RegFrom, req.RegTo, req.ModFrom, req.ModTo} {
+		filter.Periods[i] = timeOrZero(t).UTC()
+	}
+
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal item filter")
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+func encodeItemCursor(storeSeq int64, req request.FindItems, item dao.Item) (string, error) {
+	filter, err := itemFilterHash(req)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	c := itemCursor{StoreSeq: storeSeq, Filter: filter, Sort: req.Sort, Order: req.Order, ItemSeq: item.ItemSeq}
+	switch v := repository.ItemSortValue(req.Sort, item).(type) {
+	case int64:
+		c.Value = strconv.FormatInt(v, 10)
+	case string:
+		c.Value = v
+	case time.Time:
+		c.Value = v.Format(time.RFC3339Nano)
+	}
+
+	token, err := cursor.Encode(c)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return token, nil
+}
+
+// decodeItemCursor 다른 매장이나 조회, 정렬 조건이 다른 cursor 는 사용할 수 없다
+func decodeItemCursor(storeSeq int64, req request.FindItems) (*repository.ItemCursor, error) {
+	if req.Cursor == "" {
+		if req.LastItemSeq > 0 {
+			return &repository.ItemCursor{Value: req.LastItemSeq, ItemSeq: req.LastItemSeq}, nil
+		}
+		return nil, nil
+	}
+
+	c := itemCursor{}
+	if err := cursor.Decode(req.Cursor, &c); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	filter, err := itemFilterHash(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if c.StoreSeq != storeSeq || c.Filter != filter || c.Sort != req.Sort || c.Order != req.Order || c.ItemSeq <= 0 {
+		return nil, apierror.ErrInvalidCursor
+	}
+
+	after := &repository.ItemCursor{ItemSeq: c.ItemSeq}
+	switch repository.ItemSortValue(req.Sort, dao.Item{}).(type) {
+	case int64:
+		after.Value, err = strconv.ParseInt(c.Value, 10, 64)
+	case string:
+		after.Value = c.Value
+	case time.Time:
+		after.Value, err = time.Parse(time.RFC3339Nano, c.Value)
+	}
+	if err != nil {
+		return nil, apierror.ErrInvalidCursor
+	}
+
+	return after, nil
+}
+
+// itemSearchCursor 검색 결과의 마지막 상품 점수와 item_seq, 검색어가 같아야 한다
+type itemSearchCursor struct {
+	StoreSeq int64  `json:"st"`
+	Query    string `json:"q"`
+	Score    int    `json:"sc"`
+	ItemSeq  int64  `json:"i"`
+}
+
+func encodeItemSearchCursor(storeSeq int64, query search.Query, score int, itemSeq int64) (string, error) {
+	token, err := cursor.Encode(itemSearchCursor{StoreSeq: storeSeq, Query: query.String(), Score: score, ItemSeq: itemSeq})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return token, nil
+}
+
+func decodeItemSearchCursor(storeSeq int64, query search.Query, token string) (*itemSearchCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	c := itemSearchCursor{}
+	if err := cursor.Decode(token, &c); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if c.StoreSeq != storeSeq || c.Query != query.String() || c.ItemSeq <= 0 {
+		return nil, apierror.ErrInvalidCursor
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/notify"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// NotifyEventItemExpired 유통기한이 지나 판매 중지한 상품 알림
+const NotifyEventItemExpired = "item.expired"
+
+// ExpiryService 유통기한이 지난 상품을 판매 중지하고 알린다. 스케줄러가 주기적으로 Run 을 실행한다
+type ExpiryService interface {
+	Run(ctx context.Context) (*model.ItemExpiryResult, error)
+}
+
+type expiryService struct {
+	itemService ItemService
+	notifier    notify.Notifier
+	cfg         api.ExpiryConfig
+}
+
+func NewExpiryService(itemService ItemService, notifier notify.Notifier, cfg api.ExpiryConfig) (ExpiryService, error) {
+	if valid.IsNil(itemService) {
+		return nil, errors.New("item service is nil")
+	}
+
+	if valid.IsNil(notifier) {
+		return nil, errors.New("notifier is nil")
+	}
+
+	return &expiryService{itemService: itemService, notifier: notifier, cfg: cfg.WithDefaults()}, nil
+}
+
+// Run 판매 중지한 상품을 매장별로 한 번씩 알린다. 알림이 실패해도 판매 중지는 되돌리지 않으며, 실패한 매장 수를 결과에 담아 에러를 반환한다
+func (s *expiryService) Run(ctx context.Context) (*model.ItemExpiryResult, error) {
+	hidden, err := s.itemService.HideExpired(ctx, s.cfg.BatchSize)
+	result := &model.ItemExpiryResult{Hidden: len(hidden)}
+	if err != nil {
+		// 에러 전에 판매 중지한 상품도 알린다
+		logrus.Errorf("failed to hide expired items: %+v", err)
+	}
+
+	stores := make([]int64, 0)
+	byStore := make(map[int64]model.ExpiredItems)
+	for _, item := range hidden {
+		if _, ok := byStore[item.StoreSeq]; !ok {
+			stores = append(stores, item.StoreSeq)
+		}
+		byStore[item.StoreSeq] = append(byStore[item.StoreSeq], model.ExpiredItem{
+			ItemSeq:  item.ItemSeq,
+			Barcode:  item.Barcode,
+			Name:     item.Name,
+			ExpireDT: item.ExpireDT,
+		})
+	}
+	result.Stores = len(stores)
+
+	for _, storeSeq := range stores {
+		if notifyErr := s.notifier.Notify(ctx, newExpiredNotification(storeSeq, byStore[storeSeq])); notifyErr != nil {
+			logrus.Errorf("failed to notify expired items of store(%d): %+v", storeSeq, notifyErr)
+			result.NotifyFailures++
+			continue
+		}
+		result.Notified++
+	}
+
+	if err != nil {
+		return result, errors.WithStack(err)
+	}
+
+	if result.NotifyFailures > 0 {
+		return result, errors.Errorf("failed to notify %d stores", result.NotifyFailures)
+	}
+
+	return result, nil
+}
+
+func newExpiredNotification(storeSeq int64, items model.ExpiredItems) notify.Notification {
+	lines := make([]string, 0, len(items)+1)
+	lines = append(lines, fmt.Sprintf("매장 %d 의 유통기한이 지난 상품을 판매 중지했습니다. 유통기한을 수정하면 다시 판매합니다.", storeSeq))
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("- %s (바코드 %s, 유통기한 %s)", item.Name, item.Barcode, item.ExpireDT.Format("2006-01-02 15:04")))
+	}
+
+	return notify.Notification{
+		Event:     NotifyEventItemExpired,
+		StoreSeq:  storeSeq,
+		Subject:   fmt.Sprintf("유통기한이 지난 상품 %d개 판매 중지", len(items)),
+		Message:   strings.Join(lines, "\n"),
+		Data:      items,
+		CreatedAt: time.Now(),
+	}
+}
+
+// HideExpired 모든 매장에서 유통기한이 지난 상품을 batchSize 개씩 판매 중지하고 검색 색인에서 뺀다.
+// 다른 서버가 먼저 판매 중지한 상품은 결과에 담지 않는다
+func (s *itemService) HideExpired(ctx context.Context, batchSize int) (model.Items, error) {
+	if batchSize <= 0 {
+		return nil, errors.Errorf("invalid batch size(%d)", batchSize)
+	}
+
+	now := time.Now()
+	result := make(model.Items, 0)
+	for {
+		items, err := s.repo.Item().FindExpired(ctx, now, batchSize)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to find expired items")
+		}
+
+		for i := range items {
+			item := items[i]
+
+			var hidden *dao.Item
+			err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+				if err := repo.Item().MarkUnavailable(ctx, item.ItemSeq, now); err != nil {
+					return errors.WithStack(err)
+				}
+
+				var err error
+				if hidden, err = repo.Item().Get(ctx, item.ItemSeq); err != nil {
+					return errors.Wrap(err, "failed to get hidden item")
+				}
+
+				// 스케줄러가 판매 중지하므로 관리자는 0 으로 남긴다
+				return recordRevision(ctx, repo, 0, dao.ItemActionHide, &item, *hidden)
+			})
+			if errors.Is(err, apierror.ErrStaleItem) {
+				continue
+			}
+			if err != nil {
+				return result, errors.WithStack(err)
+			}
+
+			s.index.Remove(item.ItemSeq)
+			result = append(result, getItemFromDAO(*hidden))
+		}
+
+		// 판매 중지한 상품은 다음 조회에서 빠지므로 매번 처음부터 조회한다
+		if len(items) < batchSize {
+			return result, nil
+		}
+	}
+}
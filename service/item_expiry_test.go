@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/notify"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository/dao"
+)
+
+// testNotifier 받은 알림을 모으며, err 가 있으면 실패한다
+type testNotifier struct {
+	notifications []notify.Notification
+	err           error
+}
+
+func (n *testNotifier) Notify(ctx context.Context, notification notify.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func Test_expiryService(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	items, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 본점은 우유와 샌드위치, 지점은 케이크의 유통기한이 지났다
+	expired := time.Now().Add(-time.Hour)
+	stores, categories := make([]int64, 0, 2), make(map[int64]int64, 2)
+	for _, name := range []string{"본점", "지점"} {
+		store, err := repo.Store().Create(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stores = append(stores, store.StoreSeq)
+		categories[store.StoreSeq] = newTestCategory(t, repo, store.StoreSeq)
+	}
+	for _, tt := range []struct {
+		storeSeq      int64
+		barcode, name string
+		expired       bool
+	}{
+		{storeSeq: stores[0], barcode: "8801", name: "우유", expired: true},
+		{storeSeq: stores[0], barcode: "8802", name: "우유 식빵"},
+		{storeSeq: stores[0], barcode: "8803", name: "샌드위치", expired: true},
+		{storeSeq: stores[1], barcode: "8801", name: "케이크", expired: true},
+	} {
+		item := newTestItem(categories[tt.storeSeq], tt.barcode, tt.name)
+		if tt.expired {
+			item.ExpireDT = &expired
+		}
+		if err := items.Create(ctx, tt.storeSeq, 1, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := items.RebuildIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := &testNotifier{}
+	s, err := NewExpiryService(items, notifier, api.ExpiryConfig{BatchSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (model.ItemExpiryResult{Hidden: 3, Stores: 2, Notified: 2}); *got != want {
+		t.Errorf("Run() = %+v, want %+v", *got, want)
+	}
+
+	// 매장마다 한 번씩 판매 중지한 상품을 알린다
+	if len(notifier.notifications) != 2 {
+		t.Fatalf("notifications = %+v", notifier.notifications)
+	}
+	first := notifier.notifications[0]
+	if hidden, ok := first.Data.(model.ExpiredItems); first.Event != NotifyEventItemExpired || first.StoreSeq != stores[0] || !ok || len(hidden) != 2 || hidden[0].Name != "우유" {
+		t.Errorf("notification = %+v", first)
+	}
+
+	// 판매 중지된 상품은 리스트에서 기본으로 제외하고, 요청하면 포함하거나 골라서 조회한다
+	findTests := []struct {
+		name string
+		req  request.FindItems
+		want []string
+	}{
+		{name: "기본", req: request.FindItems{}, want: []string{"8802"}},
+		{name: "판매 중지 포함", req: request.FindItems{IncludeUnavailable: true}, want: []string{"8803", "8802", "8801"}},
+		{name: "판매 중지만", req: request.FindItems{OnlyUnavailable: true}, want: []string{"8803", "8801"}},
+		{name: "판매 중지만 우선", req: request.FindItems{IncludeUnavailable: true, OnlyUnavailable: true}, want: []string{"8803", "8801"}},
+	}
+	for _, tt := range findTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.WithTotal = true
+			page, err := items.Find(ctx, stores[0], tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, 0, len(page.Items))
+			for _, item := range page.Items {
+				got = append(got, item.Barcode)
+			}
+			if !reflect.DeepEqual(got, tt.want) || *page.Pagination.Total != int64(len(tt.want)) {
+				t.Errorf("Find() = %v, total = %d, want %v", got, *page.Pagination.Total, tt.want)
+			}
+		})
+	}
+
+	// 내보내기는 판매 중지된 상품도 포함한다
+	exported := make([]string, 0)
+	if err := items.Export(ctx, stores[0], func(item model.Item) error {
+		exported = append(exported, item.Barcode)
+		return nil
+	}); err != nil || !reflect.DeepEqual(exported, []string{"8803", "8802", "8801"}) {
+		t.Errorf("Export() = %v, error = %v", exported, err)
+	}
+
+	// 검색에서는 제외하고, 상세 조회는 할 수 있다
+
+	page, err := items.Search(ctx, stores[0], request.SearchItems{Text: "우유"})
+	if err != nil || len(page.Items) != 1 || page.Items[0].Barcode != "8802" {
+		t.Errorf("Search() = %+v, error = %v", page, err)
+	}
+
+	milk, err := repo.Item().GetByBarcode(ctx, stores[0], "8801")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := items.Get(ctx, stores[0], request.GetItem{ItemSeq: milk.ItemSeq})
+	if err != nil || item.Unavailable == nil {
+		t.Errorf("Get() = %+v, error = %v", item, err)
+	}
+
+	// 판매 중지도 버전을 올리고 관리자 0 의 hide 이력을 남긴다
+	history, err := items.History(ctx, stores[0], request.FindItemHistory{ItemSeq: milk.ItemSeq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Action != string(dao.ItemActionHide) || history[0].AdminSeq != 0 || history[0].Version != 2 || milk.Version != 2 {
+		t.Fatalf("History() = %+v, version = %d", history, milk.Version)
+	}
+	if _, ok := history[0].Changes["unavailable_dt"]; !ok {
+		t.Errorf("History() changes = %+v", history[0].Changes)
+	}
+
+	// 이미 판매 중지한 상품은 다시 알리지 않는다
+	if got, err := s.Run(ctx); err != nil || got.Hidden != 0 || len(notifier.notifications) != 2 {
+		t.Errorf("Run() = %+v, error = %v, notifications = %d", got, err, len(notifier.notifications))
+	}
+
+	// 유통기한을 늘리면 다시 판매한다
+	expireDT := time.Now().Add(24 * time.Hour)
+	if err := items.Update(ctx, stores[0], 1, request.UpdateItem{ItemSeq: milk.ItemSeq, ExpireDT: &expireDT, Version: &milk.Version}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err = items.Search(ctx, stores[0], request.SearchItems{Text: "우유"})
+	if err != nil || len(page.Items) != 2 {
+		t.Errorf("Search() = %+v, error = %v", page, err)
+	}
+
+	// 알림이 실패해도 판매 중지는 유지하고 실패한 매장 수를 남긴다
+	yogurt := newTestItem(categories[stores[0]], "8804", "요거트")
+	yogurt.ExpireDT = &expired
+	if err := items.Create(ctx, stores[0], 1, yogurt); err != nil {
+		t.Fatal(err)
+	}
+
+	failed, err := NewExpiryService(items, &testNotifier{err: errors.New("webhook responded 500")}, api.ExpiryConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = failed.Run(ctx)
+	if want := (model.ItemExpiryResult{Hidden: 1, Stores: 1, NotifyFailures: 1}); err == nil || *got != want {
+		t.Errorf("Run() = %+v, error = %v, want %+v", *got, err, want)
+	}
+
+	found, err := findItems(ctx, items, stores[0])
+	if err != nil || len(found) != 2 {
+		t.Errorf("Find() = %+v, error = %v", found, err)
+	}
+}
+
+func Test_schedulerService(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	items, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry, err := NewExpiryService(items, &testNotifier{}, api.ExpiryConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSchedulerService(expiry, api.ExpiryConfig{Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 시작하자마자 한 번 실행한다
+	s.Start(ctx)
+	deadline := time.Now().Add(time.Second)
+	for s.Status()[0].Runs == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := s.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	status := s.Status()
+	if len(status) != 1 || status[0].Name != JobItemExpiry || status[0].Runs != 1 || status[0].IntervalMillis != time.Hour.Milliseconds() || status[0].NextRunDT == nil {
+		t.Fatalf("Status() = %+v", status)
+	}
+	if result, ok := status[0].LastResult.(*model.ItemExpiryResult); !ok || result.Hidden != 0 {
+		t.Errorf("Status() last result = %+v", status[0].LastResult)
+	}
+}
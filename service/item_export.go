@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/repository"
+)
+
+// itemExportBatchSize 한 번에 조회하는 상품 수
+const itemExportBatchSize = 500
+
+// Export 매장의 상품을 item_seq 역순으로 나누어 조회하며 한 건씩 fn 에 넘긴다.
+// 판매 중지된 상품도 내보내며, 전체 상품을 메모리에 올리지 않고 fn 이 오류를 반환하면 중단한다
+func (s *itemService) Export(ctx context.Context, storeSeq int64, fn func(item model.Item) error) error {
+	if storeSeq <= 0 {
+		return apierror.ErrInvalidStore
+	}
+
+	if _, err := s.repo.Store().Get(ctx, storeSeq); err != nil {
+		return apierror.ErrInvalidStore
+	}
+
+	lastItemSeq := int64(0)
+	for {
+		filter := repository.ItemFilter{StoreSeq: storeSeq, Availability: repository.ItemAvailabilityAll, Limit: itemExportBatchSize}
+		if lastItemSeq > 0 {
+			filter.After = &repository.ItemCursor{Value: lastItemSeq, ItemSeq: lastItemSeq}
+		}
+
+		items, err := s.repo.Item().Find(ctx, filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to find items for export")
+		}
+
+		for _, item := range items {
+			if err := fn(getItemFromDAO(item)); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if len(items) < itemExportBatchSize {
+			return nil
+		}
+		lastItemSeq = items[len(items)-1].ItemSeq
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+)
+
+func Test_itemService_Export(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, barcode := range []string{"8801", "8802", "8803"} {
+		if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, barcode, "아메리카노")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, store.StoreSeq, 1, items[0].ItemSeq, items[0].Version); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("삭제된 상품을 제외하고 마진과 초성을 포함", func(t *testing.T) {
+		got := make([]model.Item, 0)
+		if err := s.Export(ctx, store.StoreSeq, func(item model.Item) error {
+			got = append(got, item)
+			return nil
+		}); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+
+		if len(got) != 2 || got[0].Barcode != "8802" || got[1].Barcode != "8801" {
+			t.Fatalf("Export() items = %v", got)
+		}
+
+		if got[0].Margin != 3000 || got[0].Consonant != "ㅇㅁㄹㅋㄴ" {
+			t.Errorf("Export() margin = %d, consonant = %s", got[0].Margin, got[0].Consonant)
+		}
+	})
+
+	t.Run("fn 이 오류를 반환하면 중단", func(t *testing.T) {
+		stop := errors.New("stop")
+		count := 0
+		err := s.Export(ctx, store.StoreSeq, func(item model.Item) error {
+			count++
+			return stop
+		})
+		if !errors.Is(err, stop) || count != 1 {
+			t.Errorf("Export() error = %v, count = %d", err, count)
+		}
+	})
+
+	t.Run("존재하지 않는 매장", func(t *testing.T) {
+		err := s.Export(ctx, store.StoreSeq+100, func(item model.Item) error { return nil })
+		if !errors.Is(err, apierror.ErrInvalidStore) {
+			t.Errorf("Export() error = %v, want %v", err, apierror.ErrInvalidStore)
+		}
+	})
+}
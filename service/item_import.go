@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// errImportRollback 등록하지 않기 위해 transaction 을 rollback 할 때 사용한다
+var errImportRollback = errors.New("rollback item import")
+
+// Import CSV 의 행마다 상품 등록과 같은 검증과 바코드 중복 확인을 거친다.
+// 이미 등록된 바코드는 건너뛰며, 값이 잘못된 행이 있으면 all_or_nothing 은 모두 등록하지 않는다.
+// dry run 은 실제로 등록한 뒤 rollback 하므로 파일 안의 바코드 중복도 확인된다.
+func (s *itemService) Import(ctx context.Context, storeSeq, adminSeq int64, req request.ImportItems) (*model.ItemImportResult, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := s.repo.Store().Get(ctx, storeSeq); err != nil {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	rows := make([]model.ItemImportRow, len(req.Rows))
+	created := make(dao.Items, 0, len(req.Rows))
+	importRows := func(repo repository.Repository, perRowTx bool) error {
+		for i, row := range req.Rows {
+			if !perRowTx {
+				result, item, err := importRow(ctx, repo, storeSeq, adminSeq, row)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				rows[i] = result
+				if item != nil {
+					created = append(created, *item)
+				}
+				continue
+			}
+
+			// 행마다 상품과 이력을 함께 등록하고, 등록된 상품은 바로 검색 색인에 반영한다
+			var item *dao.Item
+			if err := repo.WithTx(ctx, func(tx repository.Repository) (err error) {
+				rows[i], item, err = importRow(ctx, tx, storeSeq, adminSeq, row)
+				return err
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			if item != nil {
+				s.indexItem(*item)
+			}
+		}
+
+		return nil
+	}
+
+	applied := false
+	if req.Mode == request.ItemImportBestEffort && !req.DryRun {
+		if err := importRows(s.repo, true); err != nil {
+			return nil, errors.Wrap(err, "failed to import items")
+		}
+		applied = true
+	} else {
+		err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+			if err := importRows(repo, false); err != nil {
+				return errors.WithStack(err)
+			}
+
+			if req.DryRun || countImportRows(rows, model.ItemImportInvalid) > 0 {
+				return errImportRollback
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, errImportRollback) {
+			return nil, errors.Wrap(err, "failed to import items")
+		}
+		applied = err == nil
+		if applied {
+			for _, item := range created {
+				s.indexItem(item)
+			}
+		}
+	}
+
+	if !applied {
+		for i := range rows {
+			if rows[i].Status == model.ItemImportCreated {
+				rows[i].Status = model.ItemImportReady
+			}
+		}
+	}
+
+	return &model.ItemImportResult{
+		DryRun:     req.DryRun,
+		Mode:       string(req.Mode),
+		Applied:    applied,
+		Created:    countImportRows(rows, model.ItemImportCreated),
+		Duplicated: countImportRows(rows, model.ItemImportDuplicated),
+		Invalid:    countImportRows(rows, model.ItemImportInvalid),
+		Rows:       rows,
+	}, nil
+}
+
+// importRow 행의 결과와 등록된 상품을 반환하며, DB 오류처럼 행과 관계없는 실패만 error 로 반환한다
+func importRow(ctx context.Context, repo repository.Repository, storeSeq, adminSeq int64, row request.ItemImportRow) (model.ItemImportRow, *dao.Item, error) {
+	result := model.ItemImportRow{Line: row.Line}
+	if row.Item.Barcode != nil {
+		result.Barcode = *row.Item.Barcode
+	}
+	if row.Item.Name != nil {
+		result.Name = *row.Item.Name
+	}
+
+	item := row.Item
+	item.StoreSeq = storeSeq
+
+	err := row.Err
+	if err == nil {
+		err = item.Validate()
+	}
+
+	var created *dao.Item
+	if err == nil {
+		created, err = createItem(ctx, repo, adminSeq, item)
+	}
+
+	switch {
+	case err == nil:
+		result.Status = model.ItemImportCreated
+	case errors.Is(err, apierror.ErrDuplicatedItem), errors.Is(err, apierror.ErrDeletedItemBarcode):
+		result.Status = model.ItemImportDuplicated
+		result.Message = importMessage(err)
+	default:
+		if _, ok := apierror.IsAPIError(err); !ok {
+			return result, nil, errors.WithStack(err)
+		}
+		result.Status = model.ItemImportInvalid
+		result.Message = importMessage(err)
+	}
+
+	return result, created, nil
+}
+
+func importMessage(err error) string {
+	if apiErr, ok := apierror.IsAPIError(err); ok {
+		return apiErr.Message()
+	}
+
+	return err.Error()
+}
+
+func countImportRows(rows []model.ItemImportRow, status model.ItemImportStatus) int {
+	count := 0
+	for _, row := range rows {
+		if row.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
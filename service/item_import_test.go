@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+// testItemCSV category 는 매장에 있는 카테고리 번호
+func testItemCSV(category int64) string {
+	return fmt.Sprintf("\ufeffbarcode,name,category,size,price,cost,description,expire_dt\n"+
+		"8801,아메리카노,%[1]d,0,4500,1500,,2030-01-01\n"+
+		"8802,라떼,%[1]d,1,5000,2000,우유,2030-01-01T09:00:00+09:00\n"+
+		"8801,아메리카노 중복,%[1]d,0,4500,1500,,2030-01-01\n"+
+		"8803,가격 오류,%[1]d,0,abc,1500,,2030-01-01\n"+
+		"8804,,%[1]d,0,4500,1500,,2030-01-01\n"+
+		"9000,등록된 상품,%[1]d,0,3000,1000,,2030-01-01\n"+
+		"8805,없는 카테고리,%[2]d,0,3000,1000,,2030-01-01\n", category, category+100)
+}
+
+func Test_itemService_Import(t *testing.T) {
+	ctx := context.Background()
+
+	type want struct {
+		applied  bool
+		statuses []model.ItemImportStatus
+		items    int
+	}
+	tests := []struct {
+		name   string
+		dryRun bool
+		mode   request.ItemImportMode
+		want   want
+	}{
+		{
+			name: "all_or_nothing 은 잘못된 행이 있으면 등록하지 않음",
+			mode: request.ItemImportAllOrNothing,
+			want: want{
+				applied: false,
+				statuses: []model.ItemImportStatus{
+					model.ItemImportReady, model.ItemImportReady, model.ItemImportDuplicated,
+					model.ItemImportInvalid, model.ItemImportInvalid, model.ItemImportDuplicated, model.ItemImportInvalid,
+				},
+				items: 1,
+			},
+		},
+		{
+			name: "best_effort 는 잘못된 행과 중복만 건너뜀",
+			mode: request.ItemImportBestEffort,
+			want: want{
+				applied: true,
+				statuses: []model.ItemImportStatus{
+					model.ItemImportCreated, model.ItemImportCreated, model.ItemImportDuplicated,
+					model.ItemImportInvalid, model.ItemImportInvalid, model.ItemImportDuplicated, model.ItemImportInvalid,
+				},
+				items: 3,
+			},
+		},
+		{
+			name:   "dry run 은 등록하지 않고 결과만 확인",
+			dryRun: true,
+			mode:   request.ItemImportBestEffort,
+			want: want{
+				applied: false,
+				statuses: []model.ItemImportStatus{
+					model.ItemImportReady, model.ItemImportReady, model.ItemImportDuplicated,
+					model.ItemImportInvalid, model.ItemImportInvalid, model.ItemImportDuplicated, model.ItemImportInvalid,
+				},
+				items: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			store, err := repo.Store().Create(ctx, "카페")
+			if err != nil {
+				t.Fatal(err)
+			}
+			category := newTestCategory(t, repo, store.StoreSeq)
+
+			s, err := NewItemService(repo, api.ItemConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "9000", "등록된 상품")); err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := request.ParseItemCSV(strings.NewReader(testItemCSV(category)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := s.Import(ctx, store.StoreSeq, 1, request.ImportItems{DryRun: tt.dryRun, Mode: tt.mode, Rows: rows})
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+
+			if result.Applied != tt.want.applied {
+				t.Errorf("Import() applied = %v, want %v", result.Applied, tt.want.applied)
+			}
+
+			if len(result.Rows) != len(tt.want.statuses) {
+				t.Fatalf("Import() rows = %v, want %d rows", result.Rows, len(tt.want.statuses))
+			}
+			for i, row := range result.Rows {
+				if row.Line != i+2 || row.Status != tt.want.statuses[i] {
+					t.Errorf("Import() row = %+v, want line %d status %s", row, i+2, tt.want.statuses[i])
+				}
+			}
+
+			if result.Invalid != 3 || result.Duplicated != 2 {
+				t.Errorf("Import() invalid = %d, duplicated = %d", result.Invalid, result.Duplicated)
+			}
+
+			items, err := findItems(ctx, s, store.StoreSeq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != tt.want.items {
+				t.Errorf("Find() items = %d, want %d", len(items), tt.want.items)
+			}
+		})
+	}
+}
+
+func Test_itemService_Import_rows(t *testing.T) {
+	ctx := context.Background()
+
+	const header = "barcode,name,category,size,price,cost,description,expire_dt\n"
+
+	type want struct {
+		status  model.ItemImportStatus
+		message string
+	}
+	tests := []struct {
+		name    string
+		rows    string
+		want    []want
+		wantErr error
+	}{
+		{
+			name: "등록 가능한 행",
+			rows: "8801,아메리카노,{category},0,4500,1500,,2030-01-01\n",
+			want: []want{{status: model.ItemImportCreated}},
+		},
+		{
+			name: "내보내기 형식의 유통기한",
+			rows: "8801,아메리카노,{category},0,4500,1500,,2030-01-01 09:00:00\n",
+			want: []want{{status: model.ItemImportCreated}},
+		},
+		{
+			name: "숫자가 아닌 가격",
+			rows: "8801,아메리카노,{category},0,abc,1500,,2030-01-01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrInvalidPrice.Message()}},
+		},
+		{
+			name: "숫자가 아닌 원가",
+			rows: "8801,아메리카노,{category},0,4500,abc,,2030-01-01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrInvalidCost.Message()}},
+		},
+		{
+			name: "이름 누락",
+			rows: "8801,,{category},0,4500,1500,,2030-01-01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrNilName.Message()}},
+		},
+		{
+			name: "잘못된 유통기한",
+			rows: "8801,아메리카노,{category},0,4500,1500,,2030/01/01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrInvalidExpireDT.Message()}},
+		},
+		{
+			name: "잘못된 사이즈",
+			rows: "8801,아메리카노,{category},L,4500,1500,,2030-01-01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrInvalidSize.Message()}},
+		},
+		{
+			name: "매장에 없는 카테고리",
+			rows: "8801,아메리카노,999,0,4500,1500,,2030-01-01\n",
+			want: []want{{status: model.ItemImportInvalid, message: apierror.ErrUnknownCategory.Message()}},
+		},
+		{
+			name: "이미 등록된 바코드",
+			rows: "9000,등록된 상품,{category},0,3000,1000,,2030-01-01\n",
+			want: []want{{status: model.ItemImportDuplicated, message: apierror.ErrDuplicatedItem.Message()}},
+		},
+		{
+			name: "파일 안에서 중복된 바코드",
+			rows: "8801,아메리카노,{category},0,4500,1500,,2030-01-01\n" +
+				"8801,아메리카노 중복,{category},0,4500,1500,,2030-01-01\n",
+			want: []want{
+				{status: model.ItemImportCreated},
+				{status: model.ItemImportDuplicated, message: apierror.ErrDuplicatedItem.Message()},
+			},
+		},
+		{
+			name:    "등록할 행 없음",
+			rows:    "",
+			wantErr: apierror.ErrEmptyImportFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			store, err := repo.Store().Create(ctx, "카페")
+			if err != nil {
+				t.Fatal(err)
+			}
+			category := newTestCategory(t, repo, store.StoreSeq)
+
+			s, err := NewItemService(repo, api.ItemConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "9000", "등록된 상품")); err != nil {
+				t.Fatal(err)
+			}
+
+			csv := header + strings.ReplaceAll(tt.rows, "{category}", strconv.FormatInt(category, 10))
+			rows, err := request.ParseItemCSV(strings.NewReader(csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := s.Import(ctx, store.StoreSeq, 1, request.ImportItems{Mode: request.ItemImportBestEffort, Rows: rows})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(result.Rows) != len(tt.want) {
+				t.Fatalf("Import() rows = %+v, want %d rows", result.Rows, len(tt.want))
+			}
+
+			for i, row := range result.Rows {
+				if row.Status != tt.want[i].status || row.Message != tt.want[i].message {
+					t.Errorf("Import() row = %+v, want status %s message %q", row, tt.want[i].status, tt.want[i].message)
+				}
+			}
+		})
+	}
+}
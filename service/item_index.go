@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"hello-cafe/internal/search"
+	"hello-cafe/model"
+	"hello-cafe/repository/dao"
+)
+
+// itemIndexBatchSize 검색 색인을 만들 때 한 번에 읽는 상품 개수
+const itemIndexBatchSize = 1000
+
+// RebuildIndex 휴지통을 제외한 모든 상품으로 검색 색인을 다시 만든다. 만드는 동안에는 이전 색인 또는 DB 로 검색한다
+func (s *itemService) RebuildIndex(ctx context.Context) error {
+	err := s.index.Rebuild(func(add func(storeSeq, itemSeq int64, doc search.Document)) error {
+		var after int64
+		for {
+			items, err := s.repo.Item().Scan(ctx, after, itemIndexBatchSize)
+			if err != nil {
+				return errors.Wrap(err, "failed to scan items")
+			}
+
+			for _, item := range items {
+				add(item.StoreSeq, item.ItemSeq, itemDocument(item))
+			}
+
+			if len(items) < itemIndexBatchSize {
+				return nil
+			}
+			after = items[len(items)-1].ItemSeq
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to rebuild item index")
+	}
+
+	return nil
+}
+
+// RunIndexer 바로 검색 색인을 만들고, 다른 서버에서 바뀐 상품도 반영되도록 ctx 가 종료될 때까지 interval 마다 다시 만든다
+func (s *itemService) RunIndexer(ctx context.Context, interval time.Duration) {
+	rebuild := func() {
+		if err := s.RebuildIndex(ctx); err != nil {
+			logrus.Errorf("failed to rebuild item index: %+v", err)
+			return
+		}
+
+		stats := s.index.Stats()
+		logrus.Debugf("rebuilt item index with %d items in %s", stats.Documents, stats.RebuildDuration)
+	}
+
+	rebuild()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rebuild()
+		}
+	}
+}
+
+// IndexStats 매장의 색인 상태, 다른 매장의 상품 수는 알려주지 않는다
+func (s *itemService) IndexStats(storeSeq int64) model.SearchIndexStats {
+	stats := s.index.StoreStats(storeSeq)
+
+	result := model.SearchIndexStats{
+		Ready:         stats.Ready,
+		Items:         stats.Documents,
+		Terms:         stats.Terms,
+		Postings:      stats.Postings,
+		MemoryBytes:   stats.MemoryBytes,
+		RebuildMillis: stats.RebuildDuration.Milliseconds(),
+	}
+	if !stats.RebuiltAt.IsZero() {
+		result.RebuiltDT = &stats.RebuiltAt
+	}
+
+	return result
+}
+
+// indexItem 변경이 commit 된 뒤에 호출한다
+func (s *itemService) indexItem(item dao.Item) {
+	// 판매 중지된 상품은 검색하지 않는다
+	if item.UnavailableDT != nil {
+		s.index.Remove(item.ItemSeq)
+		return
+	}
+
+	s.index.Put(item.StoreSeq, item.ItemSeq, itemDocument(item))
+}
+
+func itemDocument(item dao.Item) search.Document {
+	return search.Document{Name: item.Name, Description: item.Description}
+}
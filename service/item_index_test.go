@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"hello-cafe/internal/api"
+	"hello-cafe/model/request"
+)
+
+func Test_itemService_Search_index(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.IndexStats(store.StoreSeq).Ready {
+		t.Fatalf("IndexStats().Ready before RebuildIndex() = true, want false")
+	}
+
+	if err := s.RebuildIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 색인을 만든 뒤의 등록, 수정, 삭제도 검색 결과에 반영된다
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8802", "카페라떼")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8803", "아이스 아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latte, iced, americano := items[1], items[0], items[2]
+
+	name := "바닐라 라떼"
+	if err := s.Update(ctx, store.StoreSeq, 1, request.UpdateItem{ItemSeq: latte.ItemSeq, Name: &name, Version: &latte.Version}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, store.StoreSeq, 1, americano.ItemSeq, americano.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []int64
+	}{
+		{name: "등록한 상품", text: "아메리카노", want: []int64{iced.ItemSeq}},
+		{name: "수정한 이름", text: "바닐라", want: []int64{latte.ItemSeq}},
+		{name: "수정 전 이름", text: "카페", want: []int64{}},
+		{name: "초성", text: "ㅂㄴㄹ", want: []int64{latte.ItemSeq}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.Search(ctx, store.StoreSeq, request.SearchItems{Text: tt.text})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]int64, 0)
+			for _, item := range page.Items {
+				got = append(got, item.ItemSeq)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 다른 매장의 상품은 색인 상태에 포함하지 않는다
+	other, err := repo.Store().Create(ctx, "다른 카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(ctx, other.StoreSeq, 1, newTestItem(newTestCategory(t, repo, other.StoreSeq), "8801", "녹차")); err != nil {
+		t.Fatal(err)
+	}
+
+	statsTests := []struct {
+		name      string
+		storeSeq  int64
+		wantItems int
+	}{
+		{name: "매장", storeSeq: store.StoreSeq, wantItems: 2},
+		{name: "다른 매장", storeSeq: other.StoreSeq, wantItems: 1},
+		{name: "상품이 없는 매장", storeSeq: other.StoreSeq + 1, wantItems: 0},
+	}
+	for _, tt := range statsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := s.IndexStats(tt.storeSeq)
+			if !stats.Ready || stats.Items != tt.wantItems || stats.RebuiltDT == nil {
+				t.Errorf("IndexStats() = %+v, want items %d", stats, tt.wantItems)
+			}
+			if tt.wantItems == 0 && (stats.Terms != 0 || stats.MemoryBytes != 0) {
+				t.Errorf("IndexStats() = %+v, want empty", stats)
+			}
+		})
+	}
+}
+
+func Test_itemService_Search_staleIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 6; i++ {
+		if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, fmt.Sprintf("880%d", i), fmt.Sprintf("아메리카노 %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.RebuildIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 다른 서버에서 앞쪽 두 상품을 삭제하고, 한 상품의 이름을 바꿔 이 서버의 색인에는 아직 남아 있다
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items[:2] {
+		if err := repo.Item().Delete(ctx, item.ItemSeq, item.Version); err != nil {
+			t.Fatal(err)
+		}
+	}
+	name := "녹차"
+	if err := repo.Item().Update(ctx, request.UpdateItem{ItemSeq: items[3].ItemSeq, Name: &name, Version: &items[3].Version}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		withTotal   bool
+		want        [][]int64
+		wantHasMore []bool
+	}{
+		{
+			name:        "남은 상품으로 페이지를 채운다",
+			want:        [][]int64{{items[2].ItemSeq, items[4].ItemSeq}, {items[5].ItemSeq}},
+			wantHasMore: []bool{true, false},
+		},
+		{
+			name:        "전체 개수는 제외한 상품을 빼고 센다",
+			withTotal:   true,
+			want:        [][]int64{{items[2].ItemSeq, items[4].ItemSeq}, {items[5].ItemSeq}},
+			wantHasMore: []bool{true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursor string
+			for i, want := range tt.want {
+				page, err := s.Search(ctx, store.StoreSeq, request.SearchItems{Text: "아메리카노", Limit: 2, Cursor: cursor, WithTotal: tt.withTotal})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := make([]int64, 0, len(page.Items))
+				for _, item := range page.Items {
+					got = append(got, item.ItemSeq)
+				}
+				if !reflect.DeepEqual(got, want) || page.Pagination.HasMore != tt.wantHasMore[i] {
+					t.Errorf("Search() page %d = %v, has more = %v, want %v", i, got, page.Pagination.HasMore, want)
+				}
+				if tt.withTotal && (page.Pagination.Total == nil || *page.Pagination.Total != 3) {
+					t.Errorf("Search() page %d total = %v, want 3", i, page.Pagination.Total)
+				}
+
+				cursor = page.Pagination.NextCursor
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+// CreateLot lot 을 만들고 입고 변동으로 수량을 더한다
+func (s *stockService) CreateLot(ctx context.Context, storeSeq, adminSeq int64, req request.CreateItemLot) (*model.ItemLot, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	receivedDT := time.Now()
+	if req.ReceivedDT != nil {
+		receivedDT = *req.ReceivedDT
+	}
+
+	var result model.ItemLot
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, err := getOwnedItem(ctx, repo, storeSeq, req.ItemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		lot, err := dao.NewItemLot(*item, *req.LotNumber, receivedDT, *req.ExpireDT)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		created, err := repo.ItemLot().Create(ctx, *lot)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apierror.ErrDuplicatedLot
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to create item lot")
+		}
+
+		movement := dao.StockMovement{
+			AdminSeq: adminSeq,
+			Type:     dao.StockMovementReceive,
+			Quantity: *req.Quantity,
+			Reason:   req.Reason,
+			LotSeq:   &created.LotSeq,
+		}
+		if _, err := moveStock(ctx, repo, *item, movement); err != nil {
+			return errors.WithStack(err)
+		}
+
+		received, err := repo.ItemLot().Get(ctx, created.LotSeq)
+		if err != nil {
+			return errors.Wrap(err, "failed to get received lot")
+		}
+
+		result = getItemLotFromDAO(*received, time.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+// Lots 남은 수량이 있는 lot 을 차감 순서(유통기한 순서)로 조회한다
+func (s *stockService) Lots(ctx context.Context, storeSeq int64, req request.FindItemLots) (model.ItemLots, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := getOwnedItem(ctx, s.repo, storeSeq, req.ItemSeq); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lots, err := s.repo.ItemLot().FindRemaining(ctx, req.ItemSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item lots")
+	}
+
+	now := time.Now()
+	result := make(model.ItemLots, 0, len(lots))
+	for _, lot := range lots {
+		result = append(result, getItemLotFromDAO(lot, now))
+	}
+
+	return result, nil
+}
+
+// Consume 판매, 폐기 수량을 lot 에서 차감한다. 원장은 차감한 lot 마다 남는다
+func (s *stockService) Consume(ctx context.Context, storeSeq, adminSeq int64, req request.ConsumeItemLots) (model.StockMovements, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.Move(ctx, storeSeq, adminSeq, req.Movement())
+}
+
+// Expiring 기간 안에 유통기한이 끝나는 lot 을 유통기한 순서로 조회한다. 이미 지난 lot 도 폐기할 수 있도록 포함한다
+func (s *stockService) Expiring(ctx context.Context, storeSeq int64, req request.FindExpiringLots) (model.ExpiringLots, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	now := time.Now()
+	lots, err := s.repo.ItemLot().FindExpiring(ctx, storeSeq, now.Add(req.Within))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find expiring lots")
+	}
+
+	itemSeqs := make([]int64, 0, len(lots))
+	for _, lot := range lots {
+		itemSeqs = append(itemSeqs, lot.ItemSeq)
+	}
+
+	items, err := s.repo.Item().FindBySeqs(ctx, storeSeq, itemSeqs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find expiring items")
+	}
+
+	byItemSeq := make(map[int64]dao.Item, len(items))
+	for _, item := range items {
+		byItemSeq[item.ItemSeq] = item
+	}
+
+	result := make(model.ExpiringLots, 0, len(lots))
+	for _, lot := range lots {
+		item, ok := byItemSeq[lot.ItemSeq]
+		if !ok {
+			continue
+		}
+
+		result = append(result, model.ExpiringLot{
+			ItemLot: getItemLotFromDAO(lot, now),
+			Barcode: item.Barcode,
+			Name:    item.Name,
+		})
+	}
+
+	return result, nil
+}
+
+func getItemLotFromDAO(lot dao.ItemLot, now time.Time) model.ItemLot {
+	return model.ItemLot{
+		LotSeq:     lot.LotSeq,
+		ItemSeq:    lot.ItemSeq,
+		LotNumber:  lot.LotNumber,
+		Quantity:   lot.Quantity,
+		Remaining:  lot.Remaining,
+		ReceivedDT: lot.ReceivedDT,
+		ExpireDT:   lot.ExpireDT,
+		Expired:    lot.Expired(now),
+	}
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model/request"
+)
+
+// lotFixture 유통기한이 지난 L0 2개, 하루 남은 L1 5개, 사흘 남은 L2 5개, lot 이 없는 재고 3개가 있는 상품
+type lotFixture struct {
+	s        StockService
+	storeSeq int64
+	itemSeq  int64
+	now      time.Time
+	lots     map[string]int64 // lot 번호별 lot seq
+}
+
+func newLotFixture(t *testing.T) lotFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(newTestCategory(t, repo, store.StoreSeq), "8801", "우유")); err != nil {
+		t.Fatal(err)
+	}
+	item, err := repo.Item().GetByBarcode(ctx, store.StoreSeq, "8801")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewStockService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := lotFixture{s: s, storeSeq: store.StoreSeq, itemSeq: item.ItemSeq, now: time.Now(), lots: map[string]int64{}}
+	for _, l := range []struct {
+		number     string
+		quantity   int64
+		receivedDT time.Time
+		expireDT   time.Time
+	}{
+		{number: "L0", quantity: 2, receivedDT: f.now.Add(-48 * time.Hour), expireDT: f.now.Add(-time.Hour)},
+		{number: "L1", quantity: 5, receivedDT: f.now, expireDT: f.now.Add(24 * time.Hour)},
+		{number: "L2", quantity: 5, receivedDT: f.now, expireDT: f.now.Add(72 * time.Hour)},
+	} {
+		number, quantity, receivedDT, expireDT := l.number, l.quantity, l.receivedDT, l.expireDT
+		lot, err := s.CreateLot(ctx, f.storeSeq, 1, request.CreateItemLot{
+			ItemSeq: f.itemSeq, LotNumber: &number, Quantity: &quantity, ReceivedDT: &receivedDT, ExpireDT: &expireDT,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.lots[number] = lot.LotSeq
+	}
+
+	if _, err := s.Move(ctx, f.storeSeq, 1, request.CreateStockMovement{ItemSeq: f.itemSeq, Type: request.StockMovementReceive, Quantity: quantity(3)}); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+// names lot seq 를 lot 번호로 바꾼다. lot 이 없는 재고는 "" 이다
+func (f lotFixture) names(lotSeqs []int64) []string {
+	byLotSeq := make(map[int64]string, len(f.lots))
+	for number, lotSeq := range f.lots {
+		byLotSeq[lotSeq] = number
+	}
+
+	names := make([]string, 0, len(lotSeqs))
+	for _, lotSeq := range lotSeqs {
+		names = append(names, byLotSeq[lotSeq])
+	}
+	return names
+}
+
+func quantity(q int64) *int64 { return &q }
+
+func stringPtr(s string) *string { return &s }
+
+func Test_stockService_CreateLot(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		number     *string
+		quantity   *int64
+		receivedDT time.Duration // 현재 시각 기준
+		expireDT   time.Duration // 현재 시각 기준
+		wantErr    error
+	}{
+		{
+			name:     "입고",
+			number:   stringPtr("L3"),
+			quantity: quantity(4),
+			expireDT: 72 * time.Hour,
+		},
+		{
+			name:     "같은 lot 번호",
+			number:   stringPtr("L1"),
+			quantity: quantity(1),
+			expireDT: time.Hour,
+			wantErr:  apierror.ErrDuplicatedLot,
+		},
+		{
+			name:     "lot 번호 누락",
+			quantity: quantity(1),
+			expireDT: time.Hour,
+			wantErr:  apierror.ErrNilLotNumber,
+		},
+		{
+			name:     "0 개 입고",
+			number:   stringPtr("L3"),
+			quantity: quantity(0),
+			expireDT: time.Hour,
+			wantErr:  apierror.ErrInvalidQuantity,
+		},
+		{
+			name:       "입고일보다 빠른 유통기한",
+			number:     stringPtr("L3"),
+			quantity:   quantity(1),
+			receivedDT: 24 * time.Hour,
+			expireDT:   time.Hour,
+			wantErr:    apierror.ErrInvalidLotExpireDT,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newLotFixture(t)
+
+			receivedDT, expireDT := f.now.Add(tt.receivedDT), f.now.Add(tt.expireDT)
+			lot, err := f.s.CreateLot(ctx, f.storeSeq, 1, request.CreateItemLot{
+				ItemSeq: f.itemSeq, LotNumber: tt.number, Quantity: tt.quantity, ReceivedDT: &receivedDT, ExpireDT: &expireDT,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateLot() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if lot.LotNumber != *tt.number || lot.Remaining != *tt.quantity {
+				t.Errorf("CreateLot() = %+v", lot)
+			}
+
+			stock, err := f.s.Get(ctx, f.storeSeq, request.GetItemStock{ItemSeq: f.itemSeq})
+			if want := 15 + *tt.quantity; err != nil || stock.Quantity != want {
+				t.Errorf("Get() = %+v, error = %v, want quantity %d", stock, err, want)
+			}
+		})
+	}
+}
+
+func Test_stockService_Consume(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		req     request.ConsumeItemLots
+		lot     string   // 지정한 lot 번호
+		want    []string // 차감한 lot 번호, lot 이 없는 재고는 ""
+		wantErr error
+	}{
+		{
+			name: "판매는 유통기한이 지난 lot 을 건너뛴다",
+			req:  request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(7)},
+			want: []string{"L1", "L2"},
+		},
+		{
+			name: "폐기는 유통기한이 지난 lot 부터",
+			req:  request.ConsumeItemLots{Type: request.StockMovementWaste, Quantity: quantity(3), Reason: "유통기한 경과"},
+			want: []string{"L0", "L1"},
+		},
+		{
+			name: "lot 이 모자라면 lot 이 없는 재고에서 차감",
+			req:  request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(12)},
+			want: []string{"L1", "L2", ""},
+		},
+		{
+			name:    "lot 과 lot 이 없는 재고보다 많은 판매",
+			req:     request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(14)},
+			wantErr: apierror.ErrInsufficientStock,
+		},
+		{
+			name: "지정한 lot 에서 차감",
+			req:  request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(2)},
+			lot:  "L2",
+			want: []string{"L2"},
+		},
+		{
+			name:    "지정한 lot 의 남은 수량 부족",
+			req:     request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(6)},
+			lot:     "L2",
+			wantErr: apierror.ErrInsufficientLot,
+		},
+		{
+			name:    "없는 lot",
+			req:     request.ConsumeItemLots{Type: request.StockMovementSell, Quantity: quantity(1), LotSeq: 100},
+			wantErr: apierror.ErrNotExistLot,
+		},
+		{
+			name:    "판매, 폐기만 차감",
+			req:     request.ConsumeItemLots{Type: request.StockMovementReceive, Quantity: quantity(1)},
+			wantErr: apierror.ErrInvalidStockType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newLotFixture(t)
+
+			tt.req.ItemSeq = f.itemSeq
+			if tt.lot != "" {
+				tt.req.LotSeq = f.lots[tt.lot]
+			}
+
+			got, err := f.s.Consume(ctx, f.storeSeq, 1, tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Consume() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lotSeqs := make([]int64, 0, len(got))
+			for _, movement := range got {
+				var lotSeq int64
+				if movement.LotSeq != nil {
+					lotSeq = *movement.LotSeq
+				}
+				lotSeqs = append(lotSeqs, lotSeq)
+			}
+			if names := f.names(lotSeqs); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Consume() lots = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stockService_Expiring(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		within      time.Duration
+		want        []string
+		wantExpired []bool
+		wantErr     error
+	}{
+		{
+			name:        "기본 48시간",
+			want:        []string{"L0", "L1"},
+			wantExpired: []bool{true, false},
+		},
+		{
+			name:        "96시간",
+			within:      96 * time.Hour,
+			want:        []string{"L0", "L1", "L2"},
+			wantExpired: []bool{true, false, false},
+		},
+		{
+			name:        "유통기한이 지난 lot 만",
+			within:      time.Nanosecond,
+			want:        []string{"L0"},
+			wantExpired: []bool{true},
+		},
+		{
+			name:    "음수 기간",
+			within:  -time.Hour,
+			wantErr: apierror.ErrInvalidPeriod,
+		},
+		{
+			name:    "최대 기간 초과",
+			within:  31 * 24 * time.Hour,
+			wantErr: apierror.ErrInvalidPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newLotFixture(t)
+
+			got, err := f.s.Expiring(ctx, f.storeSeq, request.FindExpiringLots{Within: tt.within})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expiring() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lotSeqs := make([]int64, 0, len(got))
+			expired := make([]bool, 0, len(got))
+			for _, lot := range got {
+				if lot.Name != "우유" {
+					t.Errorf("Expiring() name = %v, want 우유", lot.Name)
+				}
+				lotSeqs = append(lotSeqs, lot.LotSeq)
+				expired = append(expired, lot.Expired)
+			}
+			if names := f.names(lotSeqs); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Expiring() lots = %v, want %v", names, tt.want)
+			}
+			if !reflect.DeepEqual(expired, tt.wantExpired) {
+				t.Errorf("Expiring() expired = %v, want %v", expired, tt.wantExpired)
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type ItemOptionService interface {
+	Find(ctx context.Context, storeSeq, itemSeq int64) (model.ItemOptionGroups, error)
+
+	// 옵션 그룹
+	CreateGroup(ctx context.Context, storeSeq int64, req request.CreateItemOptionGroup) (*model.ItemOptionGroup, error)
+	UpdateGroup(ctx context.Context, storeSeq int64, req request.UpdateItemOptionGroup) (*model.ItemOptionGroup, error)
+	DeleteGroup(ctx context.Context, storeSeq int64, req request.DeleteItemOptionGroup) error
+
+	// 옵션
+	Create(ctx context.Context, storeSeq int64, req request.CreateItemOption) (*model.ItemOption, error)
+	Update(ctx context.Context, storeSeq int64, req request.UpdateItemOption) (*model.ItemOption, error)
+	Delete(ctx context.Context, storeSeq int64, req request.DeleteItemOption) error
+}
+
+type itemOptionService struct {
+	repo repository.Repository
+}
+
+func NewItemOptionService(repo repository.Repository) (ItemOptionService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &itemOptionService{repo: repo}, nil
+}
+
+func (s *itemOptionService) Find(ctx context.Context, storeSeq, itemSeq int64) (model.ItemOptionGroups, error) {
+	if itemSeq <= 0 {
+		return nil, apierror.ErrInvalidItem
+	}
+
+	item, err := getOwnedItem(ctx, s.repo, storeSeq, itemSeq)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	options, err := findItemOptions(ctx, s.repo, *item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return options.groupModels(), nil
+}
+
+// CreateGroup 함께 입력한 옵션도 같은 transaction 에서 등록한다
+func (s *itemOptionService) CreateGroup(ctx context.Context, storeSeq int64, req request.CreateItemOptionGroup) (*model.ItemOptionGroup, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *model.ItemOptionGroup
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, err := getOwnedItem(ctx, repo, storeSeq, req.ItemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		minSelect, maxSelect := req.SelectRange()
+		group, err := dao.NewItemOptionGroup(*item, *req.Name, minSelect, maxSelect, req.DisplayOrder)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		created, err := repo.ItemOption().CreateGroup(ctx, *group)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apierror.ErrDuplicatedOptions
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to create item option group")
+		}
+
+		for _, option := range req.Options {
+			if _, err := createItemOption(ctx, repo, *created, option); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		options, err := findItemOptions(ctx, repo, *item)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		result = options.groupModel(created.OptionGroupSeq)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *itemOptionService) UpdateGroup(ctx context.Context, storeSeq int64, req request.UpdateItemOptionGroup) (*model.ItemOptionGroup, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *model.ItemOptionGroup
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, group, err := getOwnedOptionGroup(ctx, repo, storeSeq, req.ItemSeq, req.OptionGroupSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if !valid.IsNil(req.Name) {
+			if group.Name, err = dao.OptionName(*req.Name); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(req.DisplayOrder) {
+			group.DisplayOrder = *req.DisplayOrder
+		}
+
+		group.MinSelect, group.MaxSelect = req.SelectRange(group.MinSelect, group.MaxSelect)
+		if err := dao.OptionSelect(group.MinSelect, group.MaxSelect); err != nil {
+			return errors.WithStack(err)
+		}
+
+		err = repo.ItemOption().UpdateGroup(ctx, *group)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apierror.ErrDuplicatedOptions
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to update item option group")
+		}
+
+		options, err := findItemOptions(ctx, repo, *item)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		result = options.groupModel(group.OptionGroupSeq)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// DeleteGroup 옵션 그룹의 옵션도 함께 삭제한다
+func (s *itemOptionService) DeleteGroup(ctx context.Context, storeSeq int64, req request.DeleteItemOptionGroup) error {
+	if err := req.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		if _, _, err := getOwnedOptionGroup(ctx, repo, storeSeq, req.ItemSeq, req.OptionGroupSeq); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := repo.ItemOption().DeleteGroup(ctx, req.OptionGroupSeq); err != nil {
+			return errors.Wrap(err, "failed to delete item option group")
+		}
+
+		return nil
+	})
+}
+
+func (s *itemOptionService) Create(ctx context.Context, storeSeq int64, req request.CreateItemOption) (*model.ItemOption, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result model.ItemOption
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, group, err := getOwnedOptionGroup(ctx, repo, storeSeq, req.ItemSeq, req.OptionGroupSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		created, err := createItemOption(ctx, repo, *group, req)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		result = getItemOptionFromDAO(*item, *created)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+func (s *itemOptionService) Update(ctx context.Context, storeSeq int64, req request.UpdateItemOption) (*model.ItemOption, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result model.ItemOption
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, option, err := getOwnedOption(ctx, repo, storeSeq, req.ItemSeq, req.OptionGroupSeq, req.OptionSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if !valid.IsNil(req.Name) {
+			if option.Name, err = dao.OptionName(*req.Name); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(req.PriceDelta) {
+			option.PriceDelta = *req.PriceDelta
+		}
+
+		if !valid.IsNil(req.CostDelta) {
+			option.CostDelta = *req.CostDelta
+		}
+
+		if !valid.IsNil(req.Barcode) {
+			if option.Barcode, err = dao.OptionBarcode(req.Barcode); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(req.SKU) {
+			if option.SKU, err = dao.OptionSKU(req.SKU); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if !valid.IsNil(req.DisplayOrder) {
+			option.DisplayOrder = *req.DisplayOrder
+		}
+
+		if err := checkItemOption(ctx, repo, *option); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := repo.ItemOption().Update(ctx, *option); err != nil {
+			return duplicatedOptionError(err)
+		}
+
+		result = getItemOptionFromDAO(*item, *option)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+func (s *itemOptionService) Delete(ctx context.Context, storeSeq int64, req request.DeleteItemOption) error {
+	if err := req.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		if _, _, err := getOwnedOption(ctx, repo, storeSeq, req.ItemSeq, req.OptionGroupSeq, req.OptionSeq); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := repo.ItemOption().Delete(ctx, req.OptionSeq); err != nil {
+			return errors.Wrap(err, "failed to delete item option")
+		}
+
+		return nil
+	})
+}
+
+func createItemOption(ctx context.Context, repo repository.Repository, group dao.ItemOptionGroup, req request.CreateItemOption) (*dao.ItemOption, error) {
+	option, err := dao.NewItemOption(group, *req.Name, req.PriceDelta, req.CostDelta, req.Barcode, req.SKU, req.DisplayOrder)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := checkItemOption(ctx, repo, *option); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	created, err := repo.ItemOption().Create(ctx, *option)
+	if err != nil {
+		return nil, duplicatedOptionError(err)
+	}
+
+	return created, nil
+}
+
+// checkItemOption 옵션 그룹 안의 이름과 매장 안의 바코드를 확인한다. 옵션 바코드는 상품 바코드(휴지통 포함)와도 겹치지 않아야 한다
+func checkItemOption(ctx context.Context, repo repository.Repository, option dao.ItemOption) error {
+	options, err := repo.ItemOption().Find(ctx, option.ItemSeq)
+	if err != nil {
+		return errors.Wrap(err, "failed to find item options")
+	}
+
+	for _, o := range options {
+		if o.OptionSeq != option.OptionSeq && o.OptionGroupSeq == option.OptionGroupSeq && o.Name == option.Name {
+			return apierror.ErrDuplicatedOption
+		}
+	}
+
+	if valid.IsNil(option.Barcode) {
+		return nil
+	}
+
+	barcode := *option.Barcode
+	exists, err := repo.ItemOption().GetByBarcode(ctx, option.StoreSeq, barcode)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WithStack(err)
+	}
+	if err == nil && exists.OptionSeq != option.OptionSeq {
+		return apierror.ErrDuplicatedOptionKey
+	}
+
+	if _, err := repo.Item().GetByBarcode(ctx, option.StoreSeq, barcode); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WithStack(duplicatedKey(err))
+	}
+
+	if _, err := repo.Item().GetDeletedByBarcode(ctx, option.StoreSeq, barcode); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WithStack(duplicatedKey(err))
+	}
+
+	return nil
+}
+
+// duplicatedKey 조회에 성공했으면 이미 있는 바코드이다
+func duplicatedKey(err error) error {
+	if err == nil {
+		return apierror.ErrDuplicatedOptionKey
+	}
+
+	return err
+}
+
+// duplicatedOptionError 이름은 미리 확인하므로 unique key 오류는 바코드나 SKU 중복이다
+func duplicatedOptionError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apierror.ErrDuplicatedOptionKey
+	}
+
+	return errors.Wrap(err, "failed to save item option")
+}
+
+// getOwnedOptionGroup 매장 상품의 옵션 그룹만 조회한다
+func getOwnedOptionGroup(ctx context.Context, repo repository.Repository, storeSeq, itemSeq, optionGroupSeq int64) (*dao.Item, *dao.ItemOptionGroup, error) {
+	item, err := getOwnedItem(ctx, repo, storeSeq, itemSeq)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	group, err := repo.ItemOption().GetGroup(ctx, optionGroupSeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && group.ItemSeq != item.ItemSeq) {
+		return nil, nil, apierror.ErrNotExistOption
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get item option group")
+	}
+
+	return item, group, nil
+}
+
+// getOwnedOption 매장 상품의 옵션 그룹에 속한 옵션만 조회한다
+func getOwnedOption(ctx context.Context, repo repository.Repository, storeSeq, itemSeq, optionGroupSeq, optionSeq int64) (*dao.Item, *dao.ItemOption, error) {
+	item, group, err := getOwnedOptionGroup(ctx, repo, storeSeq, itemSeq, optionGroupSeq)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	option, err := repo.ItemOption().Get(ctx, optionSeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && option.OptionGroupSeq != group.OptionGroupSeq) {
+		return nil, nil, apierror.ErrNotExistOption
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get item option")
+	}
+
+	return item, option, nil
+}
+
+// itemOptions 상품과 상품의 옵션 그룹, 옵션으로 가격을 계산한다
+type itemOptions struct {
+	item    dao.Item
+	groups  dao.ItemOptionGroups
+	options dao.ItemOptions
+}
+
+func findItemOptions(ctx context.Context, repo repository.Repository, item dao.Item) (*itemOptions, error) {
+	groups, err := repo.ItemOption().FindGroups(ctx, item.ItemSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item option groups")
+	}
+
+	options, err := repo.ItemOption().Find(ctx, item.ItemSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item options")
+	}
+
+	return &itemOptions{item: item, groups: groups, options: options}, nil
+}
+
+func (o *itemOptions) groupModels() model.ItemOptionGroups {
+	groups := make(model.ItemOptionGroups, 0, len(o.groups))
+	for _, group := range o.groups {
+		groups = append(groups, o.toModel(group))
+	}
+
+	return groups
+}
+
+func (o *itemOptions) groupModel(optionGroupSeq int64) *model.ItemOptionGroup {
+	for _, group := range o.groups {
+		if group.OptionGroupSeq == optionGroupSeq {
+			result := o.toModel(group)
+			return &result
+		}
+	}
+
+	return nil
+}
+
+func (o *itemOptions) toModel(group dao.ItemOptionGroup) model.ItemOptionGroup {
+	result := model.ItemOptionGroup{
+		OptionGroupSeq: group.OptionGroupSeq,
+		Name:           group.Name,
+		Required:       group.Required(),
+		MultiSelect:    group.MultiSelect(),
+		MinSelect:      group.MinSelect,
+		MaxSelect:      group.MaxSelect,
+		DisplayOrder:   group.DisplayOrder,
+		Options:        make(model.ItemOptions, 0),
+	}
+
+	for _, option := range o.options {
+		if option.OptionGroupSeq == group.OptionGroupSeq {
+			result.Options = append(result.Options, getItemOptionFromDAO(o.item, option))
+		}
+	}
+
+	return result
+}
+
+// priceRange 옵션 그룹마다 최소 선택 개수만큼은 반드시 고르고, 최대 선택 개수까지 가격을 낮추거나 높이는 옵션을 더 고른다
+func (o *itemOptions) priceRange() model.ItemPriceRange {
+	result := model.ItemPriceRange{Min: o.item.Price, Max: o.item.Price}
+	for _, group := range o.groups {
+		deltas := make([]int64, 0)
+		for _, option := range o.options {
+			if option.OptionGroupSeq == group.OptionGroupSeq {
+				deltas = append(deltas, option.PriceDelta)
+			}
+		}
+
+		sort.Slice(deltas, func(i, j int) bool { return deltas[i] < deltas[j] })
+		result.Min += pickDeltas(group, deltas, func(delta int64) bool { return delta < 0 })
+
+		slices.Reverse(deltas)
+		result.Max += pickDeltas(group, deltas, func(delta int64) bool { return delta > 0 })
+	}
+
+	return result
+}
+
+// pickDeltas 정렬된 deltas 를 앞에서부터 최소 선택 개수만큼 고르고, 이후에는 worth 인 동안 최대 선택 개수까지 고른다
+func pickDeltas(group dao.ItemOptionGroup, deltas []int64, worth func(delta int64) bool) int64 {
+	var sum int64
+	for i, delta := range deltas {
+		if i >= group.MinSelect && (!worth(delta) || (group.MaxSelect > 0 && i >= group.MaxSelect)) {
+			break
+		}
+		sum += delta
+	}
+
+	return sum
+}
+
+// quote 선택한 옵션이 모두 상품의 옵션이고 옵션 그룹마다 선택 개수가 맞아야 한다
+func (o *itemOptions) quote(optionSeqs []int64) (*model.ItemQuote, error) {
+	result := &model.ItemQuote{OptionSeqs: optionSeqs, Price: o.item.Price, Cost: o.item.Cost}
+
+	selected := make(map[int64]int, len(o.groups))
+	for i, optionSeq := range optionSeqs {
+		if slices.Contains(optionSeqs[:i], optionSeq) {
+			return nil, apierror.ErrInvalidSelection
+		}
+
+		index := slices.IndexFunc(o.options, func(option dao.ItemOption) bool { return option.OptionSeq == optionSeq })
+		if index < 0 {
+			return nil, apierror.ErrNotExistOption
+		}
+
+		option := o.options[index]
+		selected[option.OptionGroupSeq]++
+		result.Price += option.PriceDelta
+		result.Cost += option.CostDelta
+	}
+
+	for _, group := range o.groups {
+		if !group.AllowSelect(selected[group.OptionGroupSeq]) {
+			return nil, apierror.ErrInvalidSelection
+		}
+	}
+
+	result.Margin = result.Price - result.Cost
+	return result, nil
+}
+
+func getItemOptionFromDAO(item dao.Item, option dao.ItemOption) model.ItemOption {
+	result := model.ItemOption{
+		OptionSeq:    option.OptionSeq,
+		Name:         option.Name,
+		PriceDelta:   option.PriceDelta,
+		CostDelta:    option.CostDelta,
+		Price:        item.Price + option.PriceDelta,
+		Cost:         item.Cost + option.CostDelta,
+		DisplayOrder: option.DisplayOrder,
+	}
+
+	if !valid.IsNil(option.Barcode) {
+		result.Barcode = *option.Barcode
+	}
+
+	if !valid.IsNil(option.SKU) {
+		result.SKU = *option.SKU
+	}
+
+	return result
+}
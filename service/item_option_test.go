@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+func Test_itemOptionService(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	items, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 가격 4500, 원가 1500
+	if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+	item, err := repo.Item().GetByBarcode(ctx, store.StoreSeq, "8801")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewItemOptionService(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := func(name string) *string { return &name }
+	option := func(n string, priceDelta int64) request.CreateItemOption {
+		return request.CreateItemOption{Name: name(n), PriceDelta: priceDelta, CostDelta: priceDelta / 5}
+	}
+
+	size, err := s.CreateGroup(ctx, store.StoreSeq, request.CreateItemOptionGroup{
+		ItemSeq:  item.ItemSeq,
+		Name:     name("사이즈"),
+		Required: true,
+		Options:  []request.CreateItemOption{option("Tall", 0), option("Grande", 500), option("Venti", 1000)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !size.Required || size.MultiSelect || len(size.Options) != 3 || size.Options[2].Price != 5500 {
+		t.Fatalf("CreateGroup() = %+v", size)
+	}
+
+	maxSelect := 2
+	extra, err := s.CreateGroup(ctx, store.StoreSeq, request.CreateItemOptionGroup{
+		ItemSeq:     item.ItemSeq,
+		Name:        name("추가"),
+		MultiSelect: true,
+		MaxSelect:   &maxSelect,
+		Options:     []request.CreateItemOption{option("샷", 500), option("시럽", 300), option("휘핑", 700), option("연하게", -200)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 사이즈는 하나를 반드시, 추가는 두 개까지 고를 수 있다
+	got, err := items.Get(ctx, store.StoreSeq, request.GetItem{ItemSeq: item.ItemSeq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (model.ItemPriceRange{Min: 4300, Max: 6700}); !reflect.DeepEqual(*got.PriceRange, want) || len(got.OptionGroups) != 2 {
+		t.Errorf("Get() price range = %+v, want %+v", got.PriceRange, want)
+	}
+
+	grande, shot, syrup, whip := size.Options[1].OptionSeq, extra.Options[0].OptionSeq, extra.Options[1].OptionSeq, extra.Options[2].OptionSeq
+	tests := []struct {
+		name       string
+		optionSeqs []int64
+		want       *model.ItemQuote
+		wantErr    error
+	}{
+		{
+			name:       "필수 옵션과 추가 옵션",
+			optionSeqs: []int64{grande, shot, syrup},
+			want:       &model.ItemQuote{OptionSeqs: []int64{grande, shot, syrup}, Price: 5800, Cost: 1760, Margin: 4040},
+		},
+		{
+			name:       "필수 옵션 누락",
+			optionSeqs: []int64{shot},
+			wantErr:    apierror.ErrInvalidSelection,
+		},
+		{
+			name:       "최대 선택 개수 초과",
+			optionSeqs: []int64{grande, shot, syrup, whip},
+			wantErr:    apierror.ErrInvalidSelection,
+		},
+		{
+			name:       "같은 옵션 중복",
+			optionSeqs: []int64{grande, shot, shot},
+			wantErr:    apierror.ErrInvalidSelection,
+		},
+		{
+			name:       "다른 상품의 옵션",
+			optionSeqs: []int64{grande, whip + 100},
+			wantErr:    apierror.ErrNotExistOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := items.Get(ctx, store.StoreSeq, request.GetItem{ItemSeq: item.ItemSeq, OptionSeqs: tt.optionSeqs})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Get() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got.Quote, tt.want) {
+				t.Errorf("Get() quote = %+v, error = %v, want %+v", got.Quote, err, tt.want)
+			}
+		})
+	}
+
+	// 입력하지 않은 조건은 유지하며, 최소 선택 개수가 최대보다 많을 수 없다
+	intPtr := func(v int) *int { return &v }
+	boolPtr := func(v bool) *bool { return &v }
+	updateTests := []struct {
+		name         string
+		req          request.UpdateItemOptionGroup
+		wantRequired bool
+		wantMin      int
+		wantMax      int
+		wantErr      error
+	}{
+		{
+			name:    "최소 선택 개수가 최대보다 많음",
+			req:     request.UpdateItemOptionGroup{OptionGroupSeq: extra.OptionGroupSeq, MinSelect: intPtr(3)},
+			wantErr: apierror.ErrInvalidOptionSelect,
+		},
+		{
+			name:    "음수 선택 개수",
+			req:     request.UpdateItemOptionGroup{OptionGroupSeq: extra.OptionGroupSeq, MaxSelect: intPtr(-1)},
+			wantErr: apierror.ErrInvalidOptionSelect,
+		},
+		{
+			name:         "최소 선택 개수만 변경",
+			req:          request.UpdateItemOptionGroup{OptionGroupSeq: extra.OptionGroupSeq, MinSelect: intPtr(1)},
+			wantRequired: true,
+			wantMin:      1,
+			wantMax:      2,
+		},
+		{
+			name:         "필수 옵션을 여러 개 선택 가능하도록 변경",
+			req:          request.UpdateItemOptionGroup{OptionGroupSeq: size.OptionGroupSeq, MultiSelect: boolPtr(true)},
+			wantRequired: true,
+			wantMin:      1,
+			wantMax:      0,
+		},
+	}
+	for _, tt := range updateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.ItemSeq = item.ItemSeq
+			updated, err := s.UpdateGroup(ctx, store.StoreSeq, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateGroup() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if updated.Required != tt.wantRequired || updated.MinSelect != tt.wantMin || updated.MaxSelect != tt.wantMax {
+				t.Errorf("UpdateGroup() = %+v, want required %v, select %d~%d", updated, tt.wantRequired, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+
+	// 옵션 바코드는 상품 바코드와 겹칠 수 없고, 상품도 옵션 바코드를 사용할 수 없다
+	created := option("디카페인", 300)
+	created.ItemSeq, created.OptionGroupSeq, created.Barcode = item.ItemSeq, extra.OptionGroupSeq, name("8801")
+	if _, err := s.Create(ctx, store.StoreSeq, created); !errors.Is(err, apierror.ErrDuplicatedOptionKey) {
+		t.Errorf("Create() error = %v, want %v", err, apierror.ErrDuplicatedOptionKey)
+	}
+
+	created.Barcode = name("8801-decaf")
+	if _, err := s.Create(ctx, store.StoreSeq, created); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801-decaf", "디카페인")); !errors.Is(err, apierror.ErrDuplicatedItem) {
+		t.Errorf("Create() item error = %v, want %v", err, apierror.ErrDuplicatedItem)
+	}
+
+	created.Name, created.Barcode = name("디카페인"), nil
+	if _, err := s.Create(ctx, store.StoreSeq, created); !errors.Is(err, apierror.ErrDuplicatedOption) {
+		t.Errorf("Create() error = %v, want %v", err, apierror.ErrDuplicatedOption)
+	}
+
+	// 다른 상품의 옵션 그룹으로는 옵션을 변경할 수 없다
+	if err := s.Delete(ctx, store.StoreSeq, request.DeleteItemOption{ItemSeq: item.ItemSeq, OptionGroupSeq: size.OptionGroupSeq, OptionSeq: shot}); !errors.Is(err, apierror.ErrNotExistOption) {
+		t.Errorf("Delete() error = %v, want %v", err, apierror.ErrNotExistOption)
+	}
+
+	if err := s.DeleteGroup(ctx, store.StoreSeq, request.DeleteItemOptionGroup{ItemSeq: item.ItemSeq, OptionGroupSeq: extra.OptionGroupSeq}); err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := s.Find(ctx, store.StoreSeq, item.ItemSeq)
+	if err != nil || len(groups) != 1 {
+		t.Errorf("Find() = %+v, error = %v", groups, err)
+	}
+}
+
+func Test_itemOptionService_CreateGroup(t *testing.T) {
+	ctx := context.Background()
+
+	name := func(name string) *string { return &name }
+	intPtr := func(v int) *int { return &v }
+	options := []request.CreateItemOption{{Name: name("샷")}, {Name: name("시럽")}}
+
+	tests := []struct {
+		name         string
+		req          request.CreateItemOptionGroup
+		wantRequired bool
+		wantMulti    bool
+		wantMin      int
+		wantMax      int
+		wantErr      error
+	}{
+		{
+			name:    "하나를 선택할 수 있는 옵션",
+			req:     request.CreateItemOptionGroup{Name: name("온도")},
+			wantMin: 0,
+			wantMax: 1,
+		},
+		{
+			name:         "하나를 반드시 선택하는 옵션",
+			req:          request.CreateItemOptionGroup{Name: name("사이즈"), Required: true},
+			wantRequired: true,
+			wantMin:      1,
+			wantMax:      1,
+		},
+		{
+			name:      "개수 제한 없이 선택하는 옵션",
+			req:       request.CreateItemOptionGroup{Name: name("추가"), MultiSelect: true},
+			wantMulti: true,
+			wantMin:   0,
+			wantMax:   0,
+		},
+		{
+			name:         "입력한 최소, 최대 선택 개수 사용",
+			req:          request.CreateItemOptionGroup{Name: name("토핑"), MultiSelect: true, MinSelect: intPtr(1), MaxSelect: intPtr(2)},
+			wantRequired: true,
+			wantMulti:    true,
+			wantMin:      1,
+			wantMax:      2,
+		},
+		{
+			name:         "최대 0 은 개수 제한 없음",
+			req:          request.CreateItemOptionGroup{Name: name("토핑"), MultiSelect: true, MinSelect: intPtr(2), MaxSelect: intPtr(0)},
+			wantRequired: true,
+			wantMulti:    true,
+			wantMin:      2,
+			wantMax:      0,
+		},
+		{
+			name:    "최소 선택 개수가 최대보다 많음",
+			req:     request.CreateItemOptionGroup{Name: name("토핑"), MinSelect: intPtr(3), MaxSelect: intPtr(2)},
+			wantErr: apierror.ErrInvalidOptionSelect,
+		},
+		{
+			name:    "음수 선택 개수",
+			req:     request.CreateItemOptionGroup{Name: name("토핑"), MinSelect: intPtr(-1)},
+			wantErr: apierror.ErrInvalidOptionSelect,
+		},
+		{
+			name:    "옵션 그룹 이름 누락",
+			req:     request.CreateItemOptionGroup{},
+			wantErr: apierror.ErrNilOptionName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			store, err := repo.Store().Create(ctx, "카페")
+			if err != nil {
+				t.Fatal(err)
+			}
+			category := newTestCategory(t, repo, store.StoreSeq)
+
+			items, err := NewItemService(repo, api.ItemConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+				t.Fatal(err)
+			}
+			item, err := repo.Item().GetByBarcode(ctx, store.StoreSeq, "8801")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := NewItemOptionService(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.req.ItemSeq, tt.req.Options = item.ItemSeq, options
+			got, err := s.CreateGroup(ctx, store.StoreSeq, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateGroup() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Required != tt.wantRequired || got.MultiSelect != tt.wantMulti || got.MinSelect != tt.wantMin || got.MaxSelect != tt.wantMax {
+				t.Errorf("CreateGroup() = %+v, want required %v, multi %v, select %d~%d", got, tt.wantRequired, tt.wantMulti, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/search"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository/dao"
+)
+
+// itemSearchBatchSize 검색 결과를 DB 의 상품과 맞춰 볼 때 한 번에 읽는 상품 개수
+const itemSearchBatchSize = 100
+
+// searchedHit DB 의 현재 상품과 맞춰 본 검색 결과. 순서와 cursor 는 색인의 hit 을 따른다
+type searchedHit struct {
+	hit   search.Hit
+	item  dao.Item
+	match search.Match
+}
+
+// Search 점수가 높은 순서, 점수가 같으면 최신 순으로 조회한다.
+// 검색 색인으로 찾은 뒤 DB 에서 상품을 읽어 맞춰 보며, 색인을 처음 만드는 중에는 매장의 모든 상품을 DB 에서 읽어 찾는다
+func (s *itemService) Search(ctx context.Context, storeSeq int64, req request.SearchItems) (*model.ItemSearchPage, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	query := search.NewQuery(req.Text)
+	after, err := decodeItemSearchCursor(storeSeq, query, req.Cursor)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := s.repo.Store().Get(ctx, storeSeq); err != nil {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	hits, ok := s.index.Search(storeSeq, query)
+	var items map[int64]dao.Item
+	if !ok {
+		all, err := s.repo.Item().FindAll(ctx, storeSeq)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find items to search")
+		}
+		hits, items = matchItems(query, all)
+	}
+
+	sortHits(hits)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultItemLimit
+	}
+
+	// 삭제, 판매 중지된 상품을 뺀 뒤 자르므로 전체 개수가 필요하면 모든 결과를, 아니면 다음 페이지 여부를 알 수 있을 만큼만 맞춰 본다
+	page := &model.ItemSearchPage{}
+	var results []searchedHit
+	if req.WithTotal {
+		all, err := s.collectHits(ctx, storeSeq, query, hits, items, 0)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		total := int64(len(all))
+		page.Pagination.Total = &total
+		results = all[searchStart(after, len(all), func(i int) search.Hit { return all[i].hit }):]
+	} else {
+		from := searchStart(after, len(hits), func(i int) search.Hit { return hits[i] })
+		if results, err = s.collectHits(ctx, storeSeq, query, hits[from:], items, limit+1); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+
+		last := results[len(results)-1].hit
+		nextCursor, err := encodeItemSearchCursor(storeSeq, query, last.Match.Score, last.ItemSeq)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		page.Pagination.NextCursor = nextCursor
+		page.Pagination.HasMore = true
+	}
+
+	page.Items = make(model.SearchedItems, 0, len(results))
+	for _, result := range results {
+		page.Items = append(page.Items, model.SearchedItem{
+			Item:  getItemFromDAO(result.item),
+			Match: getItemMatch(result.match),
+		})
+	}
+
+	return page, nil
+}
+
+// collectHits 정렬된 hits 를 앞에서부터 DB 의 상품과 맞춰 본다. want 개를 모으면 멈추며, want 가 0 이면 모두 맞춰 본다.
+// 색인에서 찾은 뒤 삭제, 판매 중지되었거나 다른 서버에서 바뀌어 더 이상 검색어와 일치하지 않는 상품은 제외한다
+func (s *itemService) collectHits(ctx context.Context, storeSeq int64, query search.Query, hits []search.Hit, items map[int64]dao.Item, want int) ([]searchedHit, error) {
+	batchSize := itemSearchBatchSize
+	if want > batchSize {
+		batchSize = want
+	}
+
+	results := make([]searchedHit, 0, want)
+	for start := 0; start < len(hits); start += batchSize {
+		end := start + batchSize
+		if end > len(hits) {
+			end = len(hits)
+		}
+		batch := hits[start:end]
+
+		found := items
+		if valid.IsNil(found) {
+			var err error
+			if found, err = s.findItemsBySeqs(ctx, storeSeq, batch); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		for _, hit := range batch {
+			item, ok := found[hit.ItemSeq]
+			if !ok || item.UnavailableDT != nil {
+				continue
+			}
+
+			match, ok := query.Match(itemDocument(item))
+			if !ok {
+				continue
+			}
+
+			results = append(results, searchedHit{hit: hit, item: item, match: match})
+			if want > 0 && len(results) == want {
+				return results, nil
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (s *itemService) findItemsBySeqs(ctx context.Context, storeSeq int64, hits []search.Hit) (map[int64]dao.Item, error) {
+	itemSeqs := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		itemSeqs = append(itemSeqs, hit.ItemSeq)
+	}
+
+	found, err := s.repo.Item().FindBySeqs(ctx, storeSeq, itemSeqs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find searched items")
+	}
+
+	items := make(map[int64]dao.Item, len(found))
+	for _, item := range found {
+		items[item.ItemSeq] = item
+	}
+
+	return items, nil
+}
+
+// matchItems 색인 없이 검색어와 일치한 상품을 찾는다
+func matchItems(query search.Query, all dao.Items) ([]search.Hit, map[int64]dao.Item) {
+	hits := make([]search.Hit, 0)
+	items := make(map[int64]dao.Item)
+	for _, item := range all {
+		if match, ok := query.Match(itemDocument(item)); ok {
+			hits = append(hits, search.Hit{ItemSeq: item.ItemSeq, Match: match})
+			items[item.ItemSeq] = item
+		}
+	}
+
+	return hits, items
+}
+
+// sortHits 점수가 높은 순서로 정렬한다
+func sortHits(hits []search.Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		return rankedBefore(hits[i].Match.Score, hits[i].ItemSeq, hits[j].Match.Score, hits[j].ItemSeq)
+	})
+}
+
+// searchStart 정렬된 결과에서 cursor 다음 순서의 위치를 찾는다. cursor 가 없으면 처음부터이다
+func searchStart(after *itemSearchCursor, n int, hit func(i int) search.Hit) int {
+	if valid.IsNil(after) {
+		return 0
+	}
+
+	return sort.Search(n, func(i int) bool {
+		h := hit(i)
+		return rankedBefore(after.Score, after.ItemSeq, h.Match.Score, h.ItemSeq)
+	})
+}
+
+// rankedBefore (score, itemSeq) 가 (otherScore, otherItemSeq) 보다 앞인지 확인한다
+func rankedBefore(score int, itemSeq int64, otherScore int, otherItemSeq int64) bool {
+	if score != otherScore {
+		return score > otherScore
+	}
+
+	return itemSeq > otherItemSeq
+}
+
+func getItemMatch(match search.Match) model.ItemMatch {
+	highlights := make([]model.Highlight, 0, len(match.Spans))
+	for _, span := range match.Spans {
+		highlights = append(highlights, model.Highlight{Start: span.Start, End: span.End})
+	}
+
+	return model.ItemMatch{
+		Type:       string(match.Type),
+		Score:      match.Score,
+		Field:      match.Field,
+		Highlights: highlights,
+	}
+}
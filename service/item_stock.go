@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type StockService interface {
+	Get(ctx context.Context, storeSeq int64, req request.GetItemStock) (*model.ItemStock, error)
+	UpdateThreshold(ctx context.Context, storeSeq int64, req request.UpdateItemStock) (*model.ItemStock, error)
+	FindLow(ctx context.Context, storeSeq int64, req request.FindLowStock) (model.Items, error)
+
+	// 재고 변동 원장
+	Move(ctx context.Context, storeSeq, adminSeq int64, req request.CreateStockMovement) (model.StockMovements, error)
+	Movements(ctx context.Context, storeSeq int64, req request.FindStockMovements) (model.StockMovements, error)
+
+	// lot 별 재고
+	CreateLot(ctx context.Context, storeSeq, adminSeq int64, req request.CreateItemLot) (*model.ItemLot, error)
+	Lots(ctx context.Context, storeSeq int64, req request.FindItemLots) (model.ItemLots, error)
+	Consume(ctx context.Context, storeSeq, adminSeq int64, req request.ConsumeItemLots) (model.StockMovements, error)
+	Expiring(ctx context.Context, storeSeq int64, req request.FindExpiringLots) (model.ExpiringLots, error)
+}
+
+type stockService struct {
+	repo repository.Repository
+	cfg  api.ItemConfig
+}
+
+func NewStockService(repo repository.Repository, cfg api.ItemConfig) (StockService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &stockService{repo: repo, cfg: cfg.WithDefaults()}, nil
+}
+
+func (s *stockService) Get(ctx context.Context, storeSeq int64, req request.GetItemStock) (*model.ItemStock, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	item, err := getOwnedItem(ctx, s.repo, storeSeq, req.ItemSeq)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stocks, err := findItemStocks(ctx, s.repo, dao.Items{*item})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stock := getItemStockFromDAO(stocks[item.ItemSeq], s.cfg.LowStockThreshold)
+	return &stock, nil
+}
+
+func (s *stockService) UpdateThreshold(ctx context.Context, storeSeq int64, req request.UpdateItemStock) (*model.ItemStock, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result model.ItemStock
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, err := getOwnedItem(ctx, repo, storeSeq, req.ItemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if _, err := getOrCreateStock(ctx, repo, *item); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := repo.ItemStock().UpdateThreshold(ctx, item.ItemSeq, req.LowStockThreshold); err != nil {
+			return errors.Wrap(err, "failed to update low stock threshold")
+		}
+
+		stock, err := repo.ItemStock().Get(ctx, item.ItemSeq)
+		if err != nil {
+			return errors.Wrap(err, "failed to get updated stock")
+		}
+
+		result = getItemStockFromDAO(*stock, s.cfg.LowStockThreshold)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+// FindLow 재고가 기준 이하인 상품을 item_seq 순서로 조회한다
+func (s *stockService) FindLow(ctx context.Context, storeSeq int64, req request.FindLowStock) (model.Items, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultItemLimit
+	}
+
+	stocks, err := s.repo.ItemStock().FindLow(ctx, storeSeq, s.cfg.LowStockThreshold, req.LastItemSeq, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find low stocks")
+	}
+
+	itemSeqs := make([]int64, 0, len(stocks))
+	for _, stock := range stocks {
+		itemSeqs = append(itemSeqs, stock.ItemSeq)
+	}
+
+	items, err := s.repo.Item().FindBySeqs(ctx, storeSeq, itemSeqs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find low stock items")
+	}
+
+	byItemSeq := make(map[int64]dao.Item, len(items))
+	for _, item := range items {
+		byItemSeq[item.ItemSeq] = item
+	}
+
+	result := make(model.Items, 0, len(stocks))
+	for _, stock := range stocks {
+		item, ok := byItemSeq[stock.ItemSeq]
+		if !ok {
+			continue
+		}
+
+		itemStock := getItemStockFromDAO(stock, s.cfg.LowStockThreshold)
+		lowItem := getItemFromDAO(item)
+		lowItem.Stock = &itemStock
+		result = append(result, lowItem)
+	}
+
+	return result, nil
+}
+
+// Move 재고를 바꾸고 같은 transaction 안에서 원장에 남긴다. 이동은 두 매장의 원장에 각각 남긴다.
+// 판매, 입고, 폐기는 staff 도 할 수 있고, 조정과 이동은 manager 이상만 할 수 있다
+func (s *stockService) Move(ctx context.Context, storeSeq, adminSeq int64, req request.CreateStockMovement) (model.StockMovements, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if req.Type.ManagerOnly() {
+		if err := s.checkManager(ctx, storeSeq, adminSeq); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	if req.Type == request.StockMovementTransfer {
+		if err := s.checkTransferStore(ctx, storeSeq, adminSeq, req.ToStoreSeq); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	result := make(model.StockMovements, 0, 2)
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		item, err := getOwnedItem(ctx, repo, storeSeq, req.ItemSeq)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		movement := dao.StockMovement{
+			AdminSeq: adminSeq,
+			Type:     dao.StockMovementType(req.Type),
+			Quantity: req.Delta(),
+			Reason:   req.Reason,
+		}
+		if req.Type == request.StockMovementTransfer {
+			movement.TransferStoreSeq = &req.ToStoreSeq
+		}
+		if req.LotSeq > 0 {
+			movement.LotSeq = &req.LotSeq
+		}
+
+		created, err := moveStock(ctx, repo, *item, movement)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, c := range created {
+			result = append(result, getStockMovementFromDAO(c))
+		}
+
+		if req.Type != request.StockMovementTransfer {
+			return nil
+		}
+
+		// 받는 매장의 같은 바코드 상품에 같은 수량을 더한다. lot 에서 빠진 수량은 같은 번호의 lot 으로 옮긴다
+		target, err := repo.Item().GetByBarcode(ctx, req.ToStoreSeq, item.Barcode)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierror.ErrNoTransferItem
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to get transfer item")
+		}
+
+		for _, out := range created {
+			in := movement
+			in.Quantity = -out.Quantity
+			in.TransferStoreSeq = &storeSeq
+			in.LotSeq = nil
+			if out.LotSeq != nil {
+				lot, err := transferLot(ctx, repo, *target, *out.LotSeq)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				in.LotSeq = &lot.LotSeq
+			}
+
+			moved, err := moveStock(ctx, repo, *target, in)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for _, m := range moved {
+				result = append(result, getStockMovementFromDAO(m))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// checkManager 재고 조정과 이동은 재고를 빼는 매장에서 manager 이상이어야 한다
+func (s *stockService) checkManager(ctx context.Context, storeSeq, adminSeq int64) error {
+	member, err := s.repo.StoreMember().Get(ctx, storeSeq, adminSeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierror.ErrForbiddenStore
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get store member")
+	}
+
+	if !member.Role.In(model.RoleOwner, model.RoleManager) {
+		return apierror.ErrForbidden
+	}
+
+	return nil
+}
+
+// checkTransferStore 재고를 받는 매장에서도 manager 이상이어야 한다
+func (s *stockService) checkTransferStore(ctx context.Context, storeSeq, adminSeq, toStoreSeq int64) error {
+	if toStoreSeq == storeSeq {
+		return apierror.ErrInvalidTransfer
+	}
+
+	member, err := s.repo.StoreMember().Get(ctx, toStoreSeq, adminSeq)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierror.ErrForbiddenStore
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get transfer store member")
+	}
+
+	if !member.Role.In(model.RoleOwner, model.RoleManager) {
+		return apierror.ErrForbiddenStore
+	}
+
+	return nil
+}
+
+// moveStock 상품의 재고에 movement.Quantity 를 더하고 변동 후 재고로 원장을 남긴다.
+// lot 을 지정하지 않은 감소는 lot 마다 나누어 차감하므로 원장이 여러 줄 남을 수 있다
+func moveStock(ctx context.Context, repo repository.Repository, item dao.Item, movement dao.StockMovement) (dao.StockMovements, error) {
+	stock, err := getOrCreateStock(ctx, repo, item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	portions, err := splitByLot(ctx, repo, item, *stock, movement)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make(dao.StockMovements, 0, len(portions))
+	for _, portion := range portions {
+		if portion.LotSeq != nil {
+			if err := repo.ItemLot().Add(ctx, *portion.LotSeq, portion.Quantity); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		if err := repo.ItemStock().Add(ctx, item.ItemSeq, portion.Quantity); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		moved, err := repo.ItemStock().Get(ctx, item.ItemSeq)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get moved stock")
+		}
+
+		portion.ItemSeq = item.ItemSeq
+		portion.StoreSeq = item.StoreSeq
+		portion.Balance = moved.Quantity
+		portion.RegDT = time.Now()
+
+		created, err := repo.StockMovement().Create(ctx, portion)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to record stock movement")
+		}
+		result = append(result, *created)
+	}
+
+	return result, nil
+}
+
+// splitByLot lot 을 지정하지 않은 감소를 유통기한이 빠른 lot 부터 나누고(FEFO), 남는 수량은 lot 이 없는 재고에서 차감한다.
+// 판매와 이동은 유통기한이 지난 lot 을 건너뛴다
+func splitByLot(ctx context.Context, repo repository.Repository, item dao.Item, stock dao.ItemStock, movement dao.StockMovement) (dao.StockMovements, error) {
+	if movement.LotSeq != nil {
+		lot, err := repo.ItemLot().Get(ctx, *movement.LotSeq)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierror.ErrNotExistLot
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get item lot")
+		}
+
+		if lot.ItemSeq != item.ItemSeq {
+			return nil, apierror.ErrNotExistLot
+		}
+
+		return dao.StockMovements{movement}, nil
+	}
+
+	if movement.Quantity >= 0 {
+		return dao.StockMovements{movement}, nil
+	}
+
+	lots, err := repo.ItemLot().FindRemaining(ctx, item.ItemSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find remaining lots")
+	}
+
+	// lot 에 없는 재고 = 전체 재고 - lot 의 남은 수량
+	untracked := stock.Quantity
+	for _, lot := range lots {
+		untracked -= lot.Remaining
+	}
+
+	skipExpired := movement.Type == dao.StockMovementSell || movement.Type == dao.StockMovementTransfer
+	now := time.Now()
+
+	need := -movement.Quantity
+	result := make(dao.StockMovements, 0, len(lots)+1)
+	for _, lot := range lots {
+		if need == 0 {
+			break
+		}
+		if skipExpired && lot.Expired(now) {
+			continue
+		}
+
+		lotSeq, take := lot.LotSeq, min(lot.Remaining, need)
+		portion := movement
+		portion.Quantity = -take
+		portion.LotSeq = &lotSeq
+		result = append(result, portion)
+		need -= take
+	}
+
+	if need > 0 {
+		if need > untracked {
+			return nil, apierror.ErrInsufficientStock
+		}
+
+		portion := movement
+		portion.Quantity = -need
+		result = append(result, portion)
+	}
+
+	return result, nil
+}
+
+// transferLot 받는 상품에서 같은 번호의 lot 을 찾고, 없으면 같은 입고일과 유통기한으로 만든다
+func transferLot(ctx context.Context, repo repository.Repository, target dao.Item, lotSeq int64) (*dao.ItemLot, error) {
+	source, err := repo.ItemLot().Get(ctx, lotSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transfer lot")
+	}
+
+	lot, err := repo.ItemLot().GetByNumber(ctx, target.ItemSeq, source.LotNumber)
+	if err == nil {
+		return lot, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(err, "failed to get target lot")
+	}
+
+	created, err := dao.NewItemLot(target, source.LotNumber, source.ReceivedDT, source.ExpireDT)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lot, err = repo.ItemLot().Create(ctx, *created)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create target lot")
+	}
+
+	return lot, nil
+}
+
+// getOrCreateStock 재고가 없는 상품은 0 으로 만든다
+func getOrCreateStock(ctx context.Context, repo repository.Repository, item dao.Item) (*dao.ItemStock, error) {
+	stock, err := repo.ItemStock().Get(ctx, item.ItemSeq)
+	if err == nil {
+		return stock, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(err, "failed to get item stock")
+	}
+
+	created := dao.NewItemStock(item)
+	if err := repo.ItemStock().Create(ctx, created); err != nil {
+		return nil, errors.Wrap(err, "failed to create item stock")
+	}
+
+	return &created, nil
+}
+
+// Movements 휴지통의 상품도 원장을 조회할 수 있다
+func (s *stockService) Movements(ctx context.Context, storeSeq int64, req request.FindStockMovements) (model.StockMovements, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := checkItemStore(ctx, s.repo, storeSeq, req.ItemSeq); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	movements, err := s.repo.StockMovement().Find(ctx, req.ItemSeq, req.LastMovementSeq, req.Limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find stock movements")
+	}
+
+	result := make(model.StockMovements, 0, len(movements))
+	for _, movement := range movements {
+		result = append(result, getStockMovementFromDAO(movement))
+	}
+
+	return result, nil
+}
+
+// findItemStocks 상품별 재고, 재고가 없는 상품은 0 으로 채운다
+func findItemStocks(ctx context.Context, repo repository.Repository, items dao.Items) (map[int64]dao.ItemStock, error) {
+	itemSeqs := make([]int64, 0, len(items))
+	for _, item := range items {
+		itemSeqs = append(itemSeqs, item.ItemSeq)
+	}
+
+	stocks, err := repo.ItemStock().FindByItems(ctx, itemSeqs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find item stocks")
+	}
+
+	result := make(map[int64]dao.ItemStock, len(items))
+	for _, item := range items {
+		result[item.ItemSeq] = dao.ItemStock{ItemSeq: item.ItemSeq, StoreSeq: item.StoreSeq}
+	}
+	for _, stock := range stocks {
+		result[stock.ItemSeq] = stock
+	}
+
+	return result, nil
+}
+
+func getItemStockFromDAO(stock dao.ItemStock, defaultThreshold int64) model.ItemStock {
+	return model.ItemStock{
+		Quantity:          stock.Quantity,
+		LowStockThreshold: stock.Threshold(defaultThreshold),
+		DefaultThreshold:  stock.LowStockThreshold == nil,
+		LowStock:          stock.LowStock(defaultThreshold),
+		ModDT:             stock.ModDT,
+	}
+}
+
+func getStockMovementFromDAO(movement dao.StockMovement) model.StockMovement {
+	return model.StockMovement{
+		MovementSeq:      movement.MovementSeq,
+		ItemSeq:          movement.ItemSeq,
+		StoreSeq:         movement.StoreSeq,
+		AdminSeq:         movement.AdminSeq,
+		Type:             string(movement.Type),
+		Quantity:         movement.Quantity,
+		Balance:          movement.Balance,
+		Reason:           movement.Reason,
+		TransferStoreSeq: movement.TransferStoreSeq,
+		LotSeq:           movement.LotSeq,
+		RegDT:            movement.RegDT,
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+func Test_stockService(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	items, err := NewItemService(repo, api.ItemConfig{LowStockThreshold: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewStockService(repo, api.ItemConfig{LowStockThreshold: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 관리자 1 은 두 매장의 owner, 관리자 2 는 첫 번째 매장의 manager 이자 두 번째 매장의 staff, 관리자 3 은 첫 번째 매장의 staff 이다
+	stores := make([]int64, 0, 2)
+	for _, name := range []string{"본점", "지점"} {
+		store, err := repo.Store().Create(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.StoreMember().Create(ctx, store.StoreSeq, 1, model.RoleOwner); err != nil {
+			t.Fatal(err)
+		}
+		if err := items.Create(ctx, store.StoreSeq, 1, newTestItem(newTestCategory(t, repo, store.StoreSeq), "8801", "아메리카노")); err != nil {
+			t.Fatal(err)
+		}
+		stores = append(stores, store.StoreSeq)
+	}
+	for _, member := range []struct {
+		storeSeq, adminSeq int64
+		role               model.Role
+	}{
+		{storeSeq: stores[0], adminSeq: 2, role: model.RoleManager},
+		{storeSeq: stores[1], adminSeq: 2, role: model.RoleStaff},
+		{storeSeq: stores[0], adminSeq: 3, role: model.RoleStaff},
+	} {
+		if err := repo.StoreMember().Create(ctx, member.storeSeq, member.adminSeq, member.role); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	item, err := repo.Item().GetByBarcode(ctx, stores[0], "8801")
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch, err := repo.Item().GetByBarcode(ctx, stores[1], "8801")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quantity := func(q int64) *int64 { return &q }
+	tests := []struct {
+		name     string
+		adminSeq int64
+		req      request.CreateStockMovement
+		want     []int64 // 원장에 남은 변동 후 재고
+		wantErr  error
+	}{
+		{
+			name: "입고",
+			req:  request.CreateStockMovement{Type: request.StockMovementReceive, Quantity: quantity(10)},
+			want: []int64{10},
+		},
+		{
+			name: "판매",
+			req:  request.CreateStockMovement{Type: request.StockMovementSell, Quantity: quantity(2)},
+			want: []int64{8},
+		},
+		{
+			name:    "재고보다 많은 판매",
+			req:     request.CreateStockMovement{Type: request.StockMovementSell, Quantity: quantity(9)},
+			wantErr: apierror.ErrInsufficientStock,
+		},
+		{
+			name:    "사유 없는 폐기",
+			req:     request.CreateStockMovement{Type: request.StockMovementWaste, Quantity: quantity(1)},
+			wantErr: apierror.ErrNilStockReason,
+		},
+		{
+			name: "재고 조사로 줄이기",
+			req:  request.CreateStockMovement{Type: request.StockMovementAdjust, Quantity: quantity(-1), Reason: "재고 조사"},
+			want: []int64{7},
+		},
+		{
+			name:    "판매 수량은 양수만",
+			req:     request.CreateStockMovement{Type: request.StockMovementSell, Quantity: quantity(-1)},
+			wantErr: apierror.ErrInvalidQuantity,
+		},
+		{
+			name: "지점으로 이동",
+			req:  request.CreateStockMovement{Type: request.StockMovementTransfer, Quantity: quantity(5), ToStoreSeq: stores[1]},
+			want: []int64{2, 5},
+		},
+		{
+			name:    "같은 매장으로 이동",
+			req:     request.CreateStockMovement{Type: request.StockMovementTransfer, Quantity: quantity(1), ToStoreSeq: stores[0]},
+			wantErr: apierror.ErrInvalidTransfer,
+		},
+		{
+			name:     "staff 인 매장으로 이동",
+			adminSeq: 2,
+			req:      request.CreateStockMovement{Type: request.StockMovementTransfer, Quantity: quantity(1), ToStoreSeq: stores[1]},
+			wantErr:  apierror.ErrForbiddenStore,
+		},
+		{
+			name:     "staff 의 재고 조정",
+			adminSeq: 3,
+			req:      request.CreateStockMovement{Type: request.StockMovementAdjust, Quantity: quantity(-1), Reason: "재고 조사"},
+			wantErr:  apierror.ErrForbidden,
+		},
+		{
+			name:     "staff 의 이동",
+			adminSeq: 3,
+			req:      request.CreateStockMovement{Type: request.StockMovementTransfer, Quantity: quantity(1), ToStoreSeq: stores[1]},
+			wantErr:  apierror.ErrForbidden,
+		},
+		{
+			name:     "매장에 소속되지 않은 관리자의 재고 조정",
+			adminSeq: 4,
+			req:      request.CreateStockMovement{Type: request.StockMovementAdjust, Quantity: quantity(-1), Reason: "재고 조사"},
+			wantErr:  apierror.ErrForbiddenStore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminSeq := tt.adminSeq
+			if adminSeq == 0 {
+				adminSeq = 1
+			}
+
+			tt.req.ItemSeq = item.ItemSeq
+			got, err := s.Move(ctx, stores[0], adminSeq, tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Move() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			balances := make([]int64, 0, len(got))
+			for _, movement := range got {
+				balances = append(balances, movement.Balance)
+			}
+			if len(balances) != len(tt.want) || balances[0] != tt.want[0] || balances[len(balances)-1] != tt.want[len(tt.want)-1] {
+				t.Errorf("Move() balances = %v, want %v", balances, tt.want)
+			}
+		})
+	}
+
+	// 이동은 받는 매장의 원장에 양수로 남는다
+	movements, err := s.Movements(ctx, stores[1], request.FindStockMovements{ItemSeq: branch.ItemSeq})
+	if err != nil || len(movements) != 1 || movements[0].Quantity != 5 || *movements[0].TransferStoreSeq != stores[0] {
+		t.Errorf("Movements() = %+v, error = %v", movements, err)
+	}
+
+	movements, err = s.Movements(ctx, stores[0], request.FindStockMovements{ItemSeq: item.ItemSeq})
+	if err != nil || len(movements) != 4 || movements[0].Quantity != -5 || movements[3].Type != string(request.StockMovementReceive) {
+		t.Errorf("Movements() = %+v, error = %v", movements, err)
+	}
+
+	// 본점 재고 2 는 기본 기준 3 이하이다
+	found, err := findItems(ctx, items, stores[0])
+	if err != nil || len(found) != 1 || found[0].Stock == nil || found[0].Stock.Quantity != 2 || !found[0].Stock.LowStock || !found[0].Stock.DefaultThreshold {
+		t.Errorf("Find() stock = %+v, error = %v", found, err)
+	}
+
+	low, err := s.FindLow(ctx, stores[0], request.FindLowStock{})
+	if err != nil || len(low) != 1 || low[0].ItemSeq != item.ItemSeq {
+		t.Errorf("FindLow() = %+v, error = %v", low, err)
+	}
+
+	stock, err := s.UpdateThreshold(ctx, stores[0], request.UpdateItemStock{ItemSeq: item.ItemSeq, LowStockThreshold: quantity(1)})
+	if err != nil || stock.LowStock || stock.DefaultThreshold || stock.LowStockThreshold != 1 {
+		t.Errorf("UpdateThreshold() = %+v, error = %v", stock, err)
+	}
+
+	low, err = s.FindLow(ctx, stores[0], request.FindLowStock{})
+	if err != nil || len(low) != 0 {
+		t.Errorf("FindLow() = %+v, error = %v", low, err)
+	}
+
+	// 다른 매장의 상품 재고는 조회할 수 없다
+	if _, err := s.Get(ctx, stores[1], request.GetItemStock{ItemSeq: item.ItemSeq}); !errors.Is(err, apierror.ErrForbiddenItem) {
+		t.Errorf("Get() error = %v, want %v", err, apierror.ErrForbiddenItem)
+	}
+}
@@ -1,37 +1,30 @@
 package service
 
 import (
-	"os"
+	"context"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/require"
+	"github.com/pkg/errors"
 	"hello-cafe/internal/api"
-	"hello-cafe/internal/db"
+	"hello-cafe/internal/apierror"
 	"hello-cafe/model"
 	"hello-cafe/model/request"
 	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
 )
 
 func Test_itemService_Create(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
-
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		item request.CreateItem
+		storeSeq int64
+		item     request.CreateItem
 	}
 	tests := []struct {
 		name    string
@@ -45,9 +38,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 0,
 				item: request.CreateItem{
-					AdminSeq:    0,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -65,8 +58,8 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
 					Category:    nil,
 					Barcode:     new(string),
 					Price:       new(int64),
@@ -85,9 +78,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     nil,
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -105,9 +98,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       nil,
 					Cost:        new(int64),
@@ -125,9 +118,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        nil,
@@ -145,9 +138,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -165,9 +158,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -185,9 +178,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -205,9 +198,9 @@ func Test_itemService_Create(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.CreateItem{
-					AdminSeq:    1,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -225,7 +218,7 @@ func Test_itemService_Create(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			if err := s.Create(tt.args.item); (err != nil) != tt.wantErr {
+			if err := s.Create(context.Background(), tt.args.storeSeq, 1, tt.args.item); (err != nil) != tt.wantErr {
 				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -233,23 +226,15 @@ func Test_itemService_Create(t *testing.T) {
 }
 
 func Test_itemService_Delete(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
-
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		itemSeq int64
+		storeSeq int64
+		itemSeq  int64
+		version  int64
 	}
 	tests := []struct {
 		name    string
@@ -263,7 +248,21 @@ func Test_itemService_Delete(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
-				itemSeq: 0,
+				storeSeq: 1,
+				itemSeq:  0,
+				version:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "삭제 실패(version 누락)",
+			fields: fields{
+				repo: repo,
+			},
+			args: args{
+				storeSeq: 1,
+				itemSeq:  1,
+				version:  0,
 			},
 			wantErr: true,
 		},
@@ -273,7 +272,7 @@ func Test_itemService_Delete(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			if err := s.Delete(tt.args.itemSeq); (err != nil) != tt.wantErr {
+			if err := s.Delete(context.Background(), tt.args.storeSeq, 1, tt.args.itemSeq, tt.args.version); (err != nil) != tt.wantErr {
 				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -281,40 +280,29 @@ func Test_itemService_Delete(t *testing.T) {
 }
 
 func Test_itemService_Find(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
-
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		adminSeq    int64
-		lastItemSeq int64
-		limit       int
+		storeSeq int64
+		req      request.FindItems
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    model.Items
+		want    *model.ItemPage
 		wantErr bool
 	}{
 		{
-			name: "조회 실패(관리자 정보 누락)",
+			name: "조회 실패(매장 정보 누락)",
 			fields: fields{
 				repo: repo,
 			},
 			args: args{
-				adminSeq: 0,
+				storeSeq: 0,
 			},
 			want:    nil,
 			wantErr: true,
@@ -325,7 +313,7 @@ func Test_itemService_Find(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			got, err := s.Find(tt.args.adminSeq, tt.args.lastItemSeq, tt.args.limit)
+			got, err := s.Find(context.Background(), tt.args.storeSeq, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -337,24 +325,158 @@ func Test_itemService_Find(t *testing.T) {
 	}
 }
 
-func Test_itemService_Get(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
+func Test_itemService_Find_sort(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, price := range []int64{5000, 4500, 7000, 4500, 3000} {
+		item := newTestItem(category, strconv.Itoa(8801+i), "상품")
+		item.Price = &price
+		if err := s.Create(ctx, store.StoreSeq, 1, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// findAll next_cursor 로 마지막 페이지까지 조회한다
+	findAll := func(req request.FindItems) ([]string, error) {
+		req.Limit = 2
+		result := make([]string, 0)
+		for {
+			page, err := s.Find(ctx, store.StoreSeq, req)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range page.Items {
+				result = append(result, item.Barcode)
+			}
+
+			if page.Pagination.NextCursor == "" {
+				return result, nil
+			}
+			req.Cursor = page.Pagination.NextCursor
+		}
+	}
+
+	minPrice := int64(4500)
+	tests := []struct {
+		name    string
+		req     request.FindItems
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "기본 정렬",
+			req:  request.FindItems{},
+			want: []string{"8805", "8804", "8803", "8802", "8801"},
+		},
+		{
+			name: "가격 오름차순",
+			req:  request.FindItems{Sort: request.ItemSortPrice, Order: request.SortOrderAsc},
+			want: []string{"8805", "8802", "8804", "8801", "8803"},
+		},
+		{
+			name: "가격 조건과 마진 내림차순",
+			req:  request.FindItems{MinPrice: &minPrice, Sort: request.ItemSortMargin},
+			want: []string{"8803", "8801", "8804", "8802"},
+		},
+		{
+			name:    "잘못된 정렬 기준",
+			req:     request.FindItems{Sort: "barcode"},
+			wantErr: apierror.ErrInvalidItemSort,
+		},
+		{
+			name:    "잘못된 cursor",
+			req:     request.FindItems{Cursor: "invalid"},
+			wantErr: apierror.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findAll(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Find() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && tt.wantErr == nil {
+				t.Errorf("Find() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("조건이 다른 cursor", func(t *testing.T) {
+		page, err := s.Find(ctx, store.StoreSeq, request.FindItems{MinPrice: &minPrice, Sort: request.ItemSortPrice, Limit: 1})
+		if err != nil || page.Pagination.NextCursor == "" {
+			t.Fatalf("Find() page = %v, error = %v", page, err)
+		}
+
+		otherPrice := minPrice + 1
+		cursorTests := []struct {
+			name    string
+			req     request.FindItems
+			wantErr error
+		}{
+			{name: "같은 조건", req: request.FindItems{MinPrice: &minPrice, Sort: request.ItemSortPrice}},
+			{name: "다른 정렬", req: request.FindItems{MinPrice: &minPrice, Sort: request.ItemSortName}, wantErr: apierror.ErrInvalidCursor},
+			{name: "다른 가격 범위", req: request.FindItems{MinPrice: &otherPrice, Sort: request.ItemSortPrice}, wantErr: apierror.ErrInvalidCursor},
+			{name: "가격 범위 없음", req: request.FindItems{Sort: request.ItemSortPrice}, wantErr: apierror.ErrInvalidCursor},
+			{name: "판매 중지 포함", req: request.FindItems{MinPrice: &minPrice, Sort: request.ItemSortPrice, IncludeUnavailable: true}, wantErr: apierror.ErrInvalidCursor},
+		}
+		for _, tt := range cursorTests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.req.Cursor = page.Pagination.NextCursor
+				_, err := s.Find(ctx, store.StoreSeq, tt.req)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Find() error = %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+	})
+
+	t.Run("with_total", func(t *testing.T) {
+		page, err := s.Find(ctx, store.StoreSeq, request.FindItems{MinPrice: &minPrice, Limit: 3, WithTotal: true})
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
+		pagination := page.Pagination
+		if len(page.Items) != 3 || !pagination.HasMore || pagination.Total == nil || *pagination.Total != 4 {
+			t.Errorf("Find() items = %d, pagination = %+v", len(page.Items), pagination)
+		}
+	})
 
-	cfg, err := api.Config()
-	require.NoError(t, err)
+	t.Run("last_item_seq", func(t *testing.T) {
+		items, err := findItems(ctx, s, store.StoreSeq)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+		page, err := s.Find(ctx, store.StoreSeq, request.FindItems{LastItemSeq: items[1].ItemSeq})
+		if err != nil || len(page.Items) != 3 || page.Items[0].ItemSeq != items[2].ItemSeq {
+			t.Errorf("Find() page = %v, error = %v", page, err)
+		}
+	})
+}
+
+func Test_itemService_Get(t *testing.T) {
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		itemSeq int64
+		storeSeq int64
+		itemSeq  int64
 	}
 	tests := []struct {
 		name    string
@@ -369,7 +491,8 @@ func Test_itemService_Get(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
-				itemSeq: 0,
+				storeSeq: 1,
+				itemSeq:  0,
 			},
 			want:    nil,
 			wantErr: true,
@@ -380,7 +503,7 @@ func Test_itemService_Get(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			got, err := s.Get(tt.args.itemSeq)
+			got, err := s.Get(context.Background(), tt.args.storeSeq, request.GetItem{ItemSeq: tt.args.itemSeq})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -393,40 +516,30 @@ func Test_itemService_Get(t *testing.T) {
 }
 
 func Test_itemService_Search(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
-
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
-
-	cfg, err := api.Config()
-	require.NoError(t, err)
-
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		adminSeq int64
-		text     string
+		storeSeq int64
+		req      request.SearchItems
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    model.Items
+		want    *model.ItemSearchPage
 		wantErr bool
 	}{
 		{
-			name: "검색 실패 ( 관리자 정보 누락 )",
+			name: "검색 실패 ( 매장 정보 누락 )",
 			fields: fields{
 				repo: repo,
 			},
 			args: args{
-				adminSeq: 0,
-				text:     "ㅋㅍ",
+				storeSeq: 0,
+				req:      request.SearchItems{Text: "ㅋㅍ"},
 			},
 			want:    nil,
 			wantErr: true,
@@ -437,8 +550,8 @@ func Test_itemService_Search(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
-				adminSeq: 1,
-				text:     "",
+				storeSeq: 1,
+				req:      request.SearchItems{Text: ""},
 			},
 			want:    nil,
 			wantErr: true,
@@ -449,7 +562,7 @@ func Test_itemService_Search(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			got, err := s.Search(tt.args.adminSeq, tt.args.text)
+			got, err := s.Search(context.Background(), tt.args.storeSeq, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Search() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -461,24 +574,88 @@ func Test_itemService_Search(t *testing.T) {
 	}
 }
 
-func Test_itemService_Update(t *testing.T) {
-	repo, err := repository.NewRepository()
-	require.NoError(t, err)
+func Test_itemService_Search_pagination(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
 
-	err = os.Setenv("CONFIG_PATH", "/Users/shyu/workspace/hello-cafe/configs/config.yml")
-	require.NoError(t, err)
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
 
-	cfg, err := api.Config()
-	require.NoError(t, err)
+	other, err := repo.Store().Create(ctx, "다른 카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, name := range []string{"아메리카노", "아이스 아메리카노", "라떼", "디카페인 아메리카노"} {
+		if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, strconv.Itoa(8801+i), name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 이름이 같은 상품이 먼저, 부분 일치는 앞쪽에서 일치할수록 먼저
+	first, err := s.Search(ctx, store.StoreSeq, request.SearchItems{Text: "아메리카노", Limit: 2, WithTotal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 2 || !first.Pagination.HasMore || first.Pagination.Total == nil || *first.Pagination.Total != 3 {
+		t.Fatalf("Search() page = %+v", first)
+	}
+	if first.Items[0].Barcode != "8801" || first.Items[0].Match.Type != "exact" || first.Items[1].Barcode != "8802" {
+		t.Errorf("Search() items = %+v", first.Items)
+	}
+	if got := first.Items[1].Match.Highlights; !reflect.DeepEqual(got, []model.Highlight{{Start: 4, End: 9}}) {
+		t.Errorf("Search() highlights = %v", got)
+	}
+
+	chosung, err := s.Search(ctx, store.StoreSeq, request.SearchItems{Text: "ㅇㅁ", Limit: 1})
+	if err != nil || chosung.Pagination.NextCursor == "" {
+		t.Fatalf("Search() page = %+v, error = %v", chosung, err)
+	}
+
+	next, err := s.Search(ctx, store.StoreSeq, request.SearchItems{Text: "아메리카노", Limit: 2, Cursor: first.Pagination.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Items) != 1 || next.Items[0].Barcode != "8804" || next.Pagination.HasMore || next.Pagination.NextCursor != "" || next.Pagination.Total != nil {
+		t.Errorf("Search() page = %+v", next)
+	}
+
+	tests := []struct {
+		name     string
+		storeSeq int64
+		cursor   string
+	}{
+		{name: "다른 매장의 cursor", storeSeq: other.StoreSeq, cursor: first.Pagination.NextCursor},
+		{name: "다른 검색어의 cursor", storeSeq: store.StoreSeq, cursor: chosung.Pagination.NextCursor},
+		{name: "변조된 cursor", storeSeq: store.StoreSeq, cursor: first.Pagination.NextCursor + "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Search(ctx, tt.storeSeq, request.SearchItems{Text: "아메리카노", Cursor: tt.cursor})
+			if !errors.Is(err, apierror.ErrInvalidCursor) {
+				t.Errorf("Search() error = %v, want %v", err, apierror.ErrInvalidCursor)
+			}
+		})
+	}
+}
 
-	err = db.Connect(cfg.DB)
-	require.NoError(t, err)
+func Test_itemService_Update(t *testing.T) {
+	repo := newTestRepository(t)
 
 	type fields struct {
 		repo repository.Repository
 	}
 	type args struct {
-		item request.UpdateItem
+		storeSeq int64
+		item     request.UpdateItem
 	}
 	tests := []struct {
 		name    string
@@ -492,9 +669,10 @@ func Test_itemService_Update(t *testing.T) {
 				repo: repo,
 			},
 			args: args{
+				storeSeq: 1,
 				item: request.UpdateItem{
 					ItemSeq:     0,
-					Category:    new(request.ItemCategory),
+					Category:    new(int64),
 					Barcode:     new(string),
 					Price:       new(int64),
 					Cost:        new(int64),
@@ -512,9 +690,426 @@ func Test_itemService_Update(t *testing.T) {
 			s := &itemService{
 				repo: tt.fields.repo,
 			}
-			if err := s.Update(tt.args.item); (err != nil) != tt.wantErr {
+			if err := s.Update(context.Background(), tt.args.storeSeq, 1, tt.args.item); (err != nil) != tt.wantErr {
 				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_itemService_Restore(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	other, err := repo.Store().Create(ctx, "다른 카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Find() items = %v, error = %v", items, err)
+	}
+	itemSeq := items[0].ItemSeq
+
+	if err := s.Delete(ctx, store.StoreSeq, 1, itemSeq, items[0].Version); err != nil {
+		t.Fatal(err)
+	}
+
+	// 휴지통의 상품은 영구 삭제되기 전까지 바코드를 점유한다
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "라떼")); !errors.Is(err, apierror.ErrDeletedItemBarcode) {
+		t.Errorf("Create() error = %v, want %v", err, apierror.ErrDeletedItemBarcode)
+	}
+
+	trash, err := s.FindTrash(ctx, store.StoreSeq, 0, 10)
+	if err != nil || len(trash) != 1 || trash[0].DeletedDT == nil {
+		t.Fatalf("FindTrash() items = %v, error = %v", trash, err)
+	}
+
+	tests := []struct {
+		name     string
+		storeSeq int64
+		itemSeq  int64
+		wantErr  error
+	}{
+		{
+			name:     "다른 매장의 상품 복구",
+			storeSeq: other.StoreSeq,
+			itemSeq:  itemSeq,
+			wantErr:  apierror.ErrForbiddenItem,
+		},
+		{
+			name:     "휴지통에 없는 상품 복구",
+			storeSeq: store.StoreSeq,
+			itemSeq:  itemSeq + 1,
+			wantErr:  apierror.ErrNotExistDeletedItem,
+		},
+		{
+			name:     "상품 복구",
+			storeSeq: store.StoreSeq,
+			itemSeq:  itemSeq,
+			wantErr:  nil,
+		},
+		{
+			name:     "이미 복구된 상품 복구",
+			storeSeq: store.StoreSeq,
+			itemSeq:  itemSeq,
+			wantErr:  apierror.ErrNotExistDeletedItem,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Restore(ctx, tt.storeSeq, 1, tt.itemSeq); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := s.Get(ctx, store.StoreSeq, request.GetItem{ItemSeq: itemSeq}); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+}
+
+func Test_itemService_PurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	// 보관 기간이 매우 짧으면 삭제 직후 영구 삭제 대상이 된다
+	s, err := NewItemService(repo, api.ItemConfig{TrashRetention: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Find() items = %v, error = %v", items, err)
+	}
+
+	if err := s.Delete(ctx, store.StoreSeq, 1, items[0].ItemSeq, items[0].Version); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	purged, err := s.PurgeDeleted(ctx)
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgeDeleted() purged = %d, error = %v", purged, err)
+	}
+
+	// 영구 삭제된 상품의 바코드는 다시 사용할 수 있다
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "라떼")); err != nil {
+		t.Errorf("Create() error = %v", err)
+	}
+}
+
+func Test_itemService_Update_version(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Create(ctx, store.StoreSeq, 1, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Find() items = %v, error = %v", items, err)
+	}
+	itemSeq := items[0].ItemSeq
+
+	price := int64(5000)
+	stale := int64(1)
+	tests := []struct {
+		name    string
+		version *int64
+		wantErr error
+	}{
+		{
+			name:    "버전 누락",
+			version: nil,
+			wantErr: apierror.ErrNilItemVersion,
+		},
+		{
+			name:    "수정",
+			version: &stale,
+			wantErr: nil,
+		},
+		{
+			name:    "다른 사용자가 먼저 수정한 버전으로 수정",
+			version: &stale,
+			wantErr: apierror.ErrStaleItem,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Update(ctx, store.StoreSeq, 1, request.UpdateItem{ItemSeq: itemSeq, Price: &price, Version: tt.version})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	item, err := s.Get(ctx, store.StoreSeq, request.GetItem{ItemSeq: itemSeq})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Version != 2 {
+		t.Errorf("Get() version = %d, want %d", item.Version, 2)
+	}
+
+	if err := s.Delete(ctx, store.StoreSeq, 1, itemSeq, stale); !errors.Is(err, apierror.ErrStaleItem) {
+		t.Errorf("Delete() error = %v, wantErr %v", err, apierror.ErrStaleItem)
+	}
+}
+
+func Test_itemService_History(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	store, err := repo.Store().Create(ctx, "카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category := newTestCategory(t, repo, store.StoreSeq)
+
+	other, err := repo.Store().Create(ctx, "다른 카페")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewItemService(repo, api.ItemConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminSeq := int64(7)
+	if err := s.Create(ctx, store.StoreSeq, adminSeq, newTestItem(category, "8801", "아메리카노")); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := findItems(ctx, s, store.StoreSeq)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Find() items = %v, error = %v", items, err)
+	}
+	itemSeq := items[0].ItemSeq
+
+	name := "카페모카"
+	version := items[0].Version
+	if err := s.Update(ctx, store.StoreSeq, adminSeq, request.UpdateItem{ItemSeq: itemSeq, Name: &name, Version: &version}); err != nil {
+		t.Fatal(err)
+	}
+
+	price := int64(5000)
+	version++
+	if err := s.Update(ctx, store.StoreSeq, adminSeq, request.UpdateItem{ItemSeq: itemSeq, Price: &price, Version: &version}); err != nil {
+		t.Fatal(err)
+	}
+
+	version++
+	if err := s.Delete(ctx, store.StoreSeq, adminSeq, itemSeq, version); err != nil {
+		t.Fatal(err)
+	}
+
+	// 휴지통의 상품도 이력을 조회할 수 있다
+	historyTests := []struct {
+		name        string
+		storeSeq    int64
+		req         request.FindItemHistory
+		wantActions []string
+		wantErr     error
+	}{
+		{
+			name:        "최근 이력부터 조회",
+			storeSeq:    store.StoreSeq,
+			req:         request.FindItemHistory{ItemSeq: itemSeq},
+			wantActions: []string{"delete", "update", "update", "create"},
+		},
+		{
+			name:        "조회 개수 제한",
+			storeSeq:    store.StoreSeq,
+			req:         request.FindItemHistory{ItemSeq: itemSeq, Limit: 2},
+			wantActions: []string{"delete", "update"},
+		},
+		{
+			name:     "다른 매장의 상품",
+			storeSeq: other.StoreSeq,
+			req:      request.FindItemHistory{ItemSeq: itemSeq},
+			wantErr:  apierror.ErrForbiddenItem,
+		},
+		{
+			name:     "잘못된 상품",
+			storeSeq: store.StoreSeq,
+			req:      request.FindItemHistory{ItemSeq: 0},
+			wantErr:  apierror.ErrInvalidItem,
+		},
+	}
+	for _, tt := range historyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			revisions, err := s.History(ctx, tt.storeSeq, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("History() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			gotActions := make([]string, 0, len(revisions))
+			for _, revision := range revisions {
+				gotActions = append(gotActions, revision.Action)
+				if revision.AdminSeq != adminSeq {
+					t.Errorf("History() admin_seq = %d, want %d", revision.AdminSeq, adminSeq)
+				}
+			}
+
+			if tt.wantErr == nil && !reflect.DeepEqual(gotActions, tt.wantActions) {
+				t.Errorf("History() actions = %v, want %v", gotActions, tt.wantActions)
+			}
+		})
+	}
+
+	// 변경한 컬럼만 변경 전후 값을 남긴다
+	revisions, err := s.History(ctx, store.StoreSeq, request.FindItemHistory{ItemSeq: itemSeq})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	priceChange, ok := revisions[1].Changes["price"]
+	if len(revisions[1].Changes) != 1 || !ok || string(priceChange.Before) != "4500" || string(priceChange.After) != "5000" {
+		t.Errorf("History() changes = %v", revisions[1].Changes)
+	}
+
+	if _, ok := revisions[2].Changes["name"]; !ok || len(revisions[2].Changes) != 1 {
+		t.Errorf("History() changes = %v", revisions[2].Changes)
+	}
+
+	// 가격이 바뀐 이력은 등록과 가격 수정뿐이며, from 시점에 적용된 가격부터 보여준다
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+	priceTests := []struct {
+		name       string
+		storeSeq   int64
+		req        request.FindItemPrices
+		wantPrices []int64
+		wantErr    error
+	}{
+		{
+			name:       "전체 가격 이력",
+			storeSeq:   store.StoreSeq,
+			req:        request.FindItemPrices{ItemSeq: itemSeq},
+			wantPrices: []int64{4500, 5000},
+		},
+		{
+			name:       "from 시점에 적용된 가격부터",
+			storeSeq:   store.StoreSeq,
+			req:        request.FindItemPrices{ItemSeq: itemSeq, From: &future},
+			wantPrices: []int64{5000},
+		},
+		{
+			name:     "잘못된 기간",
+			storeSeq: store.StoreSeq,
+			req:      request.FindItemPrices{ItemSeq: itemSeq, From: &future, To: &past},
+			wantErr:  apierror.ErrInvalidPeriod,
+		},
+		{
+			name:     "다른 매장의 상품 가격",
+			storeSeq: other.StoreSeq,
+			req:      request.FindItemPrices{ItemSeq: itemSeq},
+			wantErr:  apierror.ErrForbiddenItem,
+		},
+	}
+	for _, tt := range priceTests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices, err := s.Prices(ctx, tt.storeSeq, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Prices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			gotPrices := make([]int64, 0, len(prices))
+			for _, p := range prices {
+				gotPrices = append(gotPrices, p.Price)
+			}
+
+			if tt.wantErr == nil && !reflect.DeepEqual(gotPrices, tt.wantPrices) {
+				t.Errorf("Prices() prices = %v, want %v", gotPrices, tt.wantPrices)
+			}
+		})
+	}
+}
+
+// findItems 기본 정렬로 첫 페이지 상품을 조회한다
+func findItems(ctx context.Context, s ItemService, storeSeq int64) (model.Items, error) {
+	page, err := s.Find(ctx, storeSeq, request.FindItems{Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Items, nil
+}
+
+// newTestCategory 상품을 등록할 수 있도록 매장에 카테고리를 만든다
+func newTestCategory(t *testing.T, repo repository.Repository, storeSeq int64) int64 {
+	t.Helper()
+
+	category, err := dao.NewCategory(storeSeq, 0, "음료", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := repo.Category().Create(context.Background(), *category)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return created.CategorySeq
+}
+
+func newTestItem(category int64, barcode, name string) request.CreateItem {
+	size := request.ItemSizeSmall
+	price := int64(4500)
+	cost := int64(1500)
+	description := "설명"
+	expireDT := time.Now().Add(24 * time.Hour)
+
+	return request.CreateItem{
+		Category:    &category,
+		Barcode:     &barcode,
+		Price:       &price,
+		Cost:        &cost,
+		Name:        &name,
+		Description: &description,
+		ExpireDT:    &expireDT,
+		Size:        &size,
+	}
+}
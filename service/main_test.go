@@ -0,0 +1,16 @@
+package service
+
+import (
+	"testing"
+
+	"hello-cafe/repository"
+	"hello-cafe/repository/memory"
+)
+
+// newTestRepository 테스트마다 비어있는 memory repository 를 만든다.
+// SQL 구현과의 동작 차이는 repositorytest 의 계약 테스트로 검증한다.
+func newTestRepository(t *testing.T) repository.Repository {
+	t.Helper()
+
+	return memory.NewRepository()
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+type MemberService interface {
+	Invite(ctx context.Context, storeSeq int64, member request.InviteMember) (*model.InvitedMember, error)
+	Find(ctx context.Context, storeSeq int64) (model.Members, error)
+	UpdateRole(ctx context.Context, storeSeq int64, adminSeq int64, role model.Role) error
+	Delete(ctx context.Context, storeSeq int64, adminSeq int64) error
+}
+
+type memberService struct {
+	repo         repository.Repository
+	storeService StoreService
+}
+
+func NewMemberService(repo repository.Repository, storeService StoreService) (MemberService, error) {
+	switch {
+	case valid.IsNil(repo):
+		return nil, errors.New("repository is nil")
+	case valid.IsNil(storeService):
+		return nil, errors.New("store service is nil")
+	}
+
+	return &memberService{repo: repo, storeService: storeService}, nil
+}
+
+func (s *memberService) Invite(ctx context.Context, storeSeq int64, member request.InviteMember) (*model.InvitedMember, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	if err := member.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var invited *model.InvitedMember
+
+	// 계정 생성과 매장 소속이 함께 처리되어야 한다
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		admin, err := repo.Admin().GetAdminByPhone(ctx, *member.Phone)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "failed to get admin")
+		}
+
+		existing := !errors.Is(err, gorm.ErrRecordNotFound)
+		if existing && !valid.IsNil(member.Password) {
+			return apierror.ErrExistingAccount
+		}
+
+		if !existing {
+			if admin, err = createAdmin(ctx, repo, member); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		joined, err := repo.StoreMember().Get(ctx, storeSeq, admin.AdminSeq)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "failed to get store member")
+		}
+
+		if !valid.IsNil(joined) {
+			return apierror.ErrDuplicatedMember
+		}
+
+		err = repo.StoreMember().Create(ctx, storeSeq, admin.AdminSeq, *member.Role)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apierror.ErrDuplicatedMember
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "failed to invite member")
+		}
+
+		if joined, err = repo.StoreMember().Get(ctx, storeSeq, admin.AdminSeq); err != nil {
+			return errors.Wrap(err, "failed to get invited member")
+		}
+
+		invited = &model.InvitedMember{
+			Member: model.Member{
+				AdminSeq: admin.AdminSeq,
+				Phone:    admin.Phone,
+				Name:     admin.Name,
+				Role:     joined.Role,
+				RegDT:    joined.RegDT,
+			},
+			ExistingAccount: existing,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return invited, nil
+}
+
+func createAdmin(ctx context.Context, repo repository.Repository, member request.InviteMember) (*dao.Admin, error) {
+	if valid.IsNil(member.Password) {
+		return nil, apierror.ErrNilPassword
+	}
+
+	encryptedPwd, err := hashPassword(*member.Password)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	admin, err := repo.Admin().Create(ctx, *member.Phone, encryptedPwd, member.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create member account")
+	}
+
+	return admin, nil
+}
+
+func (s *memberService) Find(ctx context.Context, storeSeq int64) (model.Members, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	members, err := s.repo.StoreMember().Find(ctx, storeSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find members")
+	}
+
+	result := make(model.Members, 0, len(members))
+	for _, member := range members {
+		result = append(result, s.getMemberFromDAO(member))
+	}
+
+	return result, nil
+}
+
+func (s *memberService) UpdateRole(ctx context.Context, storeSeq int64, adminSeq int64, role model.Role) error {
+	if err := role.Validate(); err != nil || role == model.RoleOwner {
+		return apierror.ErrInvalidRole
+	}
+
+	if _, err := s.getMember(ctx, storeSeq, adminSeq); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.repo.StoreMember().UpdateRole(ctx, storeSeq, adminSeq, role); err != nil {
+		return errors.Wrap(err, "failed to update member role")
+	}
+
+	// 이미 발급된 토큰에도 바뀐 권한이 바로 적용되도록 한다
+	s.storeService.InvalidateRole(storeSeq, adminSeq)
+
+	return nil
+}
+
+// Delete 관리자 계정은 다른 매장에 소속될 수 있으므로 매장에서만 제외한다
+func (s *memberService) Delete(ctx context.Context, storeSeq int64, adminSeq int64) error {
+	if _, err := s.getMember(ctx, storeSeq, adminSeq); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.repo.StoreMember().Delete(ctx, storeSeq, adminSeq); err != nil {
+		return errors.Wrap(err, "failed to delete member")
+	}
+
+	s.storeService.InvalidateRole(storeSeq, adminSeq)
+
+	return nil
+}
+
+// getMember owner 를 제외한 매장 직원만 조회한다
+func (s *memberService) getMember(ctx context.Context, storeSeq int64, adminSeq int64) (*dao.StoreMember, error) {
+	if storeSeq <= 0 {
+		return nil, apierror.ErrInvalidStore
+	}
+
+	member, err := s.repo.StoreMember().Get(ctx, storeSeq, adminSeq)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(err, "failed to get store member")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apierror.ErrNotExistMember
+	}
+
+	if member.Role == model.RoleOwner {
+		return nil, apierror.ErrForbidden
+	}
+
+	return member, nil
+}
+
+func (s *memberService) getMemberFromDAO(member dao.MemberInfo) model.Member {
+	return model.Member{
+		AdminSeq: member.AdminSeq,
+		Phone:    member.Phone,
+		Name:     member.Name,
+		Role:     member.Role,
+		RegDT:    member.RegDT,
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+)
+
+// newTestMemberServices owner(1) 의 매장(1)에 staff(2) 를 초대하고, staff 의 권한을 캐시해 둔다.
+// 다른 매장(2)의 owner(3) 도 함께 가입시킨다.
+func newTestMemberServices(t *testing.T) (MemberService, StoreService) {
+	t.Helper()
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	tokenService, err := NewTokenService(repo, internaljwt.Config{})
+	require.NoError(t, err)
+
+	adminService, err := NewAdminService(repo, tokenService)
+	require.NoError(t, err)
+	_, err = adminService.SignUp(ctx, "010-1111-1111", "12341234", "사장")
+	require.NoError(t, err)
+
+	storeService, err := NewStoreService(repo)
+	require.NoError(t, err)
+
+	memberService, err := NewMemberService(repo, storeService)
+	require.NoError(t, err)
+
+	phone, password, role := "010-2222-2222", "12341234", model.RoleStaff
+	_, err = memberService.Invite(ctx, 1, request.InviteMember{Phone: &phone, Password: &password, Role: &role})
+	require.NoError(t, err)
+
+	_, err = adminService.SignUp(ctx, "010-4444-4444", "12341234", "다른 매장 사장")
+	require.NoError(t, err)
+
+	cached, err := storeService.GetRole(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, model.RoleStaff, cached)
+
+	return memberService, storeService
+}
+
+func Test_memberService_Invite(t *testing.T) {
+	ctx := context.Background()
+
+	strPtr := func(s string) *string { return &s }
+	staff := model.RoleStaff
+
+	tests := []struct {
+		name         string
+		storeSeq     int64
+		member       request.InviteMember
+		wantErr      error
+		wantExisting bool
+		wantName     string
+	}{
+		{
+			name:     "새 계정을 만들어 초대",
+			storeSeq: 1,
+			member:   request.InviteMember{Phone: strPtr("010-3333-3333"), Password: strPtr("12341234"), Name: "직원", Role: &staff},
+			wantName: "직원",
+		},
+		{
+			name:     "가입되지 않은 계정은 비밀번호 필요",
+			storeSeq: 1,
+			member:   request.InviteMember{Phone: strPtr("010-3333-3333"), Role: &staff},
+			wantErr:  apierror.ErrNilPassword,
+		},
+		{
+			name:         "이미 가입된 계정을 매장에 추가",
+			storeSeq:     1,
+			member:       request.InviteMember{Phone: strPtr("010-4444-4444"), Name: "다른 이름", Role: &staff},
+			wantExisting: true,
+			wantName:     "다른 매장 사장",
+		},
+		{
+			name:     "이미 가입된 계정에 비밀번호를 입력하면 거부",
+			storeSeq: 1,
+			member:   request.InviteMember{Phone: strPtr("010-4444-4444"), Password: strPtr("43214321"), Role: &staff},
+			wantErr:  apierror.ErrExistingAccount,
+		},
+		{
+			name:     "이미 소속된 직원",
+			storeSeq: 1,
+			member:   request.InviteMember{Phone: strPtr("010-2222-2222"), Role: &staff},
+			wantErr:  apierror.ErrDuplicatedMember,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberService, _ := newTestMemberServices(t)
+
+			got, err := memberService.Invite(ctx, tt.storeSeq, tt.member)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Invite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, *tt.member.Phone, got.Phone)
+			require.Equal(t, tt.wantName, got.Name)
+			require.Equal(t, tt.wantExisting, got.ExistingAccount)
+		})
+	}
+}
+
+func Test_memberService_UpdateRole(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		storeSeq int64
+		adminSeq int64
+		role     model.Role
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantErr  error
+		wantRole model.Role
+	}{
+		{
+			name:     "권한 변경은 캐시된 권한에 바로 반영",
+			args:     args{storeSeq: 1, adminSeq: 2, role: model.RoleManager},
+			wantRole: model.RoleManager,
+		},
+		{
+			name:     "owner 로 변경 불가",
+			args:     args{storeSeq: 1, adminSeq: 2, role: model.RoleOwner},
+			wantErr:  apierror.ErrInvalidRole,
+			wantRole: model.RoleStaff,
+		},
+		{
+			name:     "잘못된 권한",
+			args:     args{storeSeq: 1, adminSeq: 2, role: "admin"},
+			wantErr:  apierror.ErrInvalidRole,
+			wantRole: model.RoleStaff,
+		},
+		{
+			name:     "owner 의 권한은 변경 불가",
+			args:     args{storeSeq: 1, adminSeq: 1, role: model.RoleStaff},
+			wantErr:  apierror.ErrForbidden,
+			wantRole: model.RoleStaff,
+		},
+		{
+			name:     "매장에 없는 직원",
+			args:     args{storeSeq: 1, adminSeq: 3, role: model.RoleManager},
+			wantErr:  apierror.ErrNotExistMember,
+			wantRole: model.RoleStaff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberService, storeService := newTestMemberServices(t)
+
+			err := memberService.UpdateRole(ctx, tt.args.storeSeq, tt.args.adminSeq, tt.args.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			role, err := storeService.GetRole(ctx, 1, 2)
+			require.NoError(t, err)
+
+			if role != tt.wantRole {
+				t.Errorf("GetRole() got = %v, want %v", role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func Test_memberService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		storeSeq int64
+		adminSeq int64
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     error
+		wantRoleErr error
+	}{
+		{
+			name:        "삭제된 직원은 캐시된 권한도 바로 거부",
+			args:        args{storeSeq: 1, adminSeq: 2},
+			wantRoleErr: apierror.ErrForbiddenStore,
+		},
+		{
+			name:    "owner 는 삭제 불가",
+			args:    args{storeSeq: 1, adminSeq: 1},
+			wantErr: apierror.ErrForbidden,
+		},
+		{
+			name:    "매장에 없는 직원",
+			args:    args{storeSeq: 1, adminSeq: 3},
+			wantErr: apierror.ErrNotExistMember,
+		},
+		{
+			name:    "잘못된 매장",
+			args:    args{storeSeq: 0, adminSeq: 2},
+			wantErr: apierror.ErrInvalidStore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberService, storeService := newTestMemberServices(t)
+
+			err := memberService.Delete(ctx, tt.args.storeSeq, tt.args.adminSeq)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			_, err = storeService.GetRole(ctx, 1, 2)
+			if !errors.Is(err, tt.wantRoleErr) {
+				t.Errorf("GetRole() error = %v, wantErr %v", err, tt.wantRoleErr)
+			}
+		})
+	}
+}
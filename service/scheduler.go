@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"hello-cafe/internal/api"
+	"hello-cafe/internal/scheduler"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+)
+
+// JobItemExpiry 유통기한이 지난 상품 판매 중지 작업
+const JobItemExpiry = "item.expiry"
+
+// SchedulerService 서버가 시작할 때 Start 하고, 종료할 때 Stop 으로 실행 중인 작업이 끝나기를 기다린다
+type SchedulerService interface {
+	Start(ctx context.Context)
+	Stop(ctx context.Context) error
+	Status() model.SchedulerJobs
+}
+
+type schedulerService struct {
+	scheduler *scheduler.Scheduler
+}
+
+func NewSchedulerService(expiryService ExpiryService, cfg api.ExpiryConfig) (SchedulerService, error) {
+	if valid.IsNil(expiryService) {
+		return nil, errors.New("expiry service is nil")
+	}
+
+	s := scheduler.New()
+	if err := s.Add(scheduler.Job{
+		Name:     JobItemExpiry,
+		Interval: cfg.WithDefaults().Interval,
+		Run: func(ctx context.Context) (interface{}, error) {
+			return expiryService.Run(ctx)
+		},
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schedulerService{scheduler: s}, nil
+}
+
+func (s *schedulerService) Start(ctx context.Context) {
+	s.scheduler.Start(ctx)
+}
+
+func (s *schedulerService) Stop(ctx context.Context) error {
+	return errors.WithStack(s.scheduler.Stop(ctx))
+}
+
+func (s *schedulerService) Status() model.SchedulerJobs {
+	status := s.scheduler.Status()
+
+	result := make(model.SchedulerJobs, 0, len(status))
+	for _, job := range status {
+		result = append(result, getSchedulerJob(job))
+	}
+
+	return result
+}
+
+func getSchedulerJob(status scheduler.Status) model.SchedulerJob {
+	job := model.SchedulerJob{
+		Name:           status.Name,
+		IntervalMillis: status.Interval.Milliseconds(),
+		Running:        status.Running,
+		Runs:           status.Runs,
+		Failures:       status.Failures,
+		LastMillis:     status.LastDuration.Milliseconds(),
+		LastResult:     status.LastResult,
+		LastError:      status.LastError,
+	}
+
+	if !status.LastStartedAt.IsZero() {
+		job.LastStartedDT = &status.LastStartedAt
+	}
+	if !status.LastFinishedAt.IsZero() {
+		job.LastFinishedDT = &status.LastFinishedAt
+	}
+	if !status.NextRunAt.IsZero() {
+		job.NextRunDT = &status.NextRunAt
+	}
+
+	return job
+}
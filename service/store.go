@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/cache"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+const (
+	// roleCacheTTL 매장 내 권한의 캐시 유지 시간
+	// 다른 서버에서 변경된 권한은 최대 이 시간 동안 이전 권한으로 사용될 수 있다
+	roleCacheTTL = 10 * time.Second
+)
+
+type StoreService interface {
+	Create(ctx context.Context, adminSeq int64, store request.CreateStore) (*model.Store, error)
+	Find(ctx context.Context, adminSeq int64) (model.Stores, error)
+	GetRole(ctx context.Context, storeSeq, adminSeq int64) (model.Role, error)
+	InvalidateRole(storeSeq, adminSeq int64)
+}
+
+type storeService struct {
+	repo  repository.Repository
+	roles *cache.TTLCache
+}
+
+func NewStoreService(repo repository.Repository) (StoreService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &storeService{repo: repo, roles: cache.NewTTLCache()}, nil
+}
+
+// Create 매장을 만든 관리자는 그 매장의 owner 가 되며, 기본 카테고리를 함께 만든다
+func (s *storeService) Create(ctx context.Context, adminSeq int64, store request.CreateStore) (*model.Store, error) {
+	if adminSeq <= 0 {
+		return nil, apierror.ErrInvalidAdmin
+	}
+
+	if err := store.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var created *dao.Store
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) (err error) {
+		if created, err = repo.Store().Create(ctx, *store.Name); err != nil {
+			return errors.Wrap(err, "failed to create store")
+		}
+
+		if err := repo.StoreMember().Create(ctx, created.StoreSeq, adminSeq, model.RoleOwner); err != nil {
+			return errors.Wrap(err, "failed to join store")
+		}
+
+		return createDefaultCategories(ctx, repo, created.StoreSeq)
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Store{
+		StoreSeq: created.StoreSeq,
+		Name:     created.Name,
+		Role:     model.RoleOwner,
+		RegDT:    created.RegDT,
+	}, nil
+}
+
+func (s *storeService) Find(ctx context.Context, adminSeq int64) (model.Stores, error) {
+	if adminSeq <= 0 {
+		return nil, apierror.ErrInvalidAdmin
+	}
+
+	stores, err := s.repo.Store().FindByAdmin(ctx, adminSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find stores")
+	}
+
+	result := make(model.Stores, 0, len(stores))
+	for _, store := range stores {
+		result = append(result, model.Store{
+			StoreSeq: store.StoreSeq,
+			Name:     store.Name,
+			Role:     store.Role,
+			RegDT:    store.RegDT,
+		})
+	}
+
+	return result, nil
+}
+
+// GetRole 관리자의 매장 내 권한을 조회하며, 소속되지 않은 매장이면 ErrForbiddenStore 를 반환한다
+func (s *storeService) GetRole(ctx context.Context, storeSeq, adminSeq int64) (model.Role, error) {
+	if storeSeq <= 0 {
+		return "", apierror.ErrInvalidStore
+	}
+
+	key := roleCacheKey(storeSeq, adminSeq)
+	if v, ok := s.roles.Get(key); ok {
+		return v.(model.Role), nil
+	}
+
+	member, err := s.repo.StoreMember().Get(ctx, storeSeq, adminSeq)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", errors.Wrap(err, "failed to get store member")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", apierror.ErrForbiddenStore
+	}
+
+	s.roles.Set(key, member.Role, roleCacheTTL)
+
+	return member.Role, nil
+}
+
+// InvalidateRole 권한이 바뀌거나 매장에서 제외된 관리자의 캐시된 권한을 지운다
+func (s *storeService) InvalidateRole(storeSeq, adminSeq int64) {
+	s.roles.Delete(roleCacheKey(storeSeq, adminSeq))
+}
+
+func roleCacheKey(storeSeq, adminSeq int64) string {
+	return fmt.Sprintf("%d:%d", storeSeq, adminSeq)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/model/request"
+	"hello-cafe/repository"
+)
+
+func Test_storeService_defaultCategories(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		create func(t *testing.T, repo repository.Repository) int64 // 만든 매장
+	}{
+		{
+			name: "회원가입으로 만든 매장",
+			create: func(t *testing.T, repo repository.Repository) int64 {
+				tokenService, err := NewTokenService(repo, internaljwt.Config{})
+				require.NoError(t, err)
+
+				adminService, err := NewAdminService(repo, tokenService)
+				require.NoError(t, err)
+
+				adminSeq, err := adminService.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+				require.NoError(t, err)
+
+				stores, err := repo.Store().FindByAdmin(ctx, adminSeq)
+				require.NoError(t, err)
+				require.Len(t, stores, 1)
+
+				return stores[0].StoreSeq
+			},
+		},
+		{
+			name: "매장 등록",
+			create: func(t *testing.T, repo repository.Repository) int64 {
+				storeService, err := NewStoreService(repo)
+				require.NoError(t, err)
+
+				name := "두번째 매장"
+				store, err := storeService.Create(ctx, 1, request.CreateStore{Name: &name})
+				require.NoError(t, err)
+
+				return store.StoreSeq
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			storeSeq := tt.create(t, repo)
+
+			categories, err := repo.Category().Find(ctx, storeSeq)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(categories))
+			for _, category := range categories {
+				if category.ParentSeq != 0 {
+					t.Errorf("category(%s) parent = %d, want 0", category.Name, category.ParentSeq)
+				}
+				got = append(got, category.Name)
+			}
+			if want := []string{"음료", "음식"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("categories = %v, want %v", got, want)
+			}
+		})
+	}
+}
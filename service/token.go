@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"hello-cafe/internal/apierror"
+	"hello-cafe/internal/cache"
+	"hello-cafe/internal/internaljwt"
+	"hello-cafe/internal/valid"
+	"hello-cafe/model"
+	"hello-cafe/repository"
+	"hello-cafe/repository/dao"
+)
+
+const (
+	// notRevokedCacheTTL 로그아웃 되지 않은 토큰의 캐시 유지 시간
+	// 다른 서버에서 로그아웃 된 토큰은 최대 이 시간 동안 사용될 수 있다
+	notRevokedCacheTTL = 10 * time.Second
+)
+
+type TokenService interface {
+	Issue(ctx context.Context, admin dao.Admin) (*model.Token, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.Token, error)
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	Revoke(ctx context.Context, adminSeq int64, tokenID string, expireDT time.Time, refreshToken string) error
+	SweepExpired(ctx context.Context) (int64, error)
+	RunSweeper(ctx context.Context, interval time.Duration)
+}
+
+type tokenService struct {
+	repo    repository.Repository
+	cfg     internaljwt.Config
+	revoked *cache.TTLCache
+}
+
+func NewTokenService(repo repository.Repository, cfg internaljwt.Config) (TokenService, error) {
+	if valid.IsNil(repo) {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &tokenService{
+		repo:    repo,
+		cfg:     cfg.WithDefaults(),
+		revoked: cache.NewTTLCache(),
+	}, nil
+}
+
+// Issue 로그인 시 새로운 refresh token 계열을 만들어 토큰을 발급한다
+func (s *tokenService) Issue(ctx context.Context, admin dao.Admin) (*model.Token, error) {
+	familyID, err := internaljwt.NewTokenID()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create token family")
+	}
+
+	return s.issue(ctx, admin, familyID)
+}
+
+func (s *tokenService) issue(ctx context.Context, admin dao.Admin, familyID string) (*model.Token, error) {
+	// 가장 먼저 소속된 매장을 기본 매장으로 사용하며, 다른 매장은 요청 헤더로 선택한다
+	stores, err := s.repo.Store().FindByAdmin(ctx, admin.AdminSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find stores")
+	}
+
+	if len(stores) == 0 {
+		return nil, apierror.ErrNoStore
+	}
+
+	accessToken, err := internaljwt.CreateJWT(internaljwt.Identity{
+		AdminSeq: admin.AdminSeq,
+		StoreSeq: stores[0].StoreSeq,
+		Role:     string(stores[0].Role),
+		Phone:    admin.Phone,
+		FamilyID: familyID,
+	}, s.cfg.AccessTokenTTL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create jwt token")
+	}
+
+	refreshToken, err := internaljwt.NewRefreshToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create refresh token")
+	}
+
+	now := time.Now()
+	hash := internaljwt.HashRefreshToken(refreshToken)
+	if err := s.repo.Refresh().Create(ctx, admin.AdminSeq, familyID, hash, now.Add(s.cfg.RefreshTokenTTL)); err != nil {
+		return nil, errors.Wrap(err, "failed to save refresh token")
+	}
+
+	return &model.Token{
+		AdminSeq:     admin.AdminSeq,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpireDT:     now.Add(s.cfg.AccessTokenTTL),
+	}, nil
+}
+
+// Refresh refresh token 은 한 번만 사용할 수 있으며, 사용할 때마다 새로 발급한다.
+// 이미 사용된 토큰이 다시 들어오면 탈취된 것으로 보고 같은 계열의 토큰을 모두 폐기한다.
+func (s *tokenService) Refresh(ctx context.Context, refreshToken string) (*model.Token, error) {
+	if refreshToken == "" {
+		return nil, apierror.ErrNilRefreshToken
+	}
+
+	stored, err := s.repo.Refresh().GetByHash(ctx, internaljwt.HashRefreshToken(refreshToken))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(err, "failed to get refresh token")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apierror.ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	switch {
+	case stored.RevokedDT != nil:
+		return nil, apierror.ErrInvalidRefreshToken
+	case stored.UsedDT != nil:
+		return nil, s.revokeReusedFamily(ctx, stored.FamilyID, now)
+	case now.After(stored.ExpireDT):
+		return nil, apierror.ErrInvalidRefreshToken
+	}
+
+	marked, err := s.repo.Refresh().MarkUsed(ctx, stored.TokenSeq, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to use refresh token")
+	}
+
+	// 동시에 같은 토큰으로 요청이 들어온 경우
+	if !marked {
+		return nil, s.revokeReusedFamily(ctx, stored.FamilyID, now)
+	}
+
+	admin, err := s.repo.Admin().Get(ctx, stored.AdminSeq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get admin")
+	}
+
+	// 소속 매장이나 권한이 바뀌었을 수 있으므로 관리자 정보를 다시 읽어 발급한다
+	return s.issue(ctx, *admin, stored.FamilyID)
+}
+
+func (s *tokenService) revokeReusedFamily(ctx context.Context, familyID string, now time.Time) error {
+	if err := s.repo.Refresh().RevokeFamily(ctx, familyID, now); err != nil {
+		return errors.Wrap(err, "failed to revoke reused refresh token family")
+	}
+
+	// 이 계열로 발급된 access token 은 최대 AccessTokenTTL 동안 남아있으므로 그동안 거부한다
+	s.revoked.Set(familyCacheKey(familyID), true, s.cfg.AccessTokenTTL)
+
+	logrus.Warnf("refresh token reuse detected, family(%s) revoked", familyID)
+
+	return apierror.ErrReusedRefreshToken
+}
+
+func (s *tokenService) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	if v, ok := s.revoked.Get(tokenID); ok {
+		return v.(bool), nil
+	}
+
+	logoutToken, err := s.repo.Logout().GetLogoutToken(ctx, tokenID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, errors.Wrap(err, "failed to get logout token")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.revoked.Set(tokenID, false, notRevokedCacheTTL)
+		return false, nil
+	}
+
+	s.revoked.Set(tokenID, true, time.Until(logoutToken.ExpireDT))
+
+	return true, nil
+}
+
+// IsFamilyRevoked 재사용이 감지되거나 로그아웃으로 폐기된 refresh token 계열에서 발급된 access token 인지 확인한다
+func (s *tokenService) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	key := familyCacheKey(familyID)
+	if v, ok := s.revoked.Get(key); ok {
+		return v.(bool), nil
+	}
+
+	revoked, err := s.repo.Refresh().IsFamilyRevoked(ctx, familyID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check refresh token family")
+	}
+
+	if !revoked {
+		s.revoked.Set(key, false, notRevokedCacheTTL)
+		return false, nil
+	}
+
+	s.revoked.Set(key, true, s.cfg.AccessTokenTTL)
+
+	return true, nil
+}
+
+func familyCacheKey(familyID string) string {
+	return "family:" + familyID
+}
+
+// Revoke access token 을 거부 목록에 추가하고, refresh token 이 있으면 같은 계열을 함께 폐기한다.
+// 둘 중 하나라도 실패하면 모두 rollback 한다.
+func (s *tokenService) Revoke(ctx context.Context, adminSeq int64, tokenID string, expireDT time.Time, refreshToken string) error {
+	err := s.repo.WithTx(ctx, func(repo repository.Repository) error {
+		if err := repo.Logout().Create(ctx, adminSeq, tokenID, expireDT); err != nil {
+			return errors.Wrap(err, "failed to create logout token")
+		}
+
+		if refreshToken == "" {
+			return nil
+		}
+
+		stored, err := repo.Refresh().GetByHash(ctx, internaljwt.HashRefreshToken(refreshToken))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "failed to get refresh token")
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) || stored.AdminSeq != adminSeq {
+			return apierror.ErrInvalidRefreshToken
+		}
+
+		if err := repo.Refresh().RevokeFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+			return errors.Wrap(err, "failed to revoke refresh token family")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// commit 된 이후에만 캐시에 반영한다
+	s.revoked.Set(tokenID, true, time.Until(expireDT))
+
+	return nil
+}
+
+// SweepExpired 만료된 토큰을 거부 목록과 refresh token 저장소에서 삭제한다
+func (s *tokenService) SweepExpired(ctx context.Context) (int64, error) {
+	s.revoked.DeleteExpired()
+
+	now := time.Now()
+	deleted, err := s.repo.Logout().DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete expired logout tokens")
+	}
+
+	deletedRefresh, err := s.repo.Refresh().DeleteExpired(ctx, now)
+	if err != nil {
+		return deleted, errors.Wrap(err, "failed to delete expired refresh tokens")
+	}
+
+	return deleted + deletedRefresh, nil
+}
+
+// RunSweeper ctx 가 종료될 때까지 interval 마다 SweepExpired 를 실행한다
+func (s *tokenService) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.SweepExpired(ctx)
+			if err != nil {
+				logrus.Errorf("failed to sweep logout tokens: %+v", err)
+				continue
+			}
+
+			logrus.Debugf("swept %d expired tokens", deleted)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"hello-cafe/internal/internaljwt"
+)
+
+func Test_tokenService_IsRevoked(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		revokeTokenID string
+		expireDT      time.Time
+		tokenID       string
+		sweep         bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "로그아웃 되지 않은 토큰",
+			args: args{
+				tokenID: "active",
+			},
+			want: false,
+		},
+		{
+			name: "로그아웃 된 토큰",
+			args: args{
+				revokeTokenID: "revoked",
+				expireDT:      time.Now().Add(time.Minute),
+				tokenID:       "revoked",
+			},
+			want: true,
+		},
+		{
+			name: "캐시가 만료되어도 삭제되기 전까지는 DB 에서 거부",
+			args: args{
+				revokeTokenID: "expired",
+				expireDT:      time.Now().Add(-time.Second),
+				tokenID:       "expired",
+			},
+			want: true,
+		},
+		{
+			name: "만료되어 삭제된 토큰",
+			args: args{
+				revokeTokenID: "swept",
+				expireDT:      time.Now().Add(-time.Second),
+				tokenID:       "swept",
+				sweep:         true,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			s, err := NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			if tt.args.revokeTokenID != "" {
+				require.NoError(t, s.Revoke(ctx, 1, tt.args.revokeTokenID, tt.args.expireDT, ""))
+			}
+
+			if tt.args.sweep {
+				_, err := s.SweepExpired(ctx)
+				require.NoError(t, err)
+			}
+
+			got, err := s.IsRevoked(ctx, tt.args.tokenID)
+			require.NoError(t, err)
+
+			if got != tt.want {
+				t.Errorf("IsRevoked() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tokenService_Revoke(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		adminSeq     int64
+		refreshToken string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantErr          bool
+		wantRevoked      bool
+		wantFamilyActive bool
+	}{
+		{
+			name: "access token 만 로그아웃",
+			args: args{
+				adminSeq: 1,
+			},
+			wantRevoked:      true,
+			wantFamilyActive: true,
+		},
+		{
+			name: "refresh token 계열도 함께 폐기",
+			args: args{
+				adminSeq:     1,
+				refreshToken: "refresh",
+			},
+			wantRevoked:      true,
+			wantFamilyActive: false,
+		},
+		{
+			name: "다른 관리자의 refresh token 이면 모두 rollback",
+			args: args{
+				adminSeq:     2,
+				refreshToken: "refresh",
+			},
+			wantErr:          true,
+			wantRevoked:      false,
+			wantFamilyActive: true,
+		},
+		{
+			name: "없는 refresh token 이면 모두 rollback",
+			args: args{
+				adminSeq:     1,
+				refreshToken: "unknown",
+			},
+			wantErr:          true,
+			wantRevoked:      false,
+			wantFamilyActive: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			s, err := NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			hash := internaljwt.HashRefreshToken("refresh")
+			require.NoError(t, repo.Refresh().Create(ctx, 1, "family", hash, time.Now().Add(time.Hour)))
+
+			err = s.Revoke(ctx, tt.args.adminSeq, "token", time.Now().Add(time.Minute), tt.args.refreshToken)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			revoked, err := s.IsRevoked(ctx, "token")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRevoked, revoked)
+
+			stored, err := repo.Refresh().GetByHash(ctx, hash)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFamilyActive, stored.RevokedDT == nil)
+		})
+	}
+}
+
+func Test_tokenService_SweepExpired(t *testing.T) {
+	ctx := context.Background()
+
+	type token struct {
+		id       string
+		expireIn time.Duration
+	}
+	tests := []struct {
+		name        string
+		logout      []token
+		refresh     []token
+		wantDeleted int64
+		wantKept    []string
+	}{
+		{
+			name:        "삭제할 토큰 없음",
+			wantDeleted: 0,
+		},
+		{
+			name: "만료된 토큰만 삭제",
+			logout: []token{
+				{id: "expired", expireIn: -time.Second},
+				{id: "active", expireIn: time.Minute},
+			},
+			refresh: []token{
+				{id: "expired-refresh", expireIn: -time.Second},
+				{id: "active-refresh", expireIn: time.Minute},
+			},
+			wantDeleted: 2,
+			wantKept:    []string{"active"},
+		},
+		{
+			name: "만료 직전 토큰은 유지",
+			logout: []token{
+				{id: "almost", expireIn: time.Second},
+			},
+			wantDeleted: 0,
+			wantKept:    []string{"almost"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			s, err := NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			now := time.Now()
+			for _, tk := range tt.logout {
+				require.NoError(t, repo.Logout().Create(ctx, 1, tk.id, now.Add(tk.expireIn)))
+			}
+
+			for _, tk := range tt.refresh {
+				require.NoError(t, repo.Refresh().Create(ctx, 1, tk.id, internaljwt.HashRefreshToken(tk.id), now.Add(tk.expireIn)))
+			}
+
+			deleted, err := s.SweepExpired(ctx)
+			require.NoError(t, err)
+
+			if deleted != tt.wantDeleted {
+				t.Errorf("SweepExpired() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+
+			for _, id := range tt.wantKept {
+				revoked, err := s.IsRevoked(ctx, id)
+				require.NoError(t, err)
+				require.True(t, revoked, "token(%s) should be kept", id)
+			}
+		})
+	}
+}
+
+func Test_tokenService_IsFamilyRevoked(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		reuse   bool
+		signOut bool
+		want    bool
+	}{
+		{
+			name: "사용 중인 계열",
+			want: false,
+		},
+		{
+			name:  "refresh token 재사용으로 폐기된 계열",
+			reuse: true,
+			want:  true,
+		},
+		{
+			name:    "refresh token 과 함께 로그아웃 된 계열",
+			signOut: true,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			s, err := NewTokenService(repo, internaljwt.Config{})
+			require.NoError(t, err)
+
+			adminService, err := NewAdminService(repo, s)
+			require.NoError(t, err)
+			_, err = adminService.SignUp(ctx, "010-1234-1111", "12341234", "홍길동")
+			require.NoError(t, err)
+
+			token, err := adminService.SignIn(ctx, "010-1234-1111", "12341234")
+			require.NoError(t, err)
+
+			claims, err := internaljwt.ParseJWT(token.AccessToken)
+			require.NoError(t, err)
+
+			if tt.reuse {
+				_, err := s.Refresh(ctx, token.RefreshToken)
+				require.NoError(t, err)
+
+				_, err = s.Refresh(ctx, token.RefreshToken)
+				require.Error(t, err)
+			}
+
+			if tt.signOut {
+				require.NoError(t, s.Revoke(ctx, claims.AdminSeq, claims.Id, time.Unix(claims.ExpiresAt, 0), token.RefreshToken))
+			}
+
+			got, err := s.IsFamilyRevoked(ctx, claims.FamilyID)
+			require.NoError(t, err)
+
+			if got != tt.want {
+				t.Errorf("IsFamilyRevoked() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}